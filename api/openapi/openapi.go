@@ -0,0 +1,10 @@
+// Package openapi embeds the OpenAPI v2 spec of the REST gateway. The spec is
+// generated from api/*.proto by protoc-gen-openapiv2 (make generate); a unit
+// test in this package fails when it or the generated Go code no longer
+// matches the protos.
+package openapi
+
+import _ "embed"
+
+//go:embed api.swagger.json
+var Spec []byte
@@ -0,0 +1,157 @@
+//go:build unit
+// +build unit
+
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// root is the module root, where the plugins write their files as buf
+// generate does with out: ".".
+const root = "../.."
+
+// plugins are the ones in buf.gen.yaml, at the versions in go.mod. Their
+// parameters must match the options there.
+var plugins = []struct {
+	pkg, parameter, out string
+}{
+	{pkg: "google.golang.org/protobuf/cmd/protoc-gen-go", out: root},
+	{pkg: "google.golang.org/grpc/cmd/protoc-gen-go-grpc", out: root},
+	{pkg: "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-grpc-gateway", out: root},
+	{
+		pkg:       "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2",
+		parameter: "allow_merge=true,merge_file_name=api,ignore_comments=true",
+		out:       ".",
+	},
+}
+
+// TestGeneratedMatchesProtos compiles api/*.proto, runs the plugins of
+// buf.gen.yaml on them and compares what they generate with the files in the
+// tree. Run "make generate" when it fails.
+func TestGeneratedMatchesProtos(t *testing.T) {
+	request := compileProtos(t)
+
+	for _, plugin := range plugins {
+		plugin := plugin
+		t.Run(filepath.Base(plugin.pkg), func(t *testing.T) {
+			req := proto.Clone(request).(*pluginpb.CodeGeneratorRequest)
+			if plugin.parameter != "" {
+				req.Parameter = proto.String(plugin.parameter)
+			}
+
+			files := runPlugin(t, plugin.pkg, req)
+			require.NotEmpty(t, files)
+			for _, file := range files {
+				want, err := os.ReadFile(filepath.Join(plugin.out, file.GetName()))
+				require.NoError(t, err, "%s is not generated, run make generate", file.GetName())
+				if filepath.Ext(file.GetName()) == ".json" {
+					assert.JSONEq(t, file.GetContent(), string(want), "%s is out of date, run make generate", file.GetName())
+				} else {
+					assert.Equal(t, file.GetContent(), string(want), "%s is out of date, run make generate", file.GetName())
+				}
+			}
+		})
+	}
+}
+
+// compileProtos parses api/*.proto from source. Their imports outside api
+// come from the descriptors the annotation packages register.
+func compileProtos(t *testing.T) *pluginpb.CodeGeneratorRequest {
+	paths, err := filepath.Glob("../*.proto")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	var names []string
+	for _, path := range paths {
+		names = append(names, filepath.Base(path))
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(protocompile.CompositeResolver{
+			&protocompile.SourceResolver{ImportPaths: []string{".."}},
+			protocompile.ResolverFunc(func(path string) (protocompile.SearchResult, error) {
+				file, err := protoregistry.GlobalFiles.FindFileByPath(path)
+				return protocompile.SearchResult{Desc: file}, err
+			}),
+		}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+	compiled, err := compiler.Compile(context.Background(), names...)
+	require.NoError(t, err)
+
+	req := &pluginpb.CodeGeneratorRequest{FileToGenerate: names}
+	var files []protoreflect.FileDescriptor
+	for _, file := range compiled {
+		files = append(files, file)
+	}
+	req.ProtoFile = withImports(files)
+	return req
+}
+
+// runPlugin builds the plugin in pkg and returns the files it generates for
+// req.
+func runPlugin(t *testing.T, pkg string, req *pluginpb.CodeGeneratorRequest) []*pluginpb.CodeGeneratorResponse_File {
+	plugin := filepath.Join(t.TempDir(), filepath.Base(pkg))
+	build := exec.Command("go", "build", "-o", plugin, pkg)
+	out, err := build.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	in, err := proto.Marshal(req)
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(plugin)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	require.NoError(t, cmd.Run(), stderr.String())
+
+	var resp pluginpb.CodeGeneratorResponse
+	require.NoError(t, proto.Unmarshal(stdout.Bytes(), &resp))
+	require.Empty(t, resp.GetError())
+	return resp.File
+}
+
+// withImports returns files and everything they import, each file after its
+// imports, as CodeGeneratorRequest wants them.
+func withImports(files []protoreflect.FileDescriptor) []*descriptorpb.FileDescriptorProto {
+	var result []*descriptorpb.FileDescriptorProto
+	seen := map[string]bool{}
+
+	var visit func(file protoreflect.FileDescriptor)
+	visit = func(file protoreflect.FileDescriptor) {
+		if seen[file.Path()] {
+			return
+		}
+		seen[file.Path()] = true
+
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			visit(imports.Get(i).FileDescriptor)
+		}
+		result = append(result, protodesc.ToFileDescriptorProto(file))
+	}
+
+	for _, file := range files {
+		visit(file)
+	}
+	return result
+}
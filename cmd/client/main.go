@@ -8,10 +8,13 @@ import (
 
 	pb "flash-card-manager/internal/app/grpc"
 	"flash-card-manager/internal/app/handlers/utils"
+	"flash-card-manager/internal/app/interceptors"
+	"flash-card-manager/internal/app/tlsconfig"
+	"flash-card-manager/internal/config"
 	"flash-card-manager/pkg/logger"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 func main() {
@@ -19,25 +22,59 @@ func main() {
 
 	logger.Init()
 
-	addr := flag.String("addr", "localhost:9000", "the address to connect to the gRPC server")
+	loader := config.NewLoader(flag.CommandLine)
+	idempotencyKey := flag.String("idempotency-key", "", "the key that makes a retried create safe to repeat")
+	noCache := flag.Bool("no-cache", false, "read past the server's cache")
 	flag.Parse()
 
+	cfg, err := loader.Load()
+	if err != nil {
+		logger.Errorf(ctx, "Failed to load configuration: %v", err)
+		os.Exit(1)
+	}
+	if loader.PrintRequested() {
+		cfg.Print(os.Stdout)
+		return
+	}
+
+	log, err := logger.New(cfg.Log.Level, cfg.Log.Encoding)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to create logger: %v", err)
+		os.Exit(1)
+	}
+	defer log.Sync()
+	logger.SetGlobal(log)
+
+	if *idempotencyKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, interceptors.IdempotencyKeyHeader, *idempotencyKey)
+	}
+	if *noCache {
+		ctx = metadata.AppendToOutgoingContext(ctx, interceptors.CacheControlHeader, "no-cache")
+	}
+
 	if len(flag.Args()) < 1 {
 		fmt.Println("Usage: go run cmd/client/main.go -addr=localhost:9000 [command] [data]")
 		os.Exit(1)
 	}
 
-	conn, err := grpc.Dial(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds, err := tlsconfig.DialCredentials(cfg.GRPC)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to load TLS configuration: %v", err)
+		os.Exit(1)
+	}
+
+	conn, err := grpc.Dial(cfg.GRPC.Target, grpc.WithTransportCredentials(creds))
 	if err != nil {
-		logger.Errorf(ctx, "Could not connect to %s: %v", *addr, err)
+		logger.Errorf(ctx, "Could not connect to %s: %v", cfg.GRPC.Target, err)
 		os.Exit(1)
 	}
 	defer conn.Close()
 
 	deckClient := pb.NewDeckServiceClient(conn)
 	cardClient := pb.NewCardServiceClient(conn)
+	trashClient := pb.NewTrashServiceClient(conn)
 
-	if err := utils.HandleCommand(ctx, deckClient, cardClient, flag.Arg(0), flag.Args()[1:]); err != nil {
+	if err := utils.HandleCommand(ctx, deckClient, cardClient, trashClient, flag.Arg(0), flag.Args()[1:]); err != nil {
 		logger.Errorf(ctx, "Error handling command: %v", err)
 		os.Exit(1)
 	}
@@ -2,73 +2,430 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
 	"fmt"
 	pb "flash-card-manager/internal/app/grpc"
+	"flash-card-manager/internal/app/gateway"
 	"flash-card-manager/internal/app/handlers"
-	"flash-card-manager/internal/app/handlers/utils"
+	"flash-card-manager/internal/app/health"
+	"flash-card-manager/internal/app/interceptors"
+	"flash-card-manager/internal/app/jobs"
+	"flash-card-manager/internal/app/lifecycle"
+	"flash-card-manager/internal/app/migrate"
+	"flash-card-manager/internal/app/tlsconfig"
+	"flash-card-manager/internal/app/tracing"
+	"flash-card-manager/internal/app/validation"
+	"flash-card-manager/internal/config"
 	"flash-card-manager/internal/infrastructure/kafka"
 	"flash-card-manager/pkg/db"
 	"flash-card-manager/pkg/logger"
+	"flash-card-manager/pkg/metrics"
+	"flash-card-manager/pkg/render"
+	"flash-card-manager/pkg/repository/cache"
 	repository "flash-card-manager/pkg/repository/init"
+	"flash-card-manager/pkg/repository/interfaces"
 	"net"
+	"net/http"
+	"os"
+	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	otgrpc "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
-	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 )
 
-const (
-	port = ":9000"
-)
-
+// inProcessBufferSize is the buffer of the in-memory connection between the
+// embedded gateway and the gRPC server.
+const inProcessBufferSize = 1 << 20
 
 func main() {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	ctx := context.Background()
 
 	logger.Init()
 
-	tracer, closer := utils.InitJaeger("flash-card-manager-service")
-	defer closer.Close()
-	opentracing.SetGlobalTracer(tracer)
+	loader := config.NewLoader(flag.CommandLine, "storage.dsn")
+	flag.Parse()
+
+	cfg, err := loader.Load()
+	if err != nil {
+		logger.Errorf(ctx, "Failed to load configuration: %v", err)
+		os.Exit(1)
+	}
+	if loader.PrintRequested() {
+		cfg.Print(os.Stdout)
+		return
+	}
 
-	database, err := db.NewDB(ctx, db.GenerateDsn())
+	log, err := logger.New(cfg.Log.Level, cfg.Log.Encoding)
 	if err != nil {
-		logger.Errorf(ctx, "Failed to initialize database: %v", err)
+		logger.Errorf(ctx, "Failed to create logger: %v", err)
+		os.Exit(1)
 	}
-	defer database.GetPool(ctx).Close()
+	defer log.Sync()
+	logger.SetGlobal(log)
 
-	cardRepo, deckRepo := repository.InitRepositories(database)
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(ctx, cfg.Storage.DSN, flag.Arg(1)); err != nil {
+			logger.Errorf(ctx, "Failed to migrate: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	manager := lifecycle.New(cfg.Shutdown.Timeout)
+	if err := start(ctx, cfg, manager); err != nil {
+		logger.Errorf(ctx, "Failed to start: %v", err)
+		if err := manager.Stop(); err != nil {
+			logger.Errorf(ctx, "Failed to stop: %v", err)
+		}
+		os.Exit(1)
+	}
+
+	if err := manager.Run(ctx); err != nil {
+		logger.Errorf(ctx, "Server stopped: %v", err)
+		os.Exit(1)
+	}
+}
 
-	producer, _, err := kafka.InitializeKafka()
+// start brings up the server's dependencies and the server itself, adding each
+// to manager right after it is created, so that it is stopped after everything
+// that uses it.
+func start(ctx context.Context, cfg *config.Config, manager *lifecycle.Manager) error {
+	shutdownTracing, err := tracing.Init(ctx, "flash-card-manager-service", cfg.Tracing)
 	if err != nil {
-		logger.Errorf(ctx, "Failed to initialize Kafka: %v", err)
+		return fmt.Errorf("failed to initialize tracing: %w", err)
 	}
-	defer producer.Close()
+	manager.OnStop("tracer", shutdownTracing)
 
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-			otgrpc.UnaryServerInterceptor(otgrpc.WithTracer(opentracing.GlobalTracer())),
-		)),
-		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
-			otgrpc.StreamServerInterceptor(otgrpc.WithTracer(opentracing.GlobalTracer())),
-		)),
+	var (
+		cardRepo        interfaces.CardRepository
+		deckRepo        interfaces.DeckRepository
+		trashRepo       interfaces.TrashRepository
+		idempotencyRepo interfaces.IdempotencyRepository
+		usageRepo       interfaces.UsageRepository
+		txManager       db.TxManager
+		database        db.DatabaseInterface
 	)
+	if cfg.Storage.DSN == "memory" {
+		cardRepo, deckRepo, trashRepo, idempotencyRepo, usageRepo, txManager = repository.InitMemoryRepositories()
+	} else {
+		database, err = db.Open(ctx, cfg.Storage.DSN)
+		if err != nil {
+			return fmt.Errorf("failed to initialize database: %w", err)
+		}
+		manager.OnStop("database", func(context.Context) error {
+			database.Close()
+			return nil
+		})
 
-	deckHandler := handlers.NewDeckServiceServer(deckRepo, kafka.NewKafkaEventSender(producer))
-	cardHandler := handlers.NewCardServiceServer(cardRepo, kafka.NewKafkaEventSender(producer))
+		if cfg.Storage.Migrate {
+			if err := migrateUp(ctx, database); err != nil {
+				return fmt.Errorf("failed to apply migrations: %w", err)
+			}
+		}
+
+		if err := registerPoolMetrics(database); err != nil {
+			return err
+		}
+
+		cardRepo, deckRepo, trashRepo, idempotencyRepo, usageRepo, err = repository.InitRepositories(database)
+		if err != nil {
+			return fmt.Errorf("failed to initialize repositories: %w", err)
+		}
+		txManager = database
+	}
+
+	producer, consumer, err := kafka.InitializeKafka(cfg.Kafka.Brokers)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Kafka: %w", err)
+	}
+	manager.OnStop("kafka producer", lifecycle.Closer(producer.Close))
+	manager.OnStop("kafka consumer", lifecycle.Closer(consumer.Close))
+
+	var redis *cache.Redis
+	if !cfg.Cache.Disabled {
+		var backend cache.Backend = cache.NewLRU(int(cfg.Cache.Size))
+		if cfg.Cache.RedisURL != "" {
+			if redis, err = cache.NewRedis(cfg.Cache.RedisURL); err != nil {
+				return fmt.Errorf("failed to initialize cache: %w", err)
+			}
+			manager.OnStop("cache", lifecycle.Closer(redis.Close))
+			backend = redis
+		}
+
+		repoCache := cache.New(backend, cfg.Cache.TTL, kafka.NewKafkaEventSender(producer))
+		consumer.Handle(func(ctx context.Context, event kafka.Event) {
+			repoCache.HandleEvent(ctx, event.Type, event.Query)
+		})
+		trashRepo = cache.NewTrash(trashRepo, cardRepo, repoCache)
+		deckRepo = cache.NewDeck(deckRepo, repoCache)
+		cardRepo = cache.NewCard(cardRepo, repoCache)
+		txManager = repoCache.TxManager(txManager)
+	}
+
+	for _, topic := range kafka.Topics {
+		topic := topic
+		manager.Add("kafka consumer "+topic, func(ctx context.Context) error {
+			return consumer.Consume(ctx, topic)
+		}, nil)
+	}
+
+	purger := jobs.NewTrashPurger(trashRepo, cfg.Trash.Retention, cfg.Trash.PurgeInterval)
+	manager.Add("trash purger", func(ctx context.Context) error {
+		purger.Run(ctx)
+		return nil
+	}, nil)
+
+	idempotency := interceptors.NewIdempotency(idempotencyRepo, cfg.Idempotency.TTL, cfg.Idempotency.Lease, pb.CardService_CreateCard_FullMethodName, pb.DeckService_CreateDeck_FullMethodName)
+
+	trustedProxies, err := cfg.GRPC.TrustedProxyNets()
+	if err != nil {
+		return fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		interceptors.UnaryServerIdentity(trustedProxies),
+		interceptors.UnaryServerLogging(),
+		interceptors.UnaryServerMetrics(),
+		interceptors.UnaryServerErrors(),
+		interceptors.UnaryServerCacheControl(),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		interceptors.StreamServerIdentity(trustedProxies),
+		interceptors.StreamServerLogging(),
+		interceptors.StreamServerMetrics(),
+	}
+	if !cfg.RateLimit.Disabled {
+		limiter, err := newRateLimiter(cfg.RateLimit)
+		if err != nil {
+			return err
+		}
+		unaryInterceptors = append(unaryInterceptors, limiter.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, limiter.StreamServerInterceptor())
+	}
+	unaryInterceptors = append(unaryInterceptors,
+		interceptors.UnaryServerValidation(validation.Rules()),
+		idempotency.UnaryServerInterceptor(),
+	)
+
+	var tlsConfig *tls.Config
+	if cfg.TLS.Enabled() {
+		var certificate *tlsconfig.KeyPair
+		tlsConfig, certificate, err = tlsconfig.Server(cfg.TLS)
+		if err != nil {
+			return fmt.Errorf("failed to load TLS configuration: %w", err)
+		}
+		manager.Add("tls certificate", certificate.Run, nil)
+	}
+
+	serverOptions := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
+	}
+	// With the embedded gateway TLS is terminated by the HTTP server instead.
+	if tlsConfig != nil && !cfg.Gateway.Embedded {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	grpcServer := grpc.NewServer(serverOptions...)
+
+	renderer := render.NewRenderer(render.DefaultCacheSize)
+
+	quota := handlers.NewQuota(usageRepo, handlers.QuotaLimits{
+		DecksPerUser: cfg.Quota.DecksPerUser,
+		CardsPerDeck: cfg.Quota.CardsPerDeck,
+		MediaBytes:   cfg.Quota.MediaBytes,
+	})
+
+	deckHandler := handlers.NewDeckServiceServer(deckRepo, txManager, kafka.NewKafkaEventSender(producer), renderer, quota)
+	cardHandler := handlers.NewCardServiceServer(cardRepo, txManager, kafka.NewKafkaEventSender(producer), renderer, quota)
+	trashHandler := handlers.NewTrashServiceServer(trashRepo, deckRepo, cardRepo, txManager, kafka.NewKafkaEventSender(producer), renderer, quota)
 
 	pb.RegisterDeckServiceServer(grpcServer, deckHandler)
 	pb.RegisterCardServiceServer(grpcServer, cardHandler)
+	pb.RegisterTrashServiceServer(grpcServer, trashHandler)
+	pb.RegisterUsageServiceServer(grpcServer, handlers.NewUsageServiceServer(quota))
+
+	checker := health.NewChecker(cfg.Health.Interval,
+		pb.DeckService_ServiceDesc.ServiceName,
+		pb.CardService_ServiceDesc.ServiceName,
+		pb.TrashService_ServiceDesc.ServiceName,
+		pb.UsageService_ServiceDesc.ServiceName,
+	)
+	if database != nil {
+		checker.Add("database", database.Ping)
+	}
+	checker.Add("kafka", producer.Ping)
+	if redis != nil {
+		checker.Add("cache", redis.Ping)
+	}
+	healthpb.RegisterHealthServer(grpcServer, checker.Server())
+	reflection.Register(grpcServer)
 
-	listener, err := net.Listen("tcp", port)
+	if cfg.Metrics.Listen != "" {
+		if err := serveMetrics(ctx, cfg.Metrics.Listen, manager); err != nil {
+			return err
+		}
+	}
+
+	listener, err := net.Listen("tcp", cfg.GRPC.Listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", cfg.GRPC.Listen, err)
+	}
+
+	if cfg.Gateway.Embedded {
+		if err := serveEmbedded(ctx, grpcServer, listener, tlsConfig, manager); err != nil {
+			return err
+		}
+	} else {
+		logger.Infof(ctx, "Server listening at %v", listener.Addr())
+		manager.Add("grpc server", func(context.Context) error {
+			return grpcServer.Serve(listener)
+		}, lifecycle.GracefulStop(grpcServer))
+	}
+	// Added last so that it is stopped first: readiness turns false before
+	// the server starts draining.
+	manager.Add("health checker", checker.Run, checker.Shutdown)
+
+	return nil
+}
+
+// newRateLimiter builds the rate limiter of the server from its configuration.
+func newRateLimiter(cfg config.RateLimit) (*interceptors.RateLimiter, error) {
+	limits, err := cfg.MethodLimits()
 	if err != nil {
-		logger.Errorf(ctx, "Failed to listen on port %s: %v", port, err)
+		return nil, fmt.Errorf("invalid rate limits: %w", err)
 	}
+	methods := make(map[string]interceptors.RateLimit, len(limits))
+	for _, limit := range limits {
+		methods[limit.Method] = interceptors.RateLimit{Rate: limit.Rate, Burst: limit.Burst}
+	}
+	return interceptors.NewRateLimiter(interceptors.RateLimit{Rate: cfg.Rate, Burst: cfg.Burst}, methods), nil
+}
 
-	fmt.Printf("Server listening at %v", listener.Addr())
-	if err := grpcServer.Serve(listener); err != nil {
-		logger.Errorf(ctx, "Failed to serve gRPC server over port %s: %v", port, err)
+// registerPoolMetrics exports the connection pool statistics of the database.
+func registerPoolMetrics(database db.DatabaseInterface) error {
+	switch d := database.(type) {
+	case *db.Database:
+		return metrics.Registry.Register(metrics.NewPoolCollector(d.Stat))
+	case *db.SQLiteDatabase:
+		return metrics.Registry.Register(collectors.NewDBStatsCollector(d.DB(), "sqlite"))
+	default:
+		return nil
+	}
+}
+
+// serveMetrics serves /metrics on its own listener, so that scraping does not
+// depend on the gRPC port.
+func serveMetrics(ctx context.Context, addr string, manager *lifecycle.Manager) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	logger.Infof(ctx, "Metrics listening at %v", listener.Addr())
+	manager.Add("metrics server", func(context.Context) error {
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}, server.Shutdown)
+
+	return nil
+}
+
+// serveEmbedded serves gRPC and the REST gateway together on listener, over
+// TLS if tlsConfig is not nil. The gateway reaches the services in-process,
+// through an in-memory connection.
+func serveEmbedded(ctx context.Context, grpcServer *grpc.Server, listener net.Listener, tlsConfig *tls.Config, manager *lifecycle.Manager) error {
+	inProcess := bufconn.Listen(inProcessBufferSize)
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return inProcess.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to dial in-process server: %w", err)
 	}
+	manager.OnStop("gateway connection", lifecycle.Closer(conn.Close))
+
+	handler, err := gateway.NewHandler(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	// GracefulStop panics once grpcServer has served through ServeHTTP, so
+	// the server below is shut down first, which waits for the gateway
+	// requests, and grpcServer is stopped after it. Shutdown does not wait
+	// for the gRPC streams of h2c connections; those still open are cut.
+	manager.Add("grpc server", func(context.Context) error {
+		return grpcServer.Serve(inProcess)
+	}, func(context.Context) error {
+		grpcServer.Stop()
+		return nil
+	})
+
+	server := &http.Server{
+		Handler:           gateway.Multiplex(grpcServer, handler),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	logger.Infof(ctx, "Server listening at %v (gRPC and HTTP gateway)", listener.Addr())
+	manager.Add("http server", func(context.Context) error {
+		serve := server.Serve
+		if tlsConfig != nil {
+			serve = func(listener net.Listener) error { return server.ServeTLS(listener, "", "") }
+		}
+		if err := serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}, server.Shutdown)
+
+	return nil
+}
+
+// runMigrate implements "flash-card-manager migrate <command>".
+func runMigrate(ctx context.Context, storage, command string) error {
+	if storage == "memory" {
+		return fmt.Errorf("memory storage has no migrations")
+	}
+
+	database, err := db.Open(ctx, storage)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	migrator, err := migrate.New(database)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	return migrator.Run(ctx, command)
+}
+
+func migrateUp(ctx context.Context, database db.DatabaseInterface) error {
+	migrator, err := migrate.New(database)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	return migrator.Up(ctx)
 }
@@ -2,32 +2,72 @@ package main
 
 import (
 	"context"
-	pb "flash-card-manager/internal/app/grpc"
+	"flag"
+	"flash-card-manager/internal/app/gateway"
+	"flash-card-manager/internal/app/tlsconfig"
+	"flash-card-manager/internal/app/tracing"
+	"flash-card-manager/internal/config"
 	"log"
 	"net/http"
+	"os"
 
-	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
 	ctx := context.Background()
 
-	conn, err := grpc.DialContext(ctx, "localhost:9000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	loader := config.NewLoader(flag.CommandLine)
+	flag.Parse()
+
+	cfg, err := loader.Load()
 	if err != nil {
-		log.Fatalf("failed to dial server: %v", err)
+		log.Fatalf("failed to load configuration: %v", err)
+	}
+	if loader.PrintRequested() {
+		cfg.Print(os.Stdout)
+		return
+	}
+
+	shutdownTracing, err := tracing.Init(ctx, "flash-card-manager-gateway", cfg.Tracing)
+	if err != nil {
+		log.Fatalf("failed to initialize tracing: %v", err)
+	}
+
+	creds, err := tlsconfig.DialCredentials(cfg.GRPC)
+	if err != nil {
+		log.Fatalf("failed to load TLS configuration: %v", err)
 	}
 
-	mux := runtime.NewServeMux()
-	if err := pb.RegisterCardServiceHandler(ctx, mux, conn); err != nil {
-		log.Fatalf("failed to register card service handler: %v", err)
+	conn, err := grpc.DialContext(ctx, cfg.GRPC.Target,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		log.Fatalf("failed to dial server: %v", err)
 	}
-	if err := pb.RegisterDeckServiceHandler(ctx, mux, conn); err != nil {
-		log.Fatalf("failed to register deck service handler: %v", err)
+
+	handler, err := gateway.NewHandler(ctx, conn)
+	if err != nil {
+		log.Fatalf("failed to create gateway: %v", err)
 	}
 
-	if err := http.ListenAndServe(":8080", mux); err != nil {
+	server := &http.Server{Addr: cfg.Gateway.Listen, Handler: handler}
+	if cfg.TLS.Enabled() {
+		var certificate *tlsconfig.KeyPair
+		server.TLSConfig, certificate, err = tlsconfig.Server(cfg.TLS)
+		if err != nil {
+			log.Fatalf("failed to load TLS configuration: %v", err)
+		}
+		go certificate.Run(ctx)
+
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = server.ListenAndServe()
+	}
+	_ = shutdownTracing(context.Background())
+	if err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
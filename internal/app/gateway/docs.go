@@ -0,0 +1,26 @@
+package gateway
+
+import (
+	"embed"
+	"flash-card-manager/api/openapi"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed swaggerui
+var swaggerUI embed.FS
+
+// handleDocs adds the OpenAPI spec at /openapi.json and Swagger UI for it at
+// /docs/ to mux. Both are embedded, so they work offline.
+func handleDocs(mux *http.ServeMux) {
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openapi.Spec)
+	})
+
+	ui, err := fs.Sub(swaggerUI, "swaggerui")
+	if err != nil {
+		panic(err)
+	}
+	mux.Handle("/docs/", http.StripPrefix("/docs/", http.FileServer(http.FS(ui))))
+}
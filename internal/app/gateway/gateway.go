@@ -0,0 +1,83 @@
+// Package gateway configures the HTTP/JSON gateway in front of the gRPC
+// services.
+package gateway
+
+import (
+	"context"
+	"flash-card-manager/internal/app/interceptors"
+	"net/http"
+	"net/textproto"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	// Registers the error detail types, such as BadRequest, so that error
+	// responses can render them as JSON.
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// NewServeMux returns a gateway mux that forwards If-Match, Idempotency-Key,
+// X-Request-Id and Cache-Control to the services, along with the common name
+// of the client certificate the TLS handshake verified, if any, and the
+// client's address. It exposes the etag, request ID and retry-after they return as ETag,
+// X-Request-Id and Retry-After headers and answers failed preconditions with
+// 412. Other errors are rendered as JSON statuses with their details, e.g.
+// field violations in a 400 or the retry delay in a 429.
+func NewServeMux() *runtime.ServeMux {
+	return runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithMetadata(forwardedUser),
+	)
+}
+
+// forwardedUser tells the services who the client is. The services believe it
+// only from trusted proxies such as this one, so a client cannot claim a user
+// by sending the header itself.
+func forwardedUser(ctx context.Context, r *http.Request) metadata.MD {
+	if r.TLS == nil {
+		return nil
+	}
+	if user := interceptors.CertificateUser(*r.TLS); user != "" {
+		return metadata.Pairs(interceptors.ForwardedUserHeader, user)
+	}
+	return nil
+}
+
+func incomingHeaderMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case "If-Match":
+		return "if-match", true
+	case "Idempotency-Key":
+		return "idempotency-key", true
+	case "X-Request-Id":
+		return "x-request-id", true
+	case "Cache-Control":
+		return "cache-control", true
+	case runtime.MetadataHeaderPrefix + "X-Forwarded-User", runtime.MetadataHeaderPrefix + "X-Forwarded-For":
+		// Only the gateway itself sets these.
+		return "", false
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func outgoingHeaderMatcher(key string) (string, bool) {
+	switch key {
+	case "etag":
+		return "ETag", true
+	case "x-request-id":
+		return "X-Request-Id", true
+	case "retry-after":
+		return "Retry-After", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if status.Code(err) == codes.Aborted {
+		err = &runtime.HTTPStatusError{HTTPStatus: http.StatusPreconditionFailed, Err: err}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+}
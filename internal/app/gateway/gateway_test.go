@@ -0,0 +1,32 @@
+//go:build unit
+// +build unit
+
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForwardedUser(t *testing.T) {
+	// Clients cannot send the headers the gateway sets itself.
+	for _, header := range []string{"Grpc-Metadata-X-Forwarded-User", "Grpc-Metadata-X-Forwarded-For"} {
+		_, ok := incomingHeaderMatcher(header)
+		assert.False(t, ok, header)
+	}
+	key, ok := incomingHeaderMatcher("X-Request-Id")
+	assert.True(t, ok)
+	assert.Equal(t, "x-request-id", key)
+
+	r := httptest.NewRequest("GET", "/v1/usage", nil)
+	assert.Empty(t, forwardedUser(context.Background(), r))
+
+	r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "alice"}}}}}
+	assert.Equal(t, []string{"alice"}, forwardedUser(context.Background(), r).Get("x-forwarded-user"))
+}
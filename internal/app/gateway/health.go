@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// readyTimeout bounds the health check /readyz makes upstream.
+const readyTimeout = 2 * time.Second
+
+// HandleHealth adds the probes for orchestrators to mux. /healthz answers as
+// long as the gateway runs; /readyz answers 200 only while the gRPC server
+// reports SERVING, and 503 otherwise.
+func HandleHealth(mux *runtime.ServeMux, client healthpb.HealthClient) error {
+	if err := mux.HandlePath(http.MethodGet, "/healthz", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		writeProbe(w, http.StatusOK, "ok")
+	}); err != nil {
+		return err
+	}
+
+	return mux.HandlePath(http.MethodGet, "/readyz", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+		defer cancel()
+
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+		switch {
+		case err != nil:
+			writeProbe(w, http.StatusServiceUnavailable, err.Error())
+		case resp.Status != healthpb.HealthCheckResponse_SERVING:
+			writeProbe(w, http.StatusServiceUnavailable, resp.Status.String())
+		default:
+			writeProbe(w, http.StatusOK, "ok")
+		}
+	})
+}
+
+func writeProbe(w http.ResponseWriter, code int, body string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(code)
+	_, _ = w.Write([]byte(body + "\n"))
+}
@@ -0,0 +1,55 @@
+package gateway
+
+import (
+	"context"
+	pb "flash-card-manager/internal/app/grpc"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// NewHandler returns the REST gateway for the services behind conn, with the
+// health probes, the OpenAPI spec and Swagger UI.
+func NewHandler(ctx context.Context, conn *grpc.ClientConn) (http.Handler, error) {
+	mux := NewServeMux()
+	if err := pb.RegisterCardServiceHandler(ctx, mux, conn); err != nil {
+		return nil, fmt.Errorf("failed to register card service handler: %w", err)
+	}
+	if err := pb.RegisterDeckServiceHandler(ctx, mux, conn); err != nil {
+		return nil, fmt.Errorf("failed to register deck service handler: %w", err)
+	}
+	if err := pb.RegisterTrashServiceHandler(ctx, mux, conn); err != nil {
+		return nil, fmt.Errorf("failed to register trash service handler: %w", err)
+	}
+	if err := pb.RegisterUsageServiceHandler(ctx, mux, conn); err != nil {
+		return nil, fmt.Errorf("failed to register usage service handler: %w", err)
+	}
+	if err := HandleHealth(mux, healthpb.NewHealthClient(conn)); err != nil {
+		return nil, fmt.Errorf("failed to register health handlers: %w", err)
+	}
+
+	root := http.NewServeMux()
+	root.Handle("/", mux)
+	handleDocs(root)
+
+	return otelhttp.NewHandler(root, "gateway"), nil
+}
+
+// Multiplex serves gRPC and REST on one port: HTTP/2 requests with a gRPC
+// content type go to grpcServer, everything else to gateway. HTTP/2 is also
+// accepted without TLS (h2c), as gRPC clients speak it in plaintext.
+func Multiplex(grpcServer, gateway http.Handler) http.Handler {
+	return h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		gateway.ServeHTTP(w, r)
+	}), &http2.Server{})
+}
@@ -0,0 +1,102 @@
+//go:build unit
+// +build unit
+
+package gateway
+
+import (
+	"context"
+	"flash-card-manager/api/openapi"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// TestMultiplex serves a health server and the gateway in front of it, over
+// an in-memory connection, on one port, like the server with an embedded
+// gateway.
+func TestMultiplex(t *testing.T) {
+	ctx := context.Background()
+
+	grpcServer := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	inProcess := bufconn.Listen(1 << 16)
+	go func() { _ = grpcServer.Serve(inProcess) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return inProcess.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	handler, err := NewHandler(ctx, conn)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &http.Server{Handler: Multiplex(grpcServer, handler)}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { server.Close() })
+
+	t.Run("GRPC", func(t *testing.T) {
+		client, err := grpc.DialContext(ctx, listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer client.Close()
+
+		resp, err := healthpb.NewHealthClient(client).Check(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	})
+
+	t.Run("HTTP", func(t *testing.T) {
+		get := func(path string) (int, string) {
+			resp, err := http.Get("http://" + listener.Addr().String() + path)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			return resp.StatusCode, string(body)
+		}
+
+		code, body := get("/readyz")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ok\n", body)
+
+		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		code, body = get("/readyz")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "NOT_SERVING\n", body)
+
+		code, _ = get("/healthz")
+		assert.Equal(t, http.StatusOK, code)
+
+		code, body = get("/openapi.json")
+		assert.Equal(t, http.StatusOK, code)
+		assert.JSONEq(t, string(openapi.Spec), body)
+
+		code, body = get("/docs/")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "swagger-ui-bundle.js")
+
+		code, body = get("/docs/swagger-ui-bundle.js")
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "SwaggerUIBundle")
+
+		code, _ = get("/docs/swagger-ui.css")
+		assert.Equal(t, http.StatusOK, code)
+	})
+}
@@ -0,0 +1,34 @@
+// A stand-in for the Swagger UI bundle, so that /docs/ works before the real
+// one is fetched: it lists the operations of the spec, without "Try it out".
+// "make swagger-ui" replaces this file and swagger-ui.css with swagger-ui-dist.
+(function () {
+  function escape(s) {
+    return String(s).replace(/[&<>"]/g, function (c) {
+      return { "&": "&amp;", "<": "&lt;", ">": "&gt;", "\"": "&quot;" }[c];
+    });
+  }
+
+  function render(root, url, spec) {
+    var html = "<h1>" + escape(spec.info.title) + " " + escape(spec.info.version) + "</h1>" +
+      "<p>" + escape(spec.info.description || "") + "</p>" +
+      "<p>The full spec is at <a href=\"" + escape(url) + "\"><code>" + escape(url) + "</code></a>.</p>";
+    Object.keys(spec.paths).forEach(function (path) {
+      var item = spec.paths[path];
+      Object.keys(item).forEach(function (method) {
+        var op = item[method];
+        html += "<div class=\"op\"><span class=\"method\">" + escape(method) + "</span> <code>" + escape(path) + "</code>" +
+          "<p><b>" + escape(op.summary || op.operationId) + "</b> " + escape(op.description || "") + "</p></div>";
+      });
+    });
+    root.innerHTML = html;
+  }
+
+  window.SwaggerUIBundle = function (options) {
+    var root = document.querySelector(options.dom_id);
+    root.className = "fallback";
+    fetch(options.url).then(function (resp) { return resp.json(); }).then(function (spec) {
+      render(root, options.url, spec);
+    });
+    return {};
+  };
+})();
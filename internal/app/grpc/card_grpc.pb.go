@@ -1,17 +1,17 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.3.0
-// - protoc             v3.12.4
+// - protoc             (unknown)
 // source: card.proto
 
 package grpc
 
 import (
 	context "context"
-	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -20,10 +20,16 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	CardService_CreateCard_FullMethodName  = "/grpc.CardService/CreateCard"
-	CardService_GetCardById_FullMethodName = "/grpc.CardService/GetCardById"
-	CardService_UpdateCard_FullMethodName  = "/grpc.CardService/UpdateCard"
-	CardService_DeleteCard_FullMethodName  = "/grpc.CardService/DeleteCard"
+	CardService_CreateCard_FullMethodName        = "/grpc.CardService/CreateCard"
+	CardService_GetCardById_FullMethodName       = "/grpc.CardService/GetCardById"
+	CardService_UpdateCard_FullMethodName        = "/grpc.CardService/UpdateCard"
+	CardService_DeleteCard_FullMethodName        = "/grpc.CardService/DeleteCard"
+	CardService_BatchCreateCards_FullMethodName  = "/grpc.CardService/BatchCreateCards"
+	CardService_BatchUpdateCards_FullMethodName  = "/grpc.CardService/BatchUpdateCards"
+	CardService_BatchDeleteCards_FullMethodName  = "/grpc.CardService/BatchDeleteCards"
+	CardService_MoveCards_FullMethodName         = "/grpc.CardService/MoveCards"
+	CardService_ListCardRevisions_FullMethodName = "/grpc.CardService/ListCardRevisions"
+	CardService_RevertCard_FullMethodName        = "/grpc.CardService/RevertCard"
 )
 
 // CardServiceClient is the client API for CardService service.
@@ -33,7 +39,13 @@ type CardServiceClient interface {
 	CreateCard(ctx context.Context, in *CreateCardRequest, opts ...grpc.CallOption) (*CardResponse, error)
 	GetCardById(ctx context.Context, in *GetCardByIdRequest, opts ...grpc.CallOption) (*CardResponse, error)
 	UpdateCard(ctx context.Context, in *UpdateCardRequest, opts ...grpc.CallOption) (*CardResponse, error)
-	DeleteCard(ctx context.Context, in *DeleteCardRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteCard(ctx context.Context, in *DeleteCardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	BatchCreateCards(ctx context.Context, in *BatchCreateCardsRequest, opts ...grpc.CallOption) (*BatchCardsResponse, error)
+	BatchUpdateCards(ctx context.Context, in *BatchUpdateCardsRequest, opts ...grpc.CallOption) (*BatchCardsResponse, error)
+	BatchDeleteCards(ctx context.Context, in *BatchDeleteCardsRequest, opts ...grpc.CallOption) (*BatchCardsResponse, error)
+	MoveCards(ctx context.Context, in *MoveCardsRequest, opts ...grpc.CallOption) (*BatchCardsResponse, error)
+	ListCardRevisions(ctx context.Context, in *ListCardRevisionsRequest, opts ...grpc.CallOption) (*ListCardRevisionsResponse, error)
+	RevertCard(ctx context.Context, in *RevertCardRequest, opts ...grpc.CallOption) (*CardResponse, error)
 }
 
 type cardServiceClient struct {
@@ -71,8 +83,8 @@ func (c *cardServiceClient) UpdateCard(ctx context.Context, in *UpdateCardReques
 	return out, nil
 }
 
-func (c *cardServiceClient) DeleteCard(ctx context.Context, in *DeleteCardRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
+func (c *cardServiceClient) DeleteCard(ctx context.Context, in *DeleteCardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, CardService_DeleteCard_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -80,6 +92,60 @@ func (c *cardServiceClient) DeleteCard(ctx context.Context, in *DeleteCardReques
 	return out, nil
 }
 
+func (c *cardServiceClient) BatchCreateCards(ctx context.Context, in *BatchCreateCardsRequest, opts ...grpc.CallOption) (*BatchCardsResponse, error) {
+	out := new(BatchCardsResponse)
+	err := c.cc.Invoke(ctx, CardService_BatchCreateCards_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) BatchUpdateCards(ctx context.Context, in *BatchUpdateCardsRequest, opts ...grpc.CallOption) (*BatchCardsResponse, error) {
+	out := new(BatchCardsResponse)
+	err := c.cc.Invoke(ctx, CardService_BatchUpdateCards_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) BatchDeleteCards(ctx context.Context, in *BatchDeleteCardsRequest, opts ...grpc.CallOption) (*BatchCardsResponse, error) {
+	out := new(BatchCardsResponse)
+	err := c.cc.Invoke(ctx, CardService_BatchDeleteCards_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) MoveCards(ctx context.Context, in *MoveCardsRequest, opts ...grpc.CallOption) (*BatchCardsResponse, error) {
+	out := new(BatchCardsResponse)
+	err := c.cc.Invoke(ctx, CardService_MoveCards_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) ListCardRevisions(ctx context.Context, in *ListCardRevisionsRequest, opts ...grpc.CallOption) (*ListCardRevisionsResponse, error) {
+	out := new(ListCardRevisionsResponse)
+	err := c.cc.Invoke(ctx, CardService_ListCardRevisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardServiceClient) RevertCard(ctx context.Context, in *RevertCardRequest, opts ...grpc.CallOption) (*CardResponse, error) {
+	out := new(CardResponse)
+	err := c.cc.Invoke(ctx, CardService_RevertCard_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CardServiceServer is the server API for CardService service.
 // All implementations must embed UnimplementedCardServiceServer
 // for forward compatibility
@@ -87,7 +153,13 @@ type CardServiceServer interface {
 	CreateCard(context.Context, *CreateCardRequest) (*CardResponse, error)
 	GetCardById(context.Context, *GetCardByIdRequest) (*CardResponse, error)
 	UpdateCard(context.Context, *UpdateCardRequest) (*CardResponse, error)
-	DeleteCard(context.Context, *DeleteCardRequest) (*empty.Empty, error)
+	DeleteCard(context.Context, *DeleteCardRequest) (*emptypb.Empty, error)
+	BatchCreateCards(context.Context, *BatchCreateCardsRequest) (*BatchCardsResponse, error)
+	BatchUpdateCards(context.Context, *BatchUpdateCardsRequest) (*BatchCardsResponse, error)
+	BatchDeleteCards(context.Context, *BatchDeleteCardsRequest) (*BatchCardsResponse, error)
+	MoveCards(context.Context, *MoveCardsRequest) (*BatchCardsResponse, error)
+	ListCardRevisions(context.Context, *ListCardRevisionsRequest) (*ListCardRevisionsResponse, error)
+	RevertCard(context.Context, *RevertCardRequest) (*CardResponse, error)
 	mustEmbedUnimplementedCardServiceServer()
 }
 
@@ -104,9 +176,27 @@ func (UnimplementedCardServiceServer) GetCardById(context.Context, *GetCardByIdR
 func (UnimplementedCardServiceServer) UpdateCard(context.Context, *UpdateCardRequest) (*CardResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateCard not implemented")
 }
-func (UnimplementedCardServiceServer) DeleteCard(context.Context, *DeleteCardRequest) (*empty.Empty, error) {
+func (UnimplementedCardServiceServer) DeleteCard(context.Context, *DeleteCardRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCard not implemented")
 }
+func (UnimplementedCardServiceServer) BatchCreateCards(context.Context, *BatchCreateCardsRequest) (*BatchCardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateCards not implemented")
+}
+func (UnimplementedCardServiceServer) BatchUpdateCards(context.Context, *BatchUpdateCardsRequest) (*BatchCardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateCards not implemented")
+}
+func (UnimplementedCardServiceServer) BatchDeleteCards(context.Context, *BatchDeleteCardsRequest) (*BatchCardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteCards not implemented")
+}
+func (UnimplementedCardServiceServer) MoveCards(context.Context, *MoveCardsRequest) (*BatchCardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveCards not implemented")
+}
+func (UnimplementedCardServiceServer) ListCardRevisions(context.Context, *ListCardRevisionsRequest) (*ListCardRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCardRevisions not implemented")
+}
+func (UnimplementedCardServiceServer) RevertCard(context.Context, *RevertCardRequest) (*CardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertCard not implemented")
+}
 func (UnimplementedCardServiceServer) mustEmbedUnimplementedCardServiceServer() {}
 
 // UnsafeCardServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -192,6 +282,114 @@ func _CardService_DeleteCard_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CardService_BatchCreateCards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateCardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).BatchCreateCards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_BatchCreateCards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).BatchCreateCards(ctx, req.(*BatchCreateCardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_BatchUpdateCards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateCardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).BatchUpdateCards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_BatchUpdateCards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).BatchUpdateCards(ctx, req.(*BatchUpdateCardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_BatchDeleteCards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteCardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).BatchDeleteCards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_BatchDeleteCards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).BatchDeleteCards(ctx, req.(*BatchDeleteCardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_MoveCards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveCardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).MoveCards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_MoveCards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).MoveCards(ctx, req.(*MoveCardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_ListCardRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCardRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).ListCardRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_ListCardRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).ListCardRevisions(ctx, req.(*ListCardRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CardService_RevertCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardServiceServer).RevertCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CardService_RevertCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardServiceServer).RevertCard(ctx, req.(*RevertCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CardService_ServiceDesc is the grpc.ServiceDesc for CardService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -215,6 +413,30 @@ var CardService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCard",
 			Handler:    _CardService_DeleteCard_Handler,
 		},
+		{
+			MethodName: "BatchCreateCards",
+			Handler:    _CardService_BatchCreateCards_Handler,
+		},
+		{
+			MethodName: "BatchUpdateCards",
+			Handler:    _CardService_BatchUpdateCards_Handler,
+		},
+		{
+			MethodName: "BatchDeleteCards",
+			Handler:    _CardService_BatchDeleteCards_Handler,
+		},
+		{
+			MethodName: "MoveCards",
+			Handler:    _CardService_MoveCards_Handler,
+		},
+		{
+			MethodName: "ListCardRevisions",
+			Handler:    _CardService_ListCardRevisions_Handler,
+		},
+		{
+			MethodName: "RevertCard",
+			Handler:    _CardService_RevertCard_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "card.proto",
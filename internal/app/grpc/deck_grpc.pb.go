@@ -1,17 +1,17 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.3.0
-// - protoc             v3.12.4
+// - protoc             (unknown)
 // source: deck.proto
 
 package grpc
 
 import (
 	context "context"
-	empty "github.com/golang/protobuf/ptypes/empty"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -20,10 +20,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	DeckService_CreateDeck_FullMethodName  = "/grpc.DeckService/CreateDeck"
-	DeckService_GetDeckById_FullMethodName = "/grpc.DeckService/GetDeckById"
-	DeckService_UpdateDeck_FullMethodName  = "/grpc.DeckService/UpdateDeck"
-	DeckService_DeleteDeck_FullMethodName  = "/grpc.DeckService/DeleteDeck"
+	DeckService_CreateDeck_FullMethodName        = "/grpc.DeckService/CreateDeck"
+	DeckService_GetDeckById_FullMethodName       = "/grpc.DeckService/GetDeckById"
+	DeckService_UpdateDeck_FullMethodName        = "/grpc.DeckService/UpdateDeck"
+	DeckService_DeleteDeck_FullMethodName        = "/grpc.DeckService/DeleteDeck"
+	DeckService_ListDeckRevisions_FullMethodName = "/grpc.DeckService/ListDeckRevisions"
+	DeckService_RevertDeck_FullMethodName        = "/grpc.DeckService/RevertDeck"
 )
 
 // DeckServiceClient is the client API for DeckService service.
@@ -33,7 +35,9 @@ type DeckServiceClient interface {
 	CreateDeck(ctx context.Context, in *CreateDeckRequest, opts ...grpc.CallOption) (*DeckResponse, error)
 	GetDeckById(ctx context.Context, in *GetDeckByIdRequest, opts ...grpc.CallOption) (*DeckWithCardsResponse, error)
 	UpdateDeck(ctx context.Context, in *UpdateDeckRequest, opts ...grpc.CallOption) (*DeckResponse, error)
-	DeleteDeck(ctx context.Context, in *DeleteDeckRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteDeck(ctx context.Context, in *DeleteDeckRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListDeckRevisions(ctx context.Context, in *ListDeckRevisionsRequest, opts ...grpc.CallOption) (*ListDeckRevisionsResponse, error)
+	RevertDeck(ctx context.Context, in *RevertDeckRequest, opts ...grpc.CallOption) (*DeckResponse, error)
 }
 
 type deckServiceClient struct {
@@ -71,8 +75,8 @@ func (c *deckServiceClient) UpdateDeck(ctx context.Context, in *UpdateDeckReques
 	return out, nil
 }
 
-func (c *deckServiceClient) DeleteDeck(ctx context.Context, in *DeleteDeckRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
+func (c *deckServiceClient) DeleteDeck(ctx context.Context, in *DeleteDeckRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, DeckService_DeleteDeck_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -80,6 +84,24 @@ func (c *deckServiceClient) DeleteDeck(ctx context.Context, in *DeleteDeckReques
 	return out, nil
 }
 
+func (c *deckServiceClient) ListDeckRevisions(ctx context.Context, in *ListDeckRevisionsRequest, opts ...grpc.CallOption) (*ListDeckRevisionsResponse, error) {
+	out := new(ListDeckRevisionsResponse)
+	err := c.cc.Invoke(ctx, DeckService_ListDeckRevisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deckServiceClient) RevertDeck(ctx context.Context, in *RevertDeckRequest, opts ...grpc.CallOption) (*DeckResponse, error) {
+	out := new(DeckResponse)
+	err := c.cc.Invoke(ctx, DeckService_RevertDeck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DeckServiceServer is the server API for DeckService service.
 // All implementations must embed UnimplementedDeckServiceServer
 // for forward compatibility
@@ -87,7 +109,9 @@ type DeckServiceServer interface {
 	CreateDeck(context.Context, *CreateDeckRequest) (*DeckResponse, error)
 	GetDeckById(context.Context, *GetDeckByIdRequest) (*DeckWithCardsResponse, error)
 	UpdateDeck(context.Context, *UpdateDeckRequest) (*DeckResponse, error)
-	DeleteDeck(context.Context, *DeleteDeckRequest) (*empty.Empty, error)
+	DeleteDeck(context.Context, *DeleteDeckRequest) (*emptypb.Empty, error)
+	ListDeckRevisions(context.Context, *ListDeckRevisionsRequest) (*ListDeckRevisionsResponse, error)
+	RevertDeck(context.Context, *RevertDeckRequest) (*DeckResponse, error)
 	mustEmbedUnimplementedDeckServiceServer()
 }
 
@@ -104,9 +128,15 @@ func (UnimplementedDeckServiceServer) GetDeckById(context.Context, *GetDeckByIdR
 func (UnimplementedDeckServiceServer) UpdateDeck(context.Context, *UpdateDeckRequest) (*DeckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateDeck not implemented")
 }
-func (UnimplementedDeckServiceServer) DeleteDeck(context.Context, *DeleteDeckRequest) (*empty.Empty, error) {
+func (UnimplementedDeckServiceServer) DeleteDeck(context.Context, *DeleteDeckRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeck not implemented")
 }
+func (UnimplementedDeckServiceServer) ListDeckRevisions(context.Context, *ListDeckRevisionsRequest) (*ListDeckRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeckRevisions not implemented")
+}
+func (UnimplementedDeckServiceServer) RevertDeck(context.Context, *RevertDeckRequest) (*DeckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertDeck not implemented")
+}
 func (UnimplementedDeckServiceServer) mustEmbedUnimplementedDeckServiceServer() {}
 
 // UnsafeDeckServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -192,6 +222,42 @@ func _DeckService_DeleteDeck_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeckService_ListDeckRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeckRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeckServiceServer).ListDeckRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeckService_ListDeckRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeckServiceServer).ListDeckRevisions(ctx, req.(*ListDeckRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeckService_RevertDeck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertDeckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeckServiceServer).RevertDeck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeckService_RevertDeck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeckServiceServer).RevertDeck(ctx, req.(*RevertDeckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DeckService_ServiceDesc is the grpc.ServiceDesc for DeckService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -215,6 +281,14 @@ var DeckService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDeck",
 			Handler:    _DeckService_DeleteDeck_Handler,
 		},
+		{
+			MethodName: "ListDeckRevisions",
+			Handler:    _DeckService_ListDeckRevisions_Handler,
+		},
+		{
+			MethodName: "RevertDeck",
+			Handler:    _DeckService_RevertDeck_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "deck.proto",
@@ -2,35 +2,48 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"flash-card-manager/internal/app/grpc"
 	"flash-card-manager/internal/infrastructure/kafka"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/diff"
+	"flash-card-manager/pkg/errs"
 	"flash-card-manager/pkg/logger"
+	"flash-card-manager/pkg/render"
 	"flash-card-manager/pkg/repository/interfaces"
 	"flash-card-manager/pkg/repository/structs"
+	"strconv"
 	"time"
 
-	"github.com/opentracing/opentracing-go"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// defaultPageSize is the page size of lists whose request does not set one.
+const defaultPageSize = 20
+
 type CardServiceServer struct {
 	repo        interfaces.CardRepository
+	tx          db.TxManager
 	eventSender kafka.EventSender
+	renderer    *render.Renderer
+	quota       *Quota
 	grpc.UnimplementedCardServiceServer
 }
 
-func NewCardServiceServer(r interfaces.CardRepository, eventSender kafka.EventSender) *CardServiceServer {
-	return &CardServiceServer{repo: r, eventSender: eventSender}
+func NewCardServiceServer(r interfaces.CardRepository, tx db.TxManager, eventSender kafka.EventSender, renderer *render.Renderer, quota *Quota) *CardServiceServer {
+	return &CardServiceServer{repo: r, tx: tx, eventSender: eventSender, renderer: renderer, quota: quota}
 }
 
 func (s *CardServiceServer) CreateCard(ctx context.Context, req *grpc.CreateCardRequest) (*grpc.CardResponse, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "CreateCard")
-	defer span.Finish()
+	ctx, span := tracer.Start(ctx, "CreateCard")
+	defer span.End()
 
-	if req.Front == "" || req.Back == "" {
-		return nil, status.Error(codes.InvalidArgument, "Missing required field")
+	format, err := render.ParseFormat(req.Format)
+	if err != nil {
+		return nil, errs.Violation("format", err.Error())
 	}
 
 	card := structs.Card{
@@ -38,71 +51,112 @@ func (s *CardServiceServer) CreateCard(ctx context.Context, req *grpc.CreateCard
 		Back:   req.Back,
 		DeckID: req.DeckId,
 		Author: req.Author,
+		Format: string(format),
 	}
 
-	id, err := s.repo.Add(ctx, card)
-	if err != nil {
-		return nil, status.Error(codes.Internal, "Failed to add card")
+	if err := s.quota.CheckCards(ctx, []structs.Card{card}, nil); err != nil {
+		return nil, err
 	}
 
-	fullCard, err := s.repo.GetByID(ctx, id)
+	var fullCard *structs.Card
+	err = s.tx.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		id, err := s.repo.Add(ctx, card)
+		if err != nil {
+			return err
+		}
+
+		fullCard, err = s.repo.GetByID(ctx, id)
+		return err
+	})
 	if err != nil {
-		return nil, status.Error(codes.Internal, "Failed to retrieve card after creation")
+		return nil, err
 	}
+	cardsCreated.Inc()
 
-	logger.Infof(ctx, "Вызов SendSyncMessage с параметрами: %v", req)
-	if err := s.eventSender.SendEvent("CreateCard", req.String()); err != nil {
-		logger.Errorf(ctx, "Failed to send event to Kafka: %s", err)
+	logger.Debug(ctx, "Sending CreateCard event", logger.Proto("request", req))
+	if err := s.eventSender.SendEvent(ctx, "CreateCard", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
 	}
 
-	return &grpc.CardResponse{
-		Id:        fullCard.ID,
-		Front:     fullCard.Front,
-		Back:      fullCard.Back,
-		DeckId:    fullCard.DeckID,
-		Author:    fullCard.Author,
-		CreatedAt: fullCard.CreatedAt.Format(time.RFC3339),
-	}, nil
+	setETag(ctx, fullCard.Version)
+	return newCardResponse(s.renderer, fullCard)
 }
 
 func (s *CardServiceServer) GetCardById(ctx context.Context, req *grpc.GetCardByIdRequest) (*grpc.CardResponse, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "GetCardById")
-	defer span.Finish()
-
-	if req.Id <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "Invalid ID format")
-	}
+	ctx, span := tracer.Start(ctx, "GetCardById")
+	defer span.End()
 
 	card, err := s.repo.GetByID(ctx, req.Id)
 	if err != nil {
-		if err.Error() == "card not found" {
-			return nil, status.Error(codes.NotFound, "Card not found")
-		}
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
-	if err := s.eventSender.SendEvent("GetCardById", req.String()); err != nil {
-		logger.Errorf(ctx, "Failed to send event to Kafka: %s", err)
+	if err := s.eventSender.SendEvent(ctx, "GetCardById", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
 	}
 
-	return &grpc.CardResponse{
-		Id:        card.ID,
-		Front:     card.Front,
-		Back:      card.Back,
-		DeckId:    card.DeckID,
-		Author:    card.Author,
-		CreatedAt: card.CreatedAt.Format(time.RFC3339),
-	}, nil
+	setETag(ctx, card.Version)
+	return newCardResponse(s.renderer, card)
 }
 
 func (s *CardServiceServer) UpdateCard(ctx context.Context, req *grpc.UpdateCardRequest) (*grpc.CardResponse, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "UpdateCard")
-	defer span.Finish()
+	ctx, span := tracer.Start(ctx, "UpdateCard")
+	defer span.End()
+
+	if len(req.GetUpdateMask().GetPaths()) > 0 {
+		return s.patchCard(ctx, req)
+	}
+
+	format, err := render.ParseFormat(req.Format)
+	if err != nil {
+		return nil, errs.Violation("format", err.Error())
+	}
+
+	version, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	card := structs.Card{
+		ID:      req.Id,
+		Front:   req.Front,
+		Back:    req.Back,
+		DeckID:  req.DeckId,
+		Author:  req.Author,
+		Format:  string(format),
+		Version: version,
+	}
+
+	if err := s.checkQuota(ctx, []structs.Card{card}); err != nil {
+		return nil, err
+	}
+
+	card.Version, err = s.repo.Update(ctx, card, editorOrAuthor(req.Editor, req.Author))
+	if err != nil {
+		return nil, err
+	}
+
+	if card.Version == 0 {
+		return nil, errs.NotFoundError("card", req.Id)
+	}
+
+	if err := s.eventSender.SendEvent(ctx, "UpdateCard", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
+	}
 
-	if req.Id == 0 || req.Front == "" || req.Back == "" {
-		return nil, status.Error(codes.InvalidArgument, "Invalid request payload")
+	resp, err := newCardResponse(s.renderer, &card)
+	if err != nil {
+		return nil, err
 	}
+	resp.CreatedAt = ""
 
+	setETag(ctx, card.Version)
+	return resp, nil
+}
+
+// patchCard changes only the fields listed in the update mask and answers
+// with the card as stored afterwards.
+func (s *CardServiceServer) patchCard(ctx context.Context, req *grpc.UpdateCardRequest) (*grpc.CardResponse, error) {
 	card := structs.Card{
 		ID:     req.Id,
 		Front:  req.Front,
@@ -111,43 +165,283 @@ func (s *CardServiceServer) UpdateCard(ctx context.Context, req *grpc.UpdateCard
 		Author: req.Author,
 	}
 
-	updatedRows, err := s.repo.Update(ctx, card)
+	req.UpdateMask.Normalize()
+	for _, path := range req.UpdateMask.Paths {
+		if path == "format" {
+			format, err := render.ParseFormat(req.Format)
+			if err != nil {
+				return nil, errs.Violation("format", err.Error())
+			}
+			card.Format = string(format)
+		}
+	}
+
+	var err error
+	card.Version, err = expectedVersion(ctx, req.ExpectedVersion)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
+	}
+
+	if s.quota.limitsCards() {
+		if err := s.checkPatchQuota(ctx, card, req.UpdateMask.Paths); err != nil {
+			return nil, err
+		}
 	}
 
-	if updatedRows == 0 {
-		return nil, status.Error(codes.NotFound, "Card not found")
+	var patched *structs.Card
+	err = s.tx.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		version, err := s.repo.Patch(ctx, card, req.UpdateMask.Paths, editorOrAuthor(req.Editor, req.Author))
+		if err != nil {
+			return err
+		}
+
+		if version == 0 {
+			return errs.NotFoundError("card", req.Id)
+		}
+
+		patched, err = s.repo.GetByID(ctx, req.Id)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.eventSender.SendEvent("UpdateCard", req.String()); err != nil {
-		logger.Errorf(ctx, "Failed to send event to Kafka: %s", err)
+	if err := s.eventSender.SendEvent(ctx, "UpdateCard", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
 	}
 
-	return &grpc.CardResponse{
-		Id:     card.ID,
-		Front:  card.Front,
-		Back:   card.Back,
-		DeckId: card.DeckID,
-		Author: card.Author,
-	}, nil
+	setETag(ctx, patched.Version)
+	return newCardResponse(s.renderer, patched)
 }
 
 func (s *CardServiceServer) DeleteCard(ctx context.Context, req *grpc.DeleteCardRequest) (*emptypb.Empty, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "DeleteCard")
-	defer span.Finish()
-
-	if req.Id <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "Invalid ID format")
-	}
+	ctx, span := tracer.Start(ctx, "DeleteCard")
+	defer span.End()
 
 	if err := s.repo.Delete(ctx, req.Id); err != nil {
-		return nil, status.Error(codes.Internal, "Failed to delete card")
+		return nil, err
 	}
 
-	if err := s.eventSender.SendEvent("DeleteCard", req.String()); err != nil {
-		logger.Errorf(ctx, "Failed to send event to Kafka: %s", err)
+	if err := s.eventSender.SendEvent(ctx, "DeleteCard", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
 	}
 
 	return &emptypb.Empty{}, nil
 }
+
+func (s *CardServiceServer) ListCardRevisions(ctx context.Context, req *grpc.ListCardRevisionsRequest) (*grpc.ListCardRevisionsResponse, error) {
+	ctx, span := tracer.Start(ctx, "ListCardRevisions")
+	defer span.End()
+
+	page := structs.Page{Size: defaultPageSize}
+	if req.PageSize > 0 {
+		page.Size = int(req.PageSize)
+	}
+	if req.PageToken != "" {
+		after, err := strconv.ParseInt(req.PageToken, 10, 64)
+		if err != nil {
+			return nil, errs.Violation("page_token", "is not a page token from this list")
+		}
+		page.After = after
+	}
+
+	// One more than the page tells whether there is a next one.
+	revisions, err := s.repo.ListRevisions(ctx, req.CardId, structs.Page{Size: page.Size + 1, After: page.After})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.eventSender.SendEvent(ctx, "ListCardRevisions", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
+	}
+
+	resp := &grpc.ListCardRevisionsResponse{}
+	if len(revisions) > page.Size {
+		revisions = revisions[:page.Size]
+		resp.NextPageToken = strconv.FormatInt(revisions[page.Size-1].ID, 10)
+	}
+	for i := range revisions {
+		resp.Revisions = append(resp.Revisions, newCardRevisionResponse(&revisions[i]))
+	}
+
+	return resp, nil
+}
+
+// RevertCard restores the card to the state it had before the given revision.
+// The revert itself is recorded as a new revision.
+func (s *CardServiceServer) RevertCard(ctx context.Context, req *grpc.RevertCardRequest) (*grpc.CardResponse, error) {
+	ctx, span := tracer.Start(ctx, "RevertCard")
+	defer span.End()
+
+	var reverted *structs.Card
+	err := s.tx.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		revision, err := s.repo.GetRevision(ctx, req.CardId, req.RevisionId)
+		if err != nil {
+			return err
+		}
+
+		card := structs.Card{
+			ID:     revision.CardID,
+			Front:  revision.OldFront,
+			Back:   revision.OldBack,
+			DeckID: revision.OldDeckID,
+			Author: revision.OldAuthor,
+			Format: revision.OldFormat,
+		}
+
+		if err := s.checkQuota(ctx, []structs.Card{card}); err != nil {
+			return err
+		}
+
+		version, err := s.repo.Update(ctx, card, editorOrAuthor(req.Editor, card.Author))
+		if err != nil {
+			return err
+		}
+
+		if version == 0 {
+			return errs.NotFoundError("card", card.ID)
+		}
+
+		reverted, err = s.repo.GetByID(ctx, card.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.eventSender.SendEvent(ctx, "RevertCard", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
+	}
+
+	setETag(ctx, reverted.Version)
+	return newCardResponse(s.renderer, reverted)
+}
+
+func newCardRevisionResponse(revision *structs.CardRevision) *grpc.CardRevision {
+	return &grpc.CardRevision{
+		Id:        revision.ID,
+		CardId:    revision.CardID,
+		Editor:    revision.Editor,
+		CreatedAt: revision.CreatedAt.Format(time.RFC3339),
+		Before: &grpc.CardSnapshot{
+			Front:  revision.OldFront,
+			Back:   revision.OldBack,
+			DeckId: revision.OldDeckID,
+			Author: revision.OldAuthor,
+			Format: revision.OldFormat,
+		},
+		After: &grpc.CardSnapshot{
+			Front:  revision.NewFront,
+			Back:   revision.NewBack,
+			DeckId: revision.NewDeckID,
+			Author: revision.NewAuthor,
+			Format: revision.NewFormat,
+		},
+		Diff: diff.Fields(
+			diff.Field{Name: "front", Old: revision.OldFront, New: revision.NewFront},
+			diff.Field{Name: "back", Old: revision.OldBack, New: revision.NewBack},
+			diff.Field{Name: "deck_id", Old: strconv.FormatInt(revision.OldDeckID, 10), New: strconv.FormatInt(revision.NewDeckID, 10)},
+			diff.Field{Name: "author", Old: revision.OldAuthor, New: revision.NewAuthor},
+			diff.Field{Name: "format", Old: revision.OldFormat, New: revision.NewFormat},
+		),
+	}
+}
+
+// checkQuota checks cards, which replace the stored cards with the same IDs,
+// against the quota. Cards that are not stored are left for the write to
+// report.
+func (s *CardServiceServer) checkQuota(ctx context.Context, cards []structs.Card) error {
+	if !s.quota.limitsCards() {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+	before, err := s.storedCards(ctx, ids)
+	if err != nil {
+		return err
+	}
+	return s.quota.CheckCards(ctx, cards, before)
+}
+
+// checkPatchQuota checks the stored card with the masked fields of card
+// against the quota.
+func (s *CardServiceServer) checkPatchQuota(ctx context.Context, card structs.Card, paths []string) error {
+	before, err := s.storedCards(ctx, []int64{card.ID})
+	if err != nil || len(before) == 0 {
+		return err
+	}
+
+	after := before[0]
+	for _, path := range paths {
+		switch path {
+		case "front":
+			after.Front = card.Front
+		case "back":
+			after.Back = card.Back
+		case "deck_id":
+			after.DeckID = card.DeckID
+		}
+	}
+	return s.quota.CheckCards(ctx, []structs.Card{after}, before)
+}
+
+// storedCards returns the cards with the given IDs, skipping those that do not
+// exist.
+func (s *CardServiceServer) storedCards(ctx context.Context, ids []int64) ([]structs.Card, error) {
+	cards := make([]structs.Card, 0, len(ids))
+	for _, id := range ids {
+		card, err := s.repo.GetByID(ctx, id)
+		if errors.Is(err, errs.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, *card)
+	}
+	return cards, nil
+}
+
+// editorOrAuthor returns who made a change. Requests without an explicit
+// editor are attributed to the author they carry.
+func editorOrAuthor(editor, author string) string {
+	if editor != "" {
+		return editor
+	}
+	return author
+}
+
+// newCardResponse converts a stored card into its API representation. Cards
+// written in markdown or html also get sanitized rendered_front/rendered_back.
+func newCardResponse(renderer *render.Renderer, card *structs.Card) (*grpc.CardResponse, error) {
+	resp := &grpc.CardResponse{
+		Id:        card.ID,
+		Front:     card.Front,
+		Back:      card.Back,
+		DeckId:    card.DeckID,
+		Author:    card.Author,
+		CreatedAt: card.CreatedAt.Format(time.RFC3339),
+		Format:    card.Format,
+		Version:   card.Version,
+	}
+
+	format, err := render.ParseFormat(card.Format)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if format == render.FormatPlain {
+		return resp, nil
+	}
+
+	if resp.RenderedFront, err = renderer.Render(format, card.Front); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to render card")
+	}
+	if resp.RenderedBack, err = renderer.Render(format, card.Back); err != nil {
+		return nil, status.Error(codes.Internal, "Failed to render card")
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/internal/app/grpc"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/logger"
+	"flash-card-manager/pkg/render"
+	"flash-card-manager/pkg/repository/structs"
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// BatchCreateCards inserts all the cards in one transaction. Unlike CreateCard
+// it does not re-read the cards, and it publishes the events in one go.
+func (s *CardServiceServer) BatchCreateCards(ctx context.Context, req *grpc.BatchCreateCardsRequest) (*grpc.BatchCardsResponse, error) {
+	ctx, span := tracer.Start(ctx, "BatchCreateCards")
+	defer span.End()
+
+	cards := make([]structs.Card, 0, len(req.Cards))
+	for i, item := range req.Cards {
+		format, err := render.ParseFormat(item.Format)
+		if err != nil {
+			return nil, errs.Violation(fmt.Sprintf("cards[%d].format", i), err.Error())
+		}
+		cards = append(cards, structs.Card{
+			Front:  item.Front,
+			Back:   item.Back,
+			DeckID: item.DeckId,
+			Author: item.Author,
+			Format: string(format),
+		})
+	}
+
+	if err := s.quota.CheckCards(ctx, cards, nil); err != nil {
+		return nil, err
+	}
+
+	ids, err := s.repo.BatchAdd(ctx, cards)
+	if err != nil {
+		return nil, batchItemError(err, "cards")
+	}
+	cardsCreated.Add(float64(len(ids)))
+
+	queries := make([]string, 0, len(req.Cards))
+	resp := &grpc.BatchCardsResponse{}
+	for i, id := range ids {
+		queries = append(queries, req.Cards[i].String())
+		resp.Results = append(resp.Results, &grpc.BatchCardResult{Id: id, Version: 1})
+	}
+	s.sendEvents(ctx, "CreateCard", queries)
+
+	return resp, nil
+}
+
+// BatchUpdateCards overwrites all the cards in one transaction. req.Editor is
+// recorded as the editor of every revision.
+func (s *CardServiceServer) BatchUpdateCards(ctx context.Context, req *grpc.BatchUpdateCardsRequest) (*grpc.BatchCardsResponse, error) {
+	ctx, span := tracer.Start(ctx, "BatchUpdateCards")
+	defer span.End()
+
+	cards := make([]structs.Card, 0, len(req.Cards))
+	for i, item := range req.Cards {
+		format, err := render.ParseFormat(item.Format)
+		if err != nil {
+			return nil, errs.Violation(fmt.Sprintf("cards[%d].format", i), err.Error())
+		}
+		cards = append(cards, structs.Card{
+			ID:      item.Id,
+			Front:   item.Front,
+			Back:    item.Back,
+			DeckID:  item.DeckId,
+			Author:  item.Author,
+			Format:  string(format),
+			Version: item.ExpectedVersion,
+		})
+	}
+
+	if err := s.checkQuota(ctx, cards); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.repo.BatchUpdate(ctx, cards, req.Editor)
+	if err != nil {
+		return nil, batchItemError(err, "cards")
+	}
+
+	queries := make([]string, 0, len(req.Cards))
+	resp := &grpc.BatchCardsResponse{}
+	for i, version := range versions {
+		queries = append(queries, req.Cards[i].String())
+		resp.Results = append(resp.Results, &grpc.BatchCardResult{Id: cards[i].ID, Version: version})
+	}
+	s.sendEvents(ctx, "UpdateCard", queries)
+
+	return resp, nil
+}
+
+// BatchDeleteCards moves all the cards to the trash in one statement.
+func (s *CardServiceServer) BatchDeleteCards(ctx context.Context, req *grpc.BatchDeleteCardsRequest) (*grpc.BatchCardsResponse, error) {
+	ctx, span := tracer.Start(ctx, "BatchDeleteCards")
+	defer span.End()
+
+	if err := s.repo.BatchDelete(ctx, req.CardIds); err != nil {
+		return nil, batchItemError(err, "card_ids")
+	}
+
+	queries := make([]string, 0, len(req.CardIds))
+	resp := &grpc.BatchCardsResponse{}
+	for _, id := range req.CardIds {
+		queries = append(queries, (&grpc.DeleteCardRequest{Id: id}).String())
+		resp.Results = append(resp.Results, &grpc.BatchCardResult{Id: id})
+	}
+	s.sendEvents(ctx, "DeleteCard", queries)
+
+	return resp, nil
+}
+
+// MoveCards puts all the cards into another deck in one transaction. Each
+// move is published as an UpdateCard event masked to deck_id.
+func (s *CardServiceServer) MoveCards(ctx context.Context, req *grpc.MoveCardsRequest) (*grpc.BatchCardsResponse, error) {
+	ctx, span := tracer.Start(ctx, "MoveCards")
+	defer span.End()
+
+	if s.quota.limitsCards() {
+		before, err := s.storedCards(ctx, req.CardIds)
+		if err != nil {
+			return nil, err
+		}
+		moved := make([]structs.Card, len(before))
+		for i, card := range before {
+			card.DeckID = req.DeckId
+			moved[i] = card
+		}
+		if err := s.quota.CheckCards(ctx, moved, before); err != nil {
+			return nil, err
+		}
+	}
+
+	versions, err := s.repo.Move(ctx, req.CardIds, req.DeckId, req.Editor)
+	if err != nil {
+		return nil, batchItemError(err, "card_ids")
+	}
+
+	queries := make([]string, 0, len(req.CardIds))
+	resp := &grpc.BatchCardsResponse{}
+	for i, version := range versions {
+		event := &grpc.UpdateCardRequest{
+			Id:         req.CardIds[i],
+			DeckId:     req.DeckId,
+			Editor:     req.Editor,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"deck_id"}},
+		}
+		queries = append(queries, event.String())
+		resp.Results = append(resp.Results, &grpc.BatchCardResult{Id: req.CardIds[i], Version: version})
+	}
+	s.sendEvents(ctx, "UpdateCard", queries)
+
+	return resp, nil
+}
+
+func (s *CardServiceServer) sendEvents(ctx context.Context, eventType string, queries []string) {
+	if err := s.eventSender.SendEvents(ctx, eventType, queries); err != nil {
+		logger.Error(ctx, "Failed to send events to Kafka", zap.Error(err))
+	}
+}
+
+// batchItemError names the request item a *structs.BatchError blames, e.g.
+// "cards[3]: deck not found". Other errors are returned unchanged.
+func batchItemError(err error, field string) error {
+	var batchErr *structs.BatchError
+	if !errors.As(err, &batchErr) {
+		return err
+	}
+
+	item := fmt.Sprintf("%s[%d]", field, batchErr.Index)
+	var domainErr *errs.Error
+	if errors.As(batchErr.Err, &domainErr) {
+		return domainErr.In(item)
+	}
+	return fmt.Errorf("%s: %w", item, batchErr.Err)
+}
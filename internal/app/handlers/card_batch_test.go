@@ -0,0 +1,209 @@
+//go:build unit
+// +build unit
+
+package handlers
+
+import (
+	"context"
+	"flash-card-manager/internal/app/grpc"
+	"flash-card-manager/internal/app/interceptors"
+	"flash-card-manager/internal/infrastructure/kafka"
+	mock_kafka "flash-card-manager/internal/infrastructure/kafka/mocks"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/render"
+	mock_units "flash-card-manager/pkg/repository/interfaces/mocks"
+	"flash-card-manager/pkg/repository/structs"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBatchCreateCardsGRPC(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      *grpc.BatchCreateCardsRequest
+		repoReturn []int64
+		repoErr    error
+		wantErr    bool
+		wantCode   codes.Code
+		wantMsg    string
+	}{
+		{
+			name: "Successful Creation",
+			input: &grpc.BatchCreateCardsRequest{Cards: []*grpc.CreateCardRequest{
+				{Front: "F1", Back: "B1", DeckId: 1, Author: "Author"},
+				{Front: "F2", Back: "B2", DeckId: 1, Author: "Author", Format: "markdown"},
+			}},
+			repoReturn: []int64{10, 11},
+		},
+		{
+			name: "Missing Deck",
+			input: &grpc.BatchCreateCardsRequest{Cards: []*grpc.CreateCardRequest{
+				{Front: "F1", Back: "B1", DeckId: 1, Author: "Author"},
+				{Front: "F2", Back: "B2", DeckId: 2, Author: "Author"},
+			}},
+			repoErr:  &structs.BatchError{Index: 1, Err: errs.NotFoundError("deck", 1)},
+			wantErr:  true,
+			wantCode: codes.NotFound,
+			wantMsg:  "cards[1]: deck not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mock_units.NewMockCardRepository(mockCtrl)
+			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
+
+			server := NewCardServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+			if tt.repoReturn != nil || tt.repoErr != nil {
+				mockRepo.EXPECT().BatchAdd(gomock.Any(), gomock.Len(len(tt.input.Cards))).Return(tt.repoReturn, tt.repoErr)
+			}
+			if !tt.wantErr {
+				mockProducer.EXPECT().SendSyncMessages(gomock.Len(len(tt.input.Cards))).Return(nil)
+			}
+
+			resp, err := server.BatchCreateCards(context.Background(), tt.input)
+
+			if tt.wantErr {
+				assert.Nil(t, resp)
+				st, ok := status.FromError(interceptors.StatusError(err))
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, st.Code())
+				if tt.wantMsg != "" {
+					assert.Equal(t, tt.wantMsg, st.Message())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, resp.Results, len(tt.repoReturn))
+				for i, id := range tt.repoReturn {
+					assert.Equal(t, id, resp.Results[i].Id)
+					assert.Equal(t, int64(1), resp.Results[i].Version)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchUpdateCardsGRPC(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := mock_units.NewMockCardRepository(mockCtrl)
+	mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
+
+	server := NewCardServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+	input := &grpc.BatchUpdateCardsRequest{
+		Editor: "Editor",
+		Cards: []*grpc.UpdateCardRequest{
+			{Id: 1, Front: "F1", Back: "B1", DeckId: 1, Author: "Author"},
+			{Id: 2, Front: "F2", Back: "B2", DeckId: 1, Author: "Author", ExpectedVersion: 3},
+		},
+	}
+
+	mockRepo.EXPECT().BatchUpdate(gomock.Any(), gomock.Any(), "Editor").DoAndReturn(
+		func(_ context.Context, cards []structs.Card, _ string) ([]int64, error) {
+			assert.Equal(t, int64(0), cards[0].Version)
+			assert.Equal(t, int64(3), cards[1].Version)
+			return nil, &structs.BatchError{Index: 1, Err: errs.ConflictError("card", 2)}
+		})
+
+	resp, err := server.BatchUpdateCards(context.Background(), input)
+
+	assert.Nil(t, resp)
+	st, ok := status.FromError(interceptors.StatusError(err))
+	assert.True(t, ok)
+	assert.Equal(t, codes.Aborted, st.Code())
+	assert.Equal(t, "cards[1]: version mismatch", st.Message())
+}
+
+func TestBatchDeleteCardsGRPC(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := mock_units.NewMockCardRepository(mockCtrl)
+	mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
+
+	server := NewCardServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+	mockRepo.EXPECT().BatchDelete(gomock.Any(), []int64{1, 2, 3}).Return(nil)
+	mockProducer.EXPECT().SendSyncMessages(gomock.Any()).DoAndReturn(func(msgs []*sarama.ProducerMessage) error {
+		assert.Len(t, msgs, 3)
+		return nil
+	})
+
+	resp, err := server.BatchDeleteCards(context.Background(), &grpc.BatchDeleteCardsRequest{CardIds: []int64{1, 2, 3}})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Results, 3)
+	assert.Equal(t, int64(3), resp.Results[2].Id)
+}
+
+func TestMoveCardsGRPC(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      *grpc.MoveCardsRequest
+		repoReturn []int64
+		repoErr    error
+		wantErr    bool
+		wantCode   codes.Code
+	}{
+		{
+			name:       "Successful Move",
+			input:      &grpc.MoveCardsRequest{CardIds: []int64{1, 2}, DeckId: 5, Editor: "Editor"},
+			repoReturn: []int64{2, 4},
+		},
+		{
+			name:     "Target Deck Not Found",
+			input:    &grpc.MoveCardsRequest{CardIds: []int64{1, 2}, DeckId: 5},
+			repoErr:  errs.NotFoundError("deck", 1),
+			wantErr:  true,
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "Card Not Found",
+			input:    &grpc.MoveCardsRequest{CardIds: []int64{1, 2}, DeckId: 5},
+			repoErr:  &structs.BatchError{Index: 0, Err: errs.NotFoundError("card", 1)},
+			wantErr:  true,
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mock_units.NewMockCardRepository(mockCtrl)
+			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
+
+			server := NewCardServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+			mockRepo.EXPECT().Move(gomock.Any(), tt.input.CardIds, tt.input.DeckId, tt.input.Editor).Return(tt.repoReturn, tt.repoErr)
+			if !tt.wantErr {
+				mockProducer.EXPECT().SendSyncMessages(gomock.Len(len(tt.input.CardIds))).Return(nil)
+			}
+
+			resp, err := server.MoveCards(context.Background(), tt.input)
+
+			if tt.wantErr {
+				assert.Nil(t, resp)
+				st, ok := status.FromError(interceptors.StatusError(err))
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, st.Code())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.repoReturn[1], resp.Results[1].Version)
+				assert.Equal(t, tt.input.CardIds[1], resp.Results[1].Id)
+			}
+		})
+	}
+}
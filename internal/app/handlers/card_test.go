@@ -7,20 +7,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"homework-3/internal/app/grpc"
-	"homework-3/internal/app/handlers/utils"
-	"homework-3/internal/infrastructure/kafka"
-	mock_kafka "homework-3/internal/infrastructure/kafka/mocks"
-	mock_units "homework-3/pkg/repository/interfaces/mocks"
-	"homework-3/pkg/repository/structs"
+	"flash-card-manager/internal/app/grpc"
+	"flash-card-manager/internal/app/handlers/utils"
+	"flash-card-manager/internal/app/interceptors"
+	"flash-card-manager/internal/infrastructure/kafka"
+	"flash-card-manager/pkg/db"
+	mock_db "flash-card-manager/pkg/db/mocks"
+	mock_kafka "flash-card-manager/internal/infrastructure/kafka/mocks"
+	mock_units "flash-card-manager/pkg/repository/interfaces/mocks"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/render"
+	"flash-card-manager/pkg/repository/structs"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
+// inlineTx runs units of work directly, without a transaction.
+type inlineTx struct{}
+
+func (inlineTx) RunInTx(ctx context.Context, _ db.TxOptions, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 func TestCreateCardGRPC(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -49,24 +63,6 @@ func TestCreateCardGRPC(t *testing.T) {
 			wantCode:           codes.Internal,
 			expectProducerCall: false,
 		},
-		{
-			name:               "Empty Input",
-			input:              &grpc.CreateCardRequest{},
-			repoErr:            nil,
-			repoReturn:         0,
-			wantErr:            true,
-			wantCode:           codes.InvalidArgument,
-			expectProducerCall: false,
-		},
-		{
-			name:               "Missing Required Field",
-			input:              &grpc.CreateCardRequest{Front: "TestFront"},
-			repoErr:            nil,
-			repoReturn:         0,
-			wantErr:            true,
-			wantCode:           codes.InvalidArgument,
-			expectProducerCall: false,
-		},
 	}
 
 	for _, tt := range tests {
@@ -77,18 +73,26 @@ func TestCreateCardGRPC(t *testing.T) {
 			mockRepo := mock_units.NewMockCardRepository(mockCtrl)
 			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
 
-			server := NewCardServiceServer(mockRepo, kafka.NewKafkaEventSender(mockProducer))
+			server := NewCardServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
 
 			if tt.input.Front != "" && tt.input.Back != "" {
 				mockRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(tt.repoReturn, tt.repoErr)
 			}
 
 			if tt.expectProducerCall && tt.repoErr == nil {
-				expectedQuery := fmt.Sprintf("front:\"%s\"  back:\"%s\"  deck_id:%d  author:\"%s\"", tt.input.Front, tt.input.Back, tt.input.DeckId, tt.input.Author)
+				mockRepo.EXPECT().GetByID(gomock.Any(), tt.repoReturn).Return(&structs.Card{
+					ID:      tt.repoReturn,
+					Front:   tt.input.Front,
+					Back:    tt.input.Back,
+					DeckID:  tt.input.DeckId,
+					Author:  tt.input.Author,
+					Format:  string(render.FormatPlain),
+					Version: 1,
+				}, nil)
 
 				matcher := &utils.GRPCKafkaEventMatcher{
 					ExpectedType:  "CreateCard",
-					ExpectedQuery: string(expectedQuery),
+					ExpectedQuery: tt.input.String(),
 				}
 				mockProducer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
 			}
@@ -98,7 +102,7 @@ func TestCreateCardGRPC(t *testing.T) {
 			if tt.wantErr {
 				assert.Nil(t, resp)
 				assert.Error(t, err)
-				st, ok := status.FromError(err)
+				st, ok := status.FromError(interceptors.StatusError(err))
 				assert.True(t, ok)
 				assert.Equal(t, tt.wantCode, st.Code())
 
@@ -110,11 +114,40 @@ func TestCreateCardGRPC(t *testing.T) {
 				assert.Equal(t, tt.input.Author, resp.Author)
 				assert.Equal(t, tt.input.Back, resp.Back)
 				assert.Equal(t, tt.input.DeckId, resp.DeckId)
+				assert.Equal(t, int64(1), resp.Version)
 			}
 		})
 	}
 }
 
+func TestCreateCardCommitFailureGRPC(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := mock_units.NewMockCardRepository(mockCtrl)
+	mockTx := mock_db.NewMockTxManager(mockCtrl)
+	mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
+
+	server := NewCardServiceServer(mockRepo, mockTx, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+	mockTx.EXPECT().RunInTx(gomock.Any(), db.TxOptions{}, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ db.TxOptions, fn func(ctx context.Context) error) error {
+			if err := fn(ctx); err != nil {
+				return err
+			}
+			return errors.New("commit failed")
+		})
+	mockRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	mockRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&structs.Card{ID: 1, Front: "F", Back: "B"}, nil)
+
+	resp, err := server.CreateCard(context.Background(), &grpc.CreateCardRequest{Front: "F", Back: "B", DeckId: 1, Author: "Author"})
+
+	assert.Nil(t, resp)
+	st, ok := status.FromError(interceptors.StatusError(err))
+	assert.True(t, ok)
+	assert.Equal(t, codes.Internal, st.Code())
+}
+
 func TestUpdateCardGRPC(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -152,6 +185,15 @@ func TestUpdateCardGRPC(t *testing.T) {
 			wantCode:           codes.NotFound,
 			expectProducerCall: false,
 		},
+		{
+			name:               "Stale Expected Version",
+			input:              &grpc.UpdateCardRequest{Id: 1, Front: "UpdatedFront", Back: "UpdatedBack", ExpectedVersion: 1},
+			repoErr:            errs.ConflictError("card", 1),
+			repoReturn:         0,
+			wantErr:            true,
+			wantCode:           codes.Aborted,
+			expectProducerCall: false,
+		},
 		{
 			name:               "Invalid ID",
 			input:              &grpc.UpdateCardRequest{Id: -1, Front: "UpdatedFront", Back: "UpdatedBack"},
@@ -171,16 +213,14 @@ func TestUpdateCardGRPC(t *testing.T) {
 			mockRepo := mock_units.NewMockCardRepository(mockCtrl)
 			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
 
-			server := NewCardServiceServer(mockRepo, kafka.NewKafkaEventSender(mockProducer))
+			server := NewCardServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
 
-			mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(tt.repoReturn, tt.repoErr)
+			mockRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.repoReturn, tt.repoErr)
 
 			if tt.expectProducerCall && tt.repoErr == nil {
-				expectedQuery := fmt.Sprintf("id:%d  front:\"%s\"  back:\"%s\"", tt.input.Id, tt.input.Front, tt.input.Back)
-
 				matcher := &utils.GRPCKafkaEventMatcher{
 					ExpectedType:  "UpdateCard",
-					ExpectedQuery: expectedQuery,
+					ExpectedQuery: tt.input.String(),
 				}
 				mockProducer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
 			}
@@ -190,7 +230,7 @@ func TestUpdateCardGRPC(t *testing.T) {
 			if tt.wantErr {
 				assert.Nil(t, resp)
 				assert.Error(t, err)
-				st, ok := status.FromError(err)
+				st, ok := status.FromError(interceptors.StatusError(err))
 				assert.True(t, ok)
 				assert.Equal(t, tt.wantCode, st.Code())
 			} else {
@@ -201,6 +241,145 @@ func TestUpdateCardGRPC(t *testing.T) {
 				assert.Equal(t, tt.input.Author, resp.Author)
 				assert.Equal(t, tt.input.Back, resp.Back)
 				assert.Equal(t, tt.input.DeckId, resp.DeckId)
+				assert.Equal(t, tt.repoReturn, resp.Version)
+			}
+		})
+	}
+}
+
+func TestUpdateCardIfMatchGRPC(t *testing.T) {
+	tests := []struct {
+		name            string
+		ifMatch         string
+		expectedVersion int64
+		wantErr         bool
+		wantCode        codes.Code
+	}{
+		{
+			name:            "Strong ETag",
+			ifMatch:         `"3"`,
+			expectedVersion: 3,
+		},
+		{
+			name:            "Weak ETag",
+			ifMatch:         `W/"4"`,
+			expectedVersion: 4,
+		},
+		{
+			name:            "Any Version",
+			ifMatch:         "*",
+			expectedVersion: 0,
+		},
+		{
+			name:     "Malformed ETag",
+			ifMatch:  `"abc"`,
+			wantErr:  true,
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mock_units.NewMockCardRepository(mockCtrl)
+			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
+
+			server := NewCardServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+			input := &grpc.UpdateCardRequest{Id: 1, Front: "UpdatedFront", Back: "UpdatedBack"}
+
+			if !tt.wantErr {
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, card structs.Card, _ string) (int64, error) {
+						assert.Equal(t, tt.expectedVersion, card.Version)
+						return card.Version + 1, nil
+					})
+				mockProducer.EXPECT().SendSyncMessage(gomock.Any()).Return(int32(1), int64(1), nil)
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("if-match", tt.ifMatch))
+			resp, err := server.UpdateCard(ctx, input)
+
+			if tt.wantErr {
+				assert.Nil(t, resp)
+				st, ok := status.FromError(interceptors.StatusError(err))
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, st.Code())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedVersion+1, resp.Version)
+			}
+		})
+	}
+}
+
+func TestPatchCardGRPC(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      *grpc.UpdateCardRequest
+		wantFields []string
+		wantErr    bool
+		wantCode   codes.Code
+	}{
+		{
+			name:       "Move To Another Deck",
+			input:      &grpc.UpdateCardRequest{Id: 1, DeckId: 2, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"deck_id"}}},
+			wantFields: []string{"deck_id"},
+		},
+		{
+			name:       "Change Author And Format",
+			input:      &grpc.UpdateCardRequest{Id: 1, Author: "NewAuthor", Format: "markdown", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"format", "author"}}},
+			wantFields: []string{"author", "format"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mock_units.NewMockCardRepository(mockCtrl)
+			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
+
+			server := NewCardServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+			if !tt.wantErr {
+				stored := &structs.Card{ID: 1, Front: "Front", Back: "Back", DeckID: 1, Author: "Author", Format: "plain", Version: 2}
+				mockRepo.EXPECT().Patch(gomock.Any(), gomock.Any(), tt.wantFields, gomock.Any()).DoAndReturn(
+					func(_ context.Context, card structs.Card, _ []string, _ string) (int64, error) {
+						if tt.input.DeckId != 0 {
+							stored.DeckID = card.DeckID
+						}
+						if tt.input.Author != "" {
+							stored.Author = card.Author
+							stored.Format = card.Format
+						}
+						return stored.Version, nil
+					})
+				mockRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(stored, nil)
+				mockProducer.EXPECT().SendSyncMessage(gomock.Any()).Return(int32(1), int64(1), nil)
+			}
+
+			resp, err := server.UpdateCard(context.Background(), tt.input)
+
+			if tt.wantErr {
+				assert.Nil(t, resp)
+				st, ok := status.FromError(interceptors.StatusError(err))
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, st.Code())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Front", resp.Front)
+				assert.Equal(t, "Back", resp.Back)
+				if tt.input.DeckId != 0 {
+					assert.Equal(t, tt.input.DeckId, resp.DeckId)
+				}
+				if tt.input.Author != "" {
+					assert.Equal(t, tt.input.Author, resp.Author)
+					assert.NotEmpty(t, resp.RenderedFront)
+				}
 			}
 		})
 	}
@@ -231,13 +410,6 @@ func TestDeleteCardGRPC(t *testing.T) {
 			wantCode:           codes.Internal,
 			expectProducerCall: false,
 		},
-		{
-			name:               "Invalid ID",
-			input:              &grpc.DeleteCardRequest{Id: -1},
-			wantErr:            true,
-			wantCode:           codes.InvalidArgument,
-			expectProducerCall: false,
-		},
 	}
 
 	for _, tt := range tests {
@@ -248,11 +420,9 @@ func TestDeleteCardGRPC(t *testing.T) {
 			mockRepo := mock_units.NewMockCardRepository(mockCtrl)
 			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
 
-			server := NewCardServiceServer(mockRepo, kafka.NewKafkaEventSender(mockProducer))
+			server := NewCardServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
 
-			if tt.input.Id > 0 {
-				mockRepo.EXPECT().Delete(gomock.Any(), tt.input.Id).Return(tt.repoErr)
-			}
+			mockRepo.EXPECT().Delete(gomock.Any(), tt.input.Id).Return(tt.repoErr)
 
 			if tt.expectProducerCall && tt.repoErr == nil {
 				expectedQuery := fmt.Sprintf("id:%d", tt.input.Id)
@@ -268,7 +438,7 @@ func TestDeleteCardGRPC(t *testing.T) {
 			if tt.wantErr {
 				assert.Nil(t, resp)
 				assert.Error(t, err)
-				st, ok := status.FromError(err)
+				st, ok := status.FromError(interceptors.StatusError(err))
 				assert.True(t, ok)
 				assert.Equal(t, tt.wantCode, st.Code())
 			} else {
@@ -302,18 +472,11 @@ func TestGetCardByIdGRPC(t *testing.T) {
 			name:               "Failed GetByID - Card Not Found",
 			input:              &grpc.GetCardByIdRequest{Id: 1},
 			repoReturn:         nil,
-			repoErr:            errors.New("card not found"),
+			repoErr:            errs.NotFoundError("card", 1),
 			wantErr:            true,
 			wantCode:           codes.NotFound,
 			expectProducerCall: false,
 		},
-		{
-			name:               "Invalid ID",
-			input:              &grpc.GetCardByIdRequest{Id: -1},
-			wantErr:            true,
-			wantCode:           codes.InvalidArgument,
-			expectProducerCall: false,
-		},
 	}
 
 	for _, tt := range tests {
@@ -324,18 +487,16 @@ func TestGetCardByIdGRPC(t *testing.T) {
 			mockRepo := mock_units.NewMockCardRepository(mockCtrl)
 			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
 
-			server := NewCardServiceServer(mockRepo, kafka.NewKafkaEventSender(mockProducer))
-
-			if tt.input.Id > 0 {
-				mockRepo.EXPECT().GetByID(gomock.Any(), tt.input.Id).Return(tt.repoReturn, tt.repoErr)
-				if tt.expectProducerCall && tt.repoErr == nil {
-					expectedQuery := fmt.Sprintf("id:%d", tt.input.Id)
-					matcher := &utils.GRPCKafkaEventMatcher{
-						ExpectedType:  "GetCardById",
-						ExpectedQuery: expectedQuery,
-					}
-					mockProducer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
+			server := NewCardServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+			mockRepo.EXPECT().GetByID(gomock.Any(), tt.input.Id).Return(tt.repoReturn, tt.repoErr)
+			if tt.expectProducerCall && tt.repoErr == nil {
+				expectedQuery := fmt.Sprintf("id:%d", tt.input.Id)
+				matcher := &utils.GRPCKafkaEventMatcher{
+					ExpectedType:  "GetCardById",
+					ExpectedQuery: expectedQuery,
 				}
+				mockProducer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
 			}
 
 			resp, err := server.GetCardById(context.Background(), tt.input)
@@ -343,7 +504,7 @@ func TestGetCardByIdGRPC(t *testing.T) {
 			if tt.wantErr {
 				assert.Nil(t, resp)
 				assert.Error(t, err)
-				st, ok := status.FromError(err)
+				st, ok := status.FromError(interceptors.StatusError(err))
 				assert.True(t, ok)
 				assert.Equal(t, tt.wantCode, st.Code())
 			} else {
@@ -355,3 +516,200 @@ func TestGetCardByIdGRPC(t *testing.T) {
 		})
 	}
 }
+
+func TestListCardRevisionsGRPC(t *testing.T) {
+	tests := []struct {
+		name               string
+		input              *grpc.ListCardRevisionsRequest
+		wantPage           structs.Page
+		repoReturn         []structs.CardRevision
+		repoErr            error
+		wantErr            bool
+		wantCode           codes.Code
+		wantDiff           string
+		wantRevisions      int
+		wantNextPageToken  string
+		expectProducerCall bool
+	}{
+		{
+			name:     "Successful Listing",
+			input:    &grpc.ListCardRevisionsRequest{CardId: 1},
+			wantPage: structs.Page{Size: 21},
+			repoReturn: []structs.CardRevision{
+				{ID: 2, CardID: 1, Editor: "Editor", OldFront: "cat", OldBack: "кот", NewFront: "dog", NewBack: "кот"},
+			},
+			wantErr:            false,
+			wantCode:           codes.OK,
+			wantDiff:           "--- front\n+++ front\n-cat\n+dog\n",
+			wantRevisions:      1,
+			expectProducerCall: true,
+		},
+		{
+			name:     "Next Page",
+			input:    &grpc.ListCardRevisionsRequest{CardId: 1, PageSize: 1, PageToken: "5"},
+			wantPage: structs.Page{Size: 2, After: 5},
+			repoReturn: []structs.CardRevision{
+				{ID: 4, CardID: 1, Editor: "Editor", OldFront: "cat", NewFront: "dog"},
+				{ID: 3, CardID: 1, Editor: "Editor", OldFront: "bird", NewFront: "cat"},
+			},
+			wantErr:            false,
+			wantCode:           codes.OK,
+			wantDiff:           "--- front\n+++ front\n-cat\n+dog\n",
+			wantRevisions:      1,
+			wantNextPageToken:  "4",
+			expectProducerCall: true,
+		},
+		{
+			name:               "Repository Error",
+			input:              &grpc.ListCardRevisionsRequest{CardId: 1},
+			wantPage:           structs.Page{Size: 21},
+			repoErr:            errors.New("some error"),
+			wantErr:            true,
+			wantCode:           codes.Internal,
+			expectProducerCall: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mock_units.NewMockCardRepository(mockCtrl)
+			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
+
+			server := NewCardServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+			mockRepo.EXPECT().ListRevisions(gomock.Any(), tt.input.CardId, tt.wantPage).Return(tt.repoReturn, tt.repoErr)
+
+			if tt.expectProducerCall {
+				matcher := &utils.GRPCKafkaEventMatcher{
+					ExpectedType:  "ListCardRevisions",
+					ExpectedQuery: tt.input.String(),
+				}
+				mockProducer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
+			}
+
+			resp, err := server.ListCardRevisions(context.Background(), tt.input)
+
+			if tt.wantErr {
+				assert.Nil(t, resp)
+				st, ok := status.FromError(interceptors.StatusError(err))
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, st.Code())
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, resp.Revisions, tt.wantRevisions)
+				assert.Equal(t, tt.wantNextPageToken, resp.NextPageToken)
+				assert.Equal(t, tt.wantDiff, resp.Revisions[0].Diff)
+				assert.Equal(t, "cat", resp.Revisions[0].Before.Front)
+				assert.Equal(t, "dog", resp.Revisions[0].After.Front)
+			}
+		})
+	}
+}
+
+func TestRevertCardGRPC(t *testing.T) {
+	revision := &structs.CardRevision{
+		ID:        2,
+		CardID:    1,
+		Editor:    "Editor",
+		OldFront:  "cat",
+		OldBack:   "кот",
+		OldDeckID: 1,
+		OldAuthor: "Author",
+		OldFormat: "plain",
+		NewFront:  "dog",
+		NewBack:   "кот",
+		NewDeckID: 1,
+		NewAuthor: "Author",
+		NewFormat: "plain",
+	}
+
+	tests := []struct {
+		name               string
+		input              *grpc.RevertCardRequest
+		revisionErr        error
+		updateReturn       int64
+		wantErr            bool
+		wantCode           codes.Code
+		expectUpdate       bool
+		expectProducerCall bool
+	}{
+		{
+			name:               "Successful Revert",
+			input:              &grpc.RevertCardRequest{CardId: 1, RevisionId: 2, Editor: "Reviewer"},
+			updateReturn:       1,
+			wantErr:            false,
+			wantCode:           codes.OK,
+			expectUpdate:       true,
+			expectProducerCall: true,
+		},
+		{
+			name:        "Revision Not Found",
+			input:       &grpc.RevertCardRequest{CardId: 1, RevisionId: 3},
+			revisionErr: errs.NotFoundError("revision", 1),
+			wantErr:     true,
+			wantCode:    codes.NotFound,
+		},
+		{
+			name:         "Card Deleted",
+			input:        &grpc.RevertCardRequest{CardId: 1, RevisionId: 2},
+			updateReturn: 0,
+			wantErr:      true,
+			wantCode:     codes.NotFound,
+			expectUpdate: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mock_units.NewMockCardRepository(mockCtrl)
+			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
+
+			server := NewCardServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+			if tt.revisionErr != nil {
+				mockRepo.EXPECT().GetRevision(gomock.Any(), tt.input.CardId, tt.input.RevisionId).Return(nil, tt.revisionErr)
+			} else {
+				mockRepo.EXPECT().GetRevision(gomock.Any(), tt.input.CardId, tt.input.RevisionId).Return(revision, nil)
+			}
+
+			if tt.expectUpdate {
+				expectedCard := structs.Card{ID: 1, Front: "cat", Back: "кот", DeckID: 1, Author: "Author", Format: "plain"}
+				expectedEditor := tt.input.Editor
+				if expectedEditor == "" {
+					expectedEditor = expectedCard.Author
+				}
+				mockRepo.EXPECT().Update(gomock.Any(), expectedCard, expectedEditor).Return(tt.updateReturn, nil)
+				if tt.updateReturn > 0 {
+					mockRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&expectedCard, nil)
+				}
+			}
+
+			if tt.expectProducerCall {
+				matcher := &utils.GRPCKafkaEventMatcher{
+					ExpectedType:  "RevertCard",
+					ExpectedQuery: tt.input.String(),
+				}
+				mockProducer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
+			}
+
+			resp, err := server.RevertCard(context.Background(), tt.input)
+
+			if tt.wantErr {
+				assert.Nil(t, resp)
+				st, ok := status.FromError(interceptors.StatusError(err))
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, st.Code())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "cat", resp.Front)
+				assert.Equal(t, "кот", resp.Back)
+			}
+		})
+	}
+}
@@ -3,81 +3,80 @@ package handlers
 import (
 	"context"
 	"flash-card-manager/internal/app/grpc"
+	"flash-card-manager/internal/app/interceptors"
 	"flash-card-manager/internal/infrastructure/kafka"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/diff"
+	"flash-card-manager/pkg/errs"
 	"flash-card-manager/pkg/logger"
+	"flash-card-manager/pkg/render"
 	"flash-card-manager/pkg/repository/interfaces"
 	"flash-card-manager/pkg/repository/structs"
 	"time"
 
-	"github.com/opentracing/opentracing-go"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type DeckServiceServer struct {
 	repo        interfaces.DeckRepository
+	tx          db.TxManager
 	eventSender kafka.EventSender
+	renderer    *render.Renderer
+	quota       *Quota
 	grpc.UnimplementedDeckServiceServer
 }
 
-func NewDeckServiceServer(r interfaces.DeckRepository, eventSender kafka.EventSender) *DeckServiceServer {
-	return &DeckServiceServer{repo: r, eventSender: eventSender}
+func NewDeckServiceServer(r interfaces.DeckRepository, tx db.TxManager, eventSender kafka.EventSender, renderer *render.Renderer, quota *Quota) *DeckServiceServer {
+	return &DeckServiceServer{repo: r, tx: tx, eventSender: eventSender, renderer: renderer, quota: quota}
 }
 
 func (s *DeckServiceServer) CreateDeck(ctx context.Context, req *grpc.CreateDeckRequest) (*grpc.DeckResponse, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "CreateDeck")
-	defer span.Finish()
-
-	if req.Title == "" || req.Description == "" || req.Author == "" {
-		return nil, status.Error(codes.InvalidArgument, "title, description, and author are required")
-	}
+	ctx, span := tracer.Start(ctx, "CreateDeck")
+	defer span.End()
 
 	deck := structs.Deck{
 		Title:       req.Title,
 		Description: req.Description,
 		Author:      req.Author,
+		Owner:       interceptors.UserFromContext(ctx),
+	}
+
+	if err := s.quota.CheckDeck(ctx, deck.Owner); err != nil {
+		return nil, err
 	}
 
 	id, err := s.repo.Add(ctx, deck)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
-	if err := s.eventSender.SendEvent("CreateDeck", req.String()); err != nil {
-		logger.Errorf(ctx, "Failed to send event to Kafka: %s", err)
+	if err := s.eventSender.SendEvent(ctx, "CreateDeck", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
 	}
 
+	// New decks always start at the first version.
+	setETag(ctx, 1)
 	return &grpc.DeckResponse{
 		Id:          id,
 		Title:       req.Title,
 		Description: req.Description,
 		Author:      req.Author,
+		Version:     1,
 	}, nil
 }
 
 func (s *DeckServiceServer) GetDeckById(ctx context.Context, req *grpc.GetDeckByIdRequest) (*grpc.DeckWithCardsResponse, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "GetDeckById")
-	defer span.Finish()
-
-	if req.Id <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "Invalid ID parameter")
-	}
+	ctx, span := tracer.Start(ctx, "GetDeckById")
+	defer span.End()
 
 	deckWithCards, err := s.repo.GetWithCardsByID(ctx, req.Id)
 	if err != nil {
-		if err.Error() == "deck not found" {
-			return nil, status.Error(codes.NotFound, "Deck not found")
-		}
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-
-	if deckWithCards == nil {
-		return nil, status.Error(codes.NotFound, "Deck not found")
+		return nil, err
 	}
 
-	if err := s.eventSender.SendEvent("GetDeckById", req.String()); err != nil {
-		logger.Errorf(ctx, "Failed to send event to Kafka: %s", err)
+	if err := s.eventSender.SendEvent(ctx, "GetDeckById", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
 	}
 
 	deckResponse := &grpc.DeckResponse{
@@ -86,20 +85,19 @@ func (s *DeckServiceServer) GetDeckById(ctx context.Context, req *grpc.GetDeckBy
 		Description: deckWithCards.Deck.Description,
 		Author:      deckWithCards.Deck.Author,
 		CreatedAt:   deckWithCards.Deck.CreatedAt.Format(time.RFC3339),
+		Version:     deckWithCards.Deck.Version,
 	}
 
 	var cardResponses []*grpc.CardResponse
-	for _, card := range deckWithCards.Cards {
-		cardResponses = append(cardResponses, &grpc.CardResponse{
-			Id:        card.ID,
-			Front:     card.Front,
-			Back:      card.Back,
-			DeckId:    card.DeckID,
-			Author:    card.Author,
-			CreatedAt: card.CreatedAt.Format(time.RFC3339),
-		})
+	for i := range deckWithCards.Cards {
+		cardResponse, err := newCardResponse(s.renderer, &deckWithCards.Cards[i])
+		if err != nil {
+			return nil, err
+		}
+		cardResponses = append(cardResponses, cardResponse)
 	}
 
+	setETag(ctx, deckWithCards.Deck.Version)
 	return &grpc.DeckWithCardsResponse{
 		Deck:  deckResponse,
 		Cards: cardResponses,
@@ -107,11 +105,16 @@ func (s *DeckServiceServer) GetDeckById(ctx context.Context, req *grpc.GetDeckBy
 }
 
 func (s *DeckServiceServer) UpdateDeck(ctx context.Context, req *grpc.UpdateDeckRequest) (*grpc.DeckResponse, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "UpdateDeck")
-	defer span.Finish()
+	ctx, span := tracer.Start(ctx, "UpdateDeck")
+	defer span.End()
 
-	if req.Id == 0 || req.Title == "" || req.Description == "" || req.Author == "" {
-		return nil, status.Error(codes.InvalidArgument, "Invalid request payload")
+	if len(req.GetUpdateMask().GetPaths()) > 0 {
+		return s.patchDeck(ctx, req)
+	}
+
+	version, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
 	}
 
 	deck := structs.Deck{
@@ -119,45 +122,189 @@ func (s *DeckServiceServer) UpdateDeck(ctx context.Context, req *grpc.UpdateDeck
 		Title:       req.Title,
 		Description: req.Description,
 		Author:      req.Author,
+		Version:     version,
 	}
 
-	updatedRows, err := s.repo.Update(ctx, deck)
+	deck.Version, err = s.repo.Update(ctx, deck, editorOrAuthor(req.Editor, req.Author))
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
-	if updatedRows == 0 {
-		return nil, status.Error(codes.NotFound, "Deck not found")
+	if deck.Version == 0 {
+		return nil, errs.NotFoundError("deck", req.Id)
 	}
 
-	if err := s.eventSender.SendEvent("UpdateDeck", req.String()); err != nil {
-		logger.Errorf(ctx, "Failed to send event to Kafka: %s", err)
+	if err := s.eventSender.SendEvent(ctx, "UpdateDeck", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
 	}
 
+	setETag(ctx, deck.Version)
 	return &grpc.DeckResponse{
 		Id:          deck.ID,
 		Title:       deck.Title,
 		Description: deck.Description,
 		Author:      deck.Author,
+		Version:     deck.Version,
 	}, nil
 }
 
-func (s *DeckServiceServer) DeleteDeck(ctx context.Context, req *grpc.DeleteDeckRequest) (*emptypb.Empty, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "DeleteDeck")
-	defer span.Finish()
+// patchDeck changes only the fields listed in the update mask and answers
+// with the deck as stored afterwards.
+func (s *DeckServiceServer) patchDeck(ctx context.Context, req *grpc.UpdateDeckRequest) (*grpc.DeckResponse, error) {
+	req.UpdateMask.Normalize()
+
+	version, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	deck := structs.Deck{
+		ID:          req.Id,
+		Title:       req.Title,
+		Description: req.Description,
+		Author:      req.Author,
+		Version:     version,
+	}
+
+	var patched *structs.Deck
+	err = s.tx.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		version, err := s.repo.Patch(ctx, deck, req.UpdateMask.Paths, editorOrAuthor(req.Editor, req.Author))
+		if err != nil {
+			return err
+		}
+
+		if version == 0 {
+			return errs.NotFoundError("deck", req.Id)
+		}
 
-	if req.Id <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "Invalid ID format")
+		patched, err = s.repo.GetByID(ctx, req.Id)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	if err := s.eventSender.SendEvent(ctx, "UpdateDeck", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
+	}
+
+	setETag(ctx, patched.Version)
+	return &grpc.DeckResponse{
+		Id:          patched.ID,
+		Title:       patched.Title,
+		Description: patched.Description,
+		Author:      patched.Author,
+		CreatedAt:   patched.CreatedAt.Format(time.RFC3339),
+		Version:     patched.Version,
+	}, nil
+}
+
+func (s *DeckServiceServer) DeleteDeck(ctx context.Context, req *grpc.DeleteDeckRequest) (*emptypb.Empty, error) {
+	ctx, span := tracer.Start(ctx, "DeleteDeck")
+	defer span.End()
+
 	err := s.repo.Delete(ctx, req.Id)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
+	decksDeleted.Inc()
 
-	if err := s.eventSender.SendEvent("DeleteDeck", req.String()); err != nil {
-		logger.Errorf(ctx, "Failed to send event to Kafka: %s", err)
+	if err := s.eventSender.SendEvent(ctx, "DeleteDeck", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
 	}
 
 	return &emptypb.Empty{}, nil
 }
+
+func (s *DeckServiceServer) ListDeckRevisions(ctx context.Context, req *grpc.ListDeckRevisionsRequest) (*grpc.ListDeckRevisionsResponse, error) {
+	ctx, span := tracer.Start(ctx, "ListDeckRevisions")
+	defer span.End()
+
+	revisions, err := s.repo.ListRevisions(ctx, req.DeckId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.eventSender.SendEvent(ctx, "ListDeckRevisions", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
+	}
+
+	resp := &grpc.ListDeckRevisionsResponse{}
+	for i := range revisions {
+		resp.Revisions = append(resp.Revisions, newDeckRevisionResponse(&revisions[i]))
+	}
+
+	return resp, nil
+}
+
+// RevertDeck restores the deck metadata to the state it had before the given
+// revision. The revert itself is recorded as a new revision.
+func (s *DeckServiceServer) RevertDeck(ctx context.Context, req *grpc.RevertDeckRequest) (*grpc.DeckResponse, error) {
+	ctx, span := tracer.Start(ctx, "RevertDeck")
+	defer span.End()
+
+	var deck structs.Deck
+	err := s.tx.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		revision, err := s.repo.GetRevision(ctx, req.DeckId, req.RevisionId)
+		if err != nil {
+			return err
+		}
+
+		deck = structs.Deck{
+			ID:          revision.DeckID,
+			Title:       revision.OldTitle,
+			Description: revision.OldDescription,
+			Author:      revision.OldAuthor,
+		}
+
+		deck.Version, err = s.repo.Update(ctx, deck, editorOrAuthor(req.Editor, deck.Author))
+		if err != nil {
+			return err
+		}
+
+		if deck.Version == 0 {
+			return errs.NotFoundError("deck", deck.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.eventSender.SendEvent(ctx, "RevertDeck", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
+	}
+
+	setETag(ctx, deck.Version)
+	return &grpc.DeckResponse{
+		Id:          deck.ID,
+		Title:       deck.Title,
+		Description: deck.Description,
+		Author:      deck.Author,
+		Version:     deck.Version,
+	}, nil
+}
+
+func newDeckRevisionResponse(revision *structs.DeckRevision) *grpc.DeckRevision {
+	return &grpc.DeckRevision{
+		Id:        revision.ID,
+		DeckId:    revision.DeckID,
+		Editor:    revision.Editor,
+		CreatedAt: revision.CreatedAt.Format(time.RFC3339),
+		Before: &grpc.DeckSnapshot{
+			Title:       revision.OldTitle,
+			Description: revision.OldDescription,
+			Author:      revision.OldAuthor,
+		},
+		After: &grpc.DeckSnapshot{
+			Title:       revision.NewTitle,
+			Description: revision.NewDescription,
+			Author:      revision.NewAuthor,
+		},
+		Diff: diff.Fields(
+			diff.Field{Name: "title", Old: revision.OldTitle, New: revision.NewTitle},
+			diff.Field{Name: "description", Old: revision.OldDescription, New: revision.NewDescription},
+			diff.Field{Name: "author", Old: revision.OldAuthor, New: revision.NewAuthor},
+		),
+	}
+}
@@ -7,12 +7,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"homework-3/internal/app/grpc"
-	"homework-3/internal/app/handlers/utils"
-	"homework-3/internal/infrastructure/kafka"
-	mock_kafka "homework-3/internal/infrastructure/kafka/mocks"
-	mock_units "homework-3/pkg/repository/interfaces/mocks"
-	"homework-3/pkg/repository/structs"
+	"flash-card-manager/internal/app/grpc"
+	"flash-card-manager/internal/app/handlers/utils"
+	"flash-card-manager/internal/app/interceptors"
+	"flash-card-manager/internal/infrastructure/kafka"
+	mock_kafka "flash-card-manager/internal/infrastructure/kafka/mocks"
+	mock_units "flash-card-manager/pkg/repository/interfaces/mocks"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/render"
+	"flash-card-manager/pkg/repository/structs"
 	"testing"
 	"time"
 
@@ -21,6 +24,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func TestCreateDeckGRPC(t *testing.T) {
@@ -51,13 +55,6 @@ func TestCreateDeckGRPC(t *testing.T) {
 			wantCode:           codes.Internal,
 			expectProducerCall: false,
 		},
-		{
-			name:               "Invalid Input",
-			input:              &grpc.CreateDeckRequest{},
-			wantErr:            true,
-			wantCode:           codes.InvalidArgument,
-			expectProducerCall: false,
-		},
 	}
 
 	for _, tt := range tests {
@@ -68,14 +65,14 @@ func TestCreateDeckGRPC(t *testing.T) {
 			mockRepo := mock_units.NewMockDeckRepository(mockCtrl)
 			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
 
-			server := NewDeckServiceServer(mockRepo, kafka.NewKafkaEventSender(mockProducer))
+			server := NewDeckServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
 
 			if tt.input.Title != "" && tt.input.Description != "" && tt.input.Author != "" {
 				mockRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(tt.repoReturn, tt.repoErr)
 			}
 
 			if tt.expectProducerCall && tt.repoErr == nil {
-				expectedQuery := fmt.Sprintf("title:\"%s\" description:\"%s\" author:\"%s\"", tt.input.Title, tt.input.Description, tt.input.Author)
+				expectedQuery := tt.input.String()
 				matcher := &utils.GRPCKafkaEventMatcher{
 					ExpectedType:  "CreateDeck",
 					ExpectedQuery: expectedQuery,
@@ -88,7 +85,7 @@ func TestCreateDeckGRPC(t *testing.T) {
 			if tt.wantErr {
 				assert.Nil(t, resp)
 				assert.Error(t, err)
-				st, ok := status.FromError(err)
+				st, ok := status.FromError(interceptors.StatusError(err))
 				assert.True(t, ok)
 				assert.Equal(t, tt.wantCode, st.Code())
 			} else {
@@ -132,10 +129,12 @@ func TestUpdateDeckGRPC(t *testing.T) {
 			expectProducerCall: false,
 		},
 		{
-			name:               "Invalid Input",
-			input:              &grpc.UpdateDeckRequest{},
+			name:               "Stale Expected Version",
+			input:              &grpc.UpdateDeckRequest{Id: 1, Title: "UpdatedTitle", Description: "UpdatedDescription", Author: "UpdatedAuthor", ExpectedVersion: 1},
+			repoErr:            errs.ConflictError("deck", 1),
+			repoReturn:         0,
 			wantErr:            true,
-			wantCode:           codes.InvalidArgument,
+			wantCode:           codes.Aborted,
 			expectProducerCall: false,
 		},
 	}
@@ -148,17 +147,16 @@ func TestUpdateDeckGRPC(t *testing.T) {
 			mockRepo := mock_units.NewMockDeckRepository(mockCtrl)
 			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
 
-			server := NewDeckServiceServer(mockRepo, kafka.NewKafkaEventSender(mockProducer))
+			server := NewDeckServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
 
 			if tt.input.Id != 0 && tt.input.Title != "" && tt.input.Description != "" && tt.input.Author != "" {
-				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(tt.repoReturn, tt.repoErr)
+				mockRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.repoReturn, tt.repoErr)
 			}
 
 			if tt.expectProducerCall && tt.repoErr == nil {
-				expectedQuery := fmt.Sprintf("id:%d  title:\"%s\"  description:\"%s\"  author:\"%s\"", tt.input.Id, tt.input.Title, tt.input.Description, tt.input.Author)
 				matcher := &utils.GRPCKafkaEventMatcher{
 					ExpectedType:  "UpdateDeck",
-					ExpectedQuery: expectedQuery,
+					ExpectedQuery: tt.input.String(),
 				}
 				mockProducer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
 			}
@@ -168,7 +166,7 @@ func TestUpdateDeckGRPC(t *testing.T) {
 			if tt.wantErr {
 				assert.Nil(t, resp)
 				assert.Error(t, err)
-				st, ok := status.FromError(err)
+				st, ok := status.FromError(interceptors.StatusError(err))
 				assert.True(t, ok)
 				assert.Equal(t, tt.wantCode, st.Code())
 			} else {
@@ -178,6 +176,75 @@ func TestUpdateDeckGRPC(t *testing.T) {
 				assert.Equal(t, tt.input.Title, resp.Title)
 				assert.Equal(t, tt.input.Description, resp.Description)
 				assert.Equal(t, tt.input.Author, resp.Author)
+				assert.Equal(t, tt.repoReturn, resp.Version)
+			}
+		})
+	}
+}
+
+func TestPatchDeckGRPC(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       *grpc.UpdateDeckRequest
+		repoVersion int64
+		repoErr     error
+		wantErr     bool
+		wantCode    codes.Code
+	}{
+		{
+			name:        "Rename Deck",
+			input:       &grpc.UpdateDeckRequest{Id: 1, Title: "Renamed", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}}},
+			repoVersion: 3,
+		},
+		{
+			name:     "Stale Version",
+			input:    &grpc.UpdateDeckRequest{Id: 1, Title: "Renamed", ExpectedVersion: 1, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}}},
+			repoErr:  errs.ConflictError("deck", 1),
+			wantErr:  true,
+			wantCode: codes.Aborted,
+		},
+		{
+			name:     "Deck Not Found",
+			input:    &grpc.UpdateDeckRequest{Id: 9999, Title: "Renamed", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}}},
+			wantErr:  true,
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mock_units.NewMockDeckRepository(mockCtrl)
+			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
+
+			server := NewDeckServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+			mockRepo.EXPECT().Patch(gomock.Any(), gomock.Any(), []string{"title"}, gomock.Any()).Return(tt.repoVersion, tt.repoErr)
+			if !tt.wantErr {
+				mockRepo.EXPECT().GetByID(gomock.Any(), tt.input.Id).Return(&structs.Deck{
+					ID:          tt.input.Id,
+					Title:       tt.input.Title,
+					Description: "Description",
+					Author:      "Author",
+					Version:     tt.repoVersion,
+				}, nil)
+				mockProducer.EXPECT().SendSyncMessage(gomock.Any()).Return(int32(1), int64(1), nil)
+			}
+
+			resp, err := server.UpdateDeck(context.Background(), tt.input)
+
+			if tt.wantErr {
+				assert.Nil(t, resp)
+				st, ok := status.FromError(interceptors.StatusError(err))
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, st.Code())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.input.Title, resp.Title)
+				assert.Equal(t, "Description", resp.Description)
+				assert.Equal(t, tt.repoVersion, resp.Version)
 			}
 		})
 	}
@@ -208,13 +275,6 @@ func TestDeleteDeckGRPC(t *testing.T) {
 			wantCode:           codes.Internal,
 			expectProducerCall: false,
 		},
-		{
-			name:               "Invalid ID",
-			inputID:            -1,
-			wantErr:            true,
-			wantCode:           codes.InvalidArgument,
-			expectProducerCall: false,
-		},
 	}
 
 	for _, tt := range tests {
@@ -225,18 +285,16 @@ func TestDeleteDeckGRPC(t *testing.T) {
 			mockRepo := mock_units.NewMockDeckRepository(mockCtrl)
 			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
 
-			server := NewDeckServiceServer(mockRepo, kafka.NewKafkaEventSender(mockProducer))
-
-			if tt.inputID > 0 {
-				mockRepo.EXPECT().Delete(gomock.Any(), tt.inputID).Return(tt.repoErr)
-				if tt.expectProducerCall && tt.repoErr == nil {
-					expectedQuery := fmt.Sprintf("id:%d", tt.inputID)
-					matcher := &utils.GRPCKafkaEventMatcher{
-						ExpectedType:  "DeleteDeck",
-						ExpectedQuery: expectedQuery,
-					}
-					mockProducer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
+			server := NewDeckServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+			mockRepo.EXPECT().Delete(gomock.Any(), tt.inputID).Return(tt.repoErr)
+			if tt.expectProducerCall && tt.repoErr == nil {
+				expectedQuery := fmt.Sprintf("id:%d", tt.inputID)
+				matcher := &utils.GRPCKafkaEventMatcher{
+					ExpectedType:  "DeleteDeck",
+					ExpectedQuery: expectedQuery,
 				}
+				mockProducer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
 			}
 
 			req := &grpc.DeleteDeckRequest{Id: tt.inputID}
@@ -244,7 +302,7 @@ func TestDeleteDeckGRPC(t *testing.T) {
 
 			if tt.wantErr {
 				assert.Nil(t, resp)
-				st, ok := status.FromError(err)
+				st, ok := status.FromError(interceptors.StatusError(err))
 				assert.True(t, ok)
 				assert.Equal(t, tt.wantCode, st.Code())
 			} else {
@@ -281,18 +339,11 @@ func TestGetDeckByIdGRPC(t *testing.T) {
 			name:               "Deck Not Found",
 			inputID:            1,
 			repoReturn:         nil,
-			repoErr:            errors.New("deck not found"),
+			repoErr:            errs.NotFoundError("deck", 1),
 			wantErr:            true,
 			wantCode:           codes.NotFound,
 			expectProducerCall: false,
 		},
-		{
-			name:               "Invalid ID",
-			inputID:            -1,
-			wantErr:            true,
-			wantCode:           codes.InvalidArgument,
-			expectProducerCall: false,
-		},
 	}
 
 	for _, tt := range tests {
@@ -303,18 +354,16 @@ func TestGetDeckByIdGRPC(t *testing.T) {
 			mockRepo := mock_units.NewMockDeckRepository(mockCtrl)
 			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
 
-			server := NewDeckServiceServer(mockRepo, kafka.NewKafkaEventSender(mockProducer))
-
-			if tt.inputID > 0 {
-				mockRepo.EXPECT().GetWithCardsByID(gomock.Any(), tt.inputID).Return(tt.repoReturn, tt.repoErr)
-				if tt.expectProducerCall && tt.repoErr == nil {
-					expectedQuery := fmt.Sprintf("id:%d", tt.inputID)
-					matcher := &utils.GRPCKafkaEventMatcher{
-						ExpectedType:  "GetDeckById",
-						ExpectedQuery: expectedQuery,
-					}
-					mockProducer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
+			server := NewDeckServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+			mockRepo.EXPECT().GetWithCardsByID(gomock.Any(), tt.inputID).Return(tt.repoReturn, tt.repoErr)
+			if tt.expectProducerCall && tt.repoErr == nil {
+				expectedQuery := fmt.Sprintf("id:%d", tt.inputID)
+				matcher := &utils.GRPCKafkaEventMatcher{
+					ExpectedType:  "GetDeckById",
+					ExpectedQuery: expectedQuery,
 				}
+				mockProducer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
 			}
 
 			req := &grpc.GetDeckByIdRequest{Id: tt.inputID}
@@ -322,7 +371,7 @@ func TestGetDeckByIdGRPC(t *testing.T) {
 
 			if tt.wantErr {
 				assert.Nil(t, resp)
-				st, ok := status.FromError(err)
+				st, ok := status.FromError(interceptors.StatusError(err))
 				assert.True(t, ok)
 				assert.Equal(t, tt.wantCode, st.Code())
 			} else {
@@ -332,3 +381,156 @@ func TestGetDeckByIdGRPC(t *testing.T) {
 		})
 	}
 }
+
+func TestListDeckRevisionsGRPC(t *testing.T) {
+	tests := []struct {
+		name               string
+		inputID            int64
+		repoReturn         []structs.DeckRevision
+		repoErr            error
+		wantErr            bool
+		wantCode           codes.Code
+		wantDiff           string
+		expectProducerCall bool
+	}{
+		{
+			name:    "Successful Listing",
+			inputID: 1,
+			repoReturn: []structs.DeckRevision{
+				{ID: 1, DeckID: 1, Editor: "Editor", OldTitle: "Eng", OldAuthor: "Author", NewTitle: "Eng words", NewAuthor: "Author"},
+			},
+			wantErr:            false,
+			wantCode:           codes.OK,
+			wantDiff:           "--- title\n+++ title\n-Eng\n+Eng words\n",
+			expectProducerCall: true,
+		},
+		{
+			name:     "Repository Error",
+			inputID:  1,
+			repoErr:  errors.New("some error"),
+			wantErr:  true,
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mock_units.NewMockDeckRepository(mockCtrl)
+			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
+
+			server := NewDeckServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+			req := &grpc.ListDeckRevisionsRequest{DeckId: tt.inputID}
+			mockRepo.EXPECT().ListRevisions(gomock.Any(), tt.inputID).Return(tt.repoReturn, tt.repoErr)
+
+			if tt.expectProducerCall {
+				matcher := &utils.GRPCKafkaEventMatcher{
+					ExpectedType:  "ListDeckRevisions",
+					ExpectedQuery: req.String(),
+				}
+				mockProducer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
+			}
+
+			resp, err := server.ListDeckRevisions(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Nil(t, resp)
+				st, ok := status.FromError(interceptors.StatusError(err))
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, st.Code())
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, resp.Revisions, 1)
+				assert.Equal(t, tt.wantDiff, resp.Revisions[0].Diff)
+			}
+		})
+	}
+}
+
+func TestRevertDeckGRPC(t *testing.T) {
+	revision := &structs.DeckRevision{
+		ID:             4,
+		DeckID:         1,
+		Editor:         "Editor",
+		OldTitle:       "Eng words",
+		OldDescription: "Description",
+		OldAuthor:      "Author",
+		NewTitle:       "broken",
+		NewDescription: "",
+		NewAuthor:      "Author",
+	}
+
+	tests := []struct {
+		name               string
+		input              *grpc.RevertDeckRequest
+		revisionErr        error
+		updateReturn       int64
+		wantErr            bool
+		wantCode           codes.Code
+		expectUpdate       bool
+		expectProducerCall bool
+	}{
+		{
+			name:               "Successful Revert",
+			input:              &grpc.RevertDeckRequest{DeckId: 1, RevisionId: 4, Editor: "Reviewer"},
+			updateReturn:       1,
+			wantErr:            false,
+			wantCode:           codes.OK,
+			expectUpdate:       true,
+			expectProducerCall: true,
+		},
+		{
+			name:        "Revision Not Found",
+			input:       &grpc.RevertDeckRequest{DeckId: 1, RevisionId: 5},
+			revisionErr: errs.NotFoundError("revision", 1),
+			wantErr:     true,
+			wantCode:    codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRepo := mock_units.NewMockDeckRepository(mockCtrl)
+			mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
+
+			server := NewDeckServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), nil)
+
+			if tt.revisionErr != nil {
+				mockRepo.EXPECT().GetRevision(gomock.Any(), tt.input.DeckId, tt.input.RevisionId).Return(nil, tt.revisionErr)
+			} else {
+				mockRepo.EXPECT().GetRevision(gomock.Any(), tt.input.DeckId, tt.input.RevisionId).Return(revision, nil)
+			}
+
+			if tt.expectUpdate {
+				expectedDeck := structs.Deck{ID: 1, Title: "Eng words", Description: "Description", Author: "Author"}
+				mockRepo.EXPECT().Update(gomock.Any(), expectedDeck, tt.input.Editor).Return(tt.updateReturn, nil)
+			}
+
+			if tt.expectProducerCall {
+				matcher := &utils.GRPCKafkaEventMatcher{
+					ExpectedType:  "RevertDeck",
+					ExpectedQuery: tt.input.String(),
+				}
+				mockProducer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
+			}
+
+			resp, err := server.RevertDeck(context.Background(), tt.input)
+
+			if tt.wantErr {
+				assert.Nil(t, resp)
+				st, ok := status.FromError(interceptors.StatusError(err))
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, st.Code())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Eng words", resp.Title)
+			}
+		})
+	}
+}
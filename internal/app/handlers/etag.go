@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	etagHeader    = "etag"
+	ifMatchHeader = "if-match"
+)
+
+// expectedVersion returns the version an update is conditional on. An explicit
+// expected_version in the request wins over an If-Match header forwarded by
+// the gateway. Zero means the update is unconditional.
+func expectedVersion(ctx context.Context, fromRequest int64) (int64, error) {
+	if fromRequest != 0 {
+		return fromRequest, nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, nil
+	}
+
+	values := md.Get(ifMatchHeader)
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	tag := strings.TrimSpace(values[0])
+	if tag == "*" {
+		return 0, nil
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(tag, "W/"), `"`), 10, 64)
+	if err != nil || version <= 0 {
+		return 0, status.Error(codes.InvalidArgument, "If-Match must be an ETag returned by the server")
+	}
+
+	return version, nil
+}
+
+// setETag sends the resource version back as an etag header, which the
+// gateway turns into an HTTP ETag.
+func setETag(ctx context.Context, version int64) {
+	if version <= 0 {
+		return
+	}
+	// SetHeader only fails outside of a gRPC call, e.g. in unit tests.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(etagHeader, strconv.Quote(strconv.FormatInt(version, 10))))
+}
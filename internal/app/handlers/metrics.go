@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"flash-card-manager/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	cardsCreated = promauto.With(metrics.Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "cards_created_total",
+		Help:      "Cards created, one at a time or in batches.",
+	})
+
+	decksDeleted = promauto.With(metrics.Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "decks_deleted_total",
+		Help:      "Decks moved to the trash.",
+	})
+)
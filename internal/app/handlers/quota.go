@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/internal/app/grpc"
+	"flash-card-manager/internal/app/interceptors"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+	"fmt"
+)
+
+// QuotaLimits are the storage limits per user. Zero means no limit.
+type QuotaLimits struct {
+	DecksPerUser int64
+	CardsPerDeck int64
+	// MediaBytes bounds the bytes of the front and back of all the cards in
+	// the decks a user owns.
+	MediaBytes int64
+}
+
+// Quota checks writes against QuotaLimits before they are made. The check and
+// the write are not atomic, so concurrent requests may overshoot a limit by a
+// few items. A nil *Quota allows everything.
+type Quota struct {
+	usage  interfaces.UsageRepository
+	limits QuotaLimits
+}
+
+func NewQuota(usage interfaces.UsageRepository, limits QuotaLimits) *Quota {
+	return &Quota{usage: usage, limits: limits}
+}
+
+// CheckDeck fails if owner cannot have another deck.
+func (q *Quota) CheckDeck(ctx context.Context, owner string) error {
+	if q == nil || q.limits.DecksPerUser == 0 {
+		return nil
+	}
+
+	usage, err := q.usage.Usage(ctx, owner)
+	if err != nil {
+		return err
+	}
+	if usage.Decks >= q.limits.DecksPerUser {
+		return errs.QuotaExceededError("deck", 0, fmt.Sprintf("quota exceeded: at most %d decks per user", q.limits.DecksPerUser))
+	}
+	return nil
+}
+
+// CheckCards fails if storing cards in place of before, the stored versions
+// of the same cards (nil for new ones), would put more than the limit into a
+// deck or take its owner past the media bytes limit. Decks that do not exist
+// are left for the write itself to report.
+func (q *Quota) CheckCards(ctx context.Context, cards, before []structs.Card) error {
+	if !q.limitsCards() {
+		return nil
+	}
+
+	added := map[int64]int64{}
+	grown := map[int64]int64{}
+	for _, card := range cards {
+		added[card.DeckID]++
+		grown[card.DeckID] += cardBytes(card)
+	}
+	for _, card := range before {
+		added[card.DeckID]--
+		grown[card.DeckID] -= cardBytes(card)
+	}
+
+	owners := map[string]int64{}
+	for deckID := range added {
+		if added[deckID] <= 0 && grown[deckID] == 0 {
+			continue
+		}
+
+		deck, err := q.usage.DeckUsage(ctx, deckID)
+		if errors.Is(err, errs.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if limit := q.limits.CardsPerDeck; limit > 0 && added[deckID] > 0 && deck.Cards+added[deckID] > limit {
+			return errs.QuotaExceededError("deck", deckID, fmt.Sprintf("quota exceeded: at most %d cards per deck", limit))
+		}
+		owners[deck.Owner] += grown[deckID]
+	}
+
+	if q.limits.MediaBytes == 0 {
+		return nil
+	}
+	for owner, grown := range owners {
+		if grown <= 0 {
+			continue
+		}
+		usage, err := q.usage.Usage(ctx, owner)
+		if err != nil {
+			return err
+		}
+		if usage.MediaBytes+grown > q.limits.MediaBytes {
+			return errs.QuotaExceededError("media", 0, fmt.Sprintf("quota exceeded: at most %d bytes of cards per user", q.limits.MediaBytes))
+		}
+	}
+	return nil
+}
+
+// CheckRestore fails if restoring from the trash took the deck deckID or its
+// owner past a limit. It runs after the restore, in the same transaction, so
+// that failing rolls the restore back. restoredDeck says whether the deck
+// itself came back and so counts against the decks limit.
+func (q *Quota) CheckRestore(ctx context.Context, deckID int64, restoredDeck bool) error {
+	checkDecks := restoredDeck && q != nil && q.limits.DecksPerUser > 0
+	if !checkDecks && !q.limitsCards() {
+		return nil
+	}
+
+	deck, err := q.usage.DeckUsage(ctx, deckID)
+	if err != nil {
+		return err
+	}
+	if limit := q.limits.CardsPerDeck; limit > 0 && deck.Cards > limit {
+		return errs.QuotaExceededError("deck", deckID, fmt.Sprintf("quota exceeded: at most %d cards per deck", limit))
+	}
+
+	if !checkDecks && q.limits.MediaBytes == 0 {
+		return nil
+	}
+	usage, err := q.usage.Usage(ctx, deck.Owner)
+	if err != nil {
+		return err
+	}
+	if checkDecks && usage.Decks > q.limits.DecksPerUser {
+		return errs.QuotaExceededError("deck", 0, fmt.Sprintf("quota exceeded: at most %d decks per user", q.limits.DecksPerUser))
+	}
+	if limit := q.limits.MediaBytes; limit > 0 && usage.MediaBytes > limit {
+		return errs.QuotaExceededError("media", 0, fmt.Sprintf("quota exceeded: at most %d bytes of cards per user", limit))
+	}
+	return nil
+}
+
+// limitsCards reports whether CheckCards has anything to check, so that
+// callers can skip loading the stored cards.
+func (q *Quota) limitsCards() bool {
+	return q != nil && (q.limits.CardsPerDeck > 0 || q.limits.MediaBytes > 0)
+}
+
+func cardBytes(card structs.Card) int64 {
+	return int64(len(card.Front) + len(card.Back))
+}
+
+// UsageServiceServer reports the storage the caller uses against the quotas.
+// The caller is the verified user of the request; anonymous callers share
+// one account.
+type UsageServiceServer struct {
+	quota *Quota
+	grpc.UnimplementedUsageServiceServer
+}
+
+func NewUsageServiceServer(quota *Quota) *UsageServiceServer {
+	return &UsageServiceServer{quota: quota}
+}
+
+func (s *UsageServiceServer) GetUsage(ctx context.Context, req *grpc.GetUsageRequest) (*grpc.UsageResponse, error) {
+	ctx, span := tracer.Start(ctx, "GetUsage")
+	defer span.End()
+
+	user := interceptors.UserFromContext(ctx)
+	usage, err := s.quota.usage.Usage(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := s.quota.limits
+	resp := &grpc.UsageResponse{
+		Decks:      &grpc.QuotaUsage{Used: usage.Decks, Limit: limits.DecksPerUser},
+		MediaBytes: &grpc.QuotaUsage{Used: usage.MediaBytes, Limit: limits.MediaBytes},
+	}
+
+	if req.DeckId != 0 {
+		deck, err := s.quota.usage.DeckUsage(ctx, req.DeckId)
+		if err != nil {
+			return nil, err
+		}
+		// Other users' decks are reported as missing rather than forbidden,
+		// so that their IDs do not leak.
+		if deck.Owner != user {
+			return nil, errs.NotFoundError("deck", req.DeckId)
+		}
+		resp.DeckCards = &grpc.QuotaUsage{Used: deck.Cards, Limit: limits.CardsPerDeck}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,127 @@
+//go:build unit
+// +build unit
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/internal/app/grpc"
+	"flash-card-manager/internal/app/interceptors"
+	"flash-card-manager/internal/infrastructure/kafka"
+	mock_kafka "flash-card-manager/internal/infrastructure/kafka/mocks"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/render"
+	mock_units "flash-card-manager/pkg/repository/interfaces/mocks"
+	"flash-card-manager/pkg/repository/structs"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaCheckCards(t *testing.T) {
+	limits := QuotaLimits{CardsPerDeck: 3, MediaBytes: 100}
+
+	tests := []struct {
+		name    string
+		cards   []structs.Card
+		before  []structs.Card
+		wantErr bool
+	}{
+		{
+			name:  "Within Limits",
+			cards: []structs.Card{{DeckID: 1, Front: "a", Back: "b"}},
+		},
+		{
+			name:    "Too Many Cards",
+			cards:   []structs.Card{{DeckID: 1}, {DeckID: 1}},
+			wantErr: true,
+		},
+		{
+			name:    "Too Many Bytes",
+			cards:   []structs.Card{{DeckID: 1, Front: string(make([]byte, 60))}},
+			wantErr: true,
+		},
+		{
+			name:   "Shrinking A Card",
+			cards:  []structs.Card{{ID: 5, DeckID: 1, Front: string(make([]byte, 60))}},
+			before: []structs.Card{{ID: 5, DeckID: 1, Front: string(make([]byte, 70))}},
+		},
+		{
+			name:  "Missing Deck",
+			cards: []structs.Card{{DeckID: 2}, {DeckID: 2}, {DeckID: 2}, {DeckID: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockUsage := mock_units.NewMockUsageRepository(mockCtrl)
+			mockUsage.EXPECT().DeckUsage(gomock.Any(), int64(1)).Return(structs.DeckUsage{DeckID: 1, Owner: "alice", Cards: 2}, nil).AnyTimes()
+			mockUsage.EXPECT().DeckUsage(gomock.Any(), int64(2)).Return(structs.DeckUsage{}, errs.NotFoundError("deck", 2)).AnyTimes()
+			mockUsage.EXPECT().Usage(gomock.Any(), "alice").Return(structs.Usage{Decks: 1, MediaBytes: 50}, nil).AnyTimes()
+
+			err := NewQuota(mockUsage, limits).CheckCards(context.Background(), tt.cards, tt.before)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, errs.ErrQuotaExceeded), "got %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateDeckQuota(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := mock_units.NewMockDeckRepository(mockCtrl)
+	mockUsage := mock_units.NewMockUsageRepository(mockCtrl)
+	mockProducer := mock_kafka.NewMockProducerInterface(mockCtrl)
+
+	quota := NewQuota(mockUsage, QuotaLimits{DecksPerUser: 2})
+	server := NewDeckServiceServer(mockRepo, inlineTx{}, kafka.NewKafkaEventSender(mockProducer), render.NewRenderer(0), quota)
+
+	ctx := interceptors.WithCaller(context.Background(), interceptors.Caller{User: "alice"})
+	mockUsage.EXPECT().Usage(gomock.Any(), "alice").Return(structs.Usage{Decks: 2}, nil)
+
+	_, err := server.CreateDeck(ctx, &grpc.CreateDeckRequest{Title: "TestTitle", Description: "TestDescription", Author: "TestAuthor"})
+	assert.True(t, errors.Is(err, errs.ErrQuotaExceeded), "got %v", err)
+}
+
+func TestGetUsage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockUsage := mock_units.NewMockUsageRepository(mockCtrl)
+	server := NewUsageServiceServer(NewQuota(mockUsage, QuotaLimits{DecksPerUser: 10, CardsPerDeck: 100, MediaBytes: 1000}))
+
+	ctx := interceptors.WithCaller(context.Background(), interceptors.Caller{User: "alice"})
+	mockUsage.EXPECT().Usage(gomock.Any(), "alice").Return(structs.Usage{Decks: 3, MediaBytes: 250}, nil)
+	mockUsage.EXPECT().DeckUsage(gomock.Any(), int64(7)).Return(structs.DeckUsage{DeckID: 7, Owner: "alice", Cards: 42}, nil)
+
+	resp, err := server.GetUsage(ctx, &grpc.GetUsageRequest{DeckId: 7})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Decks.Used)
+	assert.Equal(t, int64(10), resp.Decks.Limit)
+	assert.Equal(t, int64(250), resp.MediaBytes.Used)
+	assert.Equal(t, int64(1000), resp.MediaBytes.Limit)
+	assert.Equal(t, int64(42), resp.DeckCards.Used)
+	assert.Equal(t, int64(100), resp.DeckCards.Limit)
+
+	// Without a deck only the user's usage is reported.
+	mockUsage.EXPECT().Usage(gomock.Any(), "alice").Return(structs.Usage{Decks: 3, MediaBytes: 250}, nil)
+	resp, err = server.GetUsage(ctx, &grpc.GetUsageRequest{})
+	require.NoError(t, err)
+	assert.Nil(t, resp.DeckCards)
+
+	// Another user's deck is not reported.
+	mockUsage.EXPECT().Usage(gomock.Any(), "alice").Return(structs.Usage{Decks: 3, MediaBytes: 250}, nil)
+	mockUsage.EXPECT().DeckUsage(gomock.Any(), int64(8)).Return(structs.DeckUsage{DeckID: 8, Owner: "bob", Cards: 5}, nil)
+	_, err = server.GetUsage(ctx, &grpc.GetUsageRequest{DeckId: 8})
+	assert.True(t, errors.Is(err, errs.ErrNotFound), "got %v", err)
+}
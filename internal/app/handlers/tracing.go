@@ -0,0 +1,5 @@
+package handlers
+
+import "go.opentelemetry.io/otel"
+
+var tracer = otel.Tracer("flash-card-manager/internal/app/handlers")
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"flash-card-manager/internal/app/grpc"
+	"flash-card-manager/internal/app/interceptors"
+	"flash-card-manager/internal/infrastructure/kafka"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/logger"
+	"flash-card-manager/pkg/render"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type TrashServiceServer struct {
+	repo        interfaces.TrashRepository
+	deckRepo    interfaces.DeckRepository
+	cardRepo    interfaces.CardRepository
+	tx          db.TxManager
+	eventSender kafka.EventSender
+	renderer    *render.Renderer
+	quota       *Quota
+	grpc.UnimplementedTrashServiceServer
+}
+
+func NewTrashServiceServer(r interfaces.TrashRepository, deckRepo interfaces.DeckRepository, cardRepo interfaces.CardRepository, tx db.TxManager, eventSender kafka.EventSender, renderer *render.Renderer, quota *Quota) *TrashServiceServer {
+	return &TrashServiceServer{repo: r, deckRepo: deckRepo, cardRepo: cardRepo, tx: tx, eventSender: eventSender, renderer: renderer, quota: quota}
+}
+
+func (s *TrashServiceServer) ListTrash(ctx context.Context, req *grpc.ListTrashRequest) (*grpc.ListTrashResponse, error) {
+	ctx, span := tracer.Start(ctx, "ListTrash")
+	defer span.End()
+
+	owner := interceptors.UserFromContext(ctx)
+	decks, err := s.repo.ListDecks(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := s.repo.ListCards(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &grpc.ListTrashResponse{}
+	for _, deck := range decks {
+		resp.Decks = append(resp.Decks, &grpc.TrashedDeck{
+			Deck: &grpc.DeckResponse{
+				Id:          deck.ID,
+				Title:       deck.Title,
+				Description: deck.Description,
+				Author:      deck.Author,
+				CreatedAt:   deck.CreatedAt.Format(time.RFC3339),
+				Version:     deck.Version,
+			},
+			DeletedAt: formatDeletedAt(deck.DeletedAt),
+			CardCount: deck.CardCount,
+		})
+	}
+	for i := range cards {
+		cardResponse, err := newCardResponse(s.renderer, &cards[i])
+		if err != nil {
+			return nil, err
+		}
+		resp.Cards = append(resp.Cards, &grpc.TrashedCard{
+			Card:      cardResponse,
+			DeletedAt: formatDeletedAt(cards[i].DeletedAt),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *TrashServiceServer) RestoreDeck(ctx context.Context, req *grpc.RestoreDeckRequest) (*grpc.RestoreDeckResponse, error) {
+	ctx, span := tracer.Start(ctx, "RestoreDeck")
+	defer span.End()
+
+	var restoredCards int64
+	var deck *structs.Deck
+	err := s.tx.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		var err error
+		restoredCards, err = s.repo.RestoreDeck(ctx, req.Id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.quota.CheckRestore(ctx, req.Id, true); err != nil {
+			return err
+		}
+
+		deck, err = s.deckRepo.GetByID(ctx, req.Id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.eventSender.SendEvent(ctx, "RestoreDeck", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
+	}
+
+	return &grpc.RestoreDeckResponse{
+		Deck: &grpc.DeckResponse{
+			Id:          deck.ID,
+			Title:       deck.Title,
+			Description: deck.Description,
+			Author:      deck.Author,
+			CreatedAt:   deck.CreatedAt.Format(time.RFC3339),
+			Version:     deck.Version,
+		},
+		RestoredCards: restoredCards,
+	}, nil
+}
+
+func (s *TrashServiceServer) RestoreCard(ctx context.Context, req *grpc.RestoreCardRequest) (*grpc.CardResponse, error) {
+	ctx, span := tracer.Start(ctx, "RestoreCard")
+	defer span.End()
+
+	var card *structs.Card
+	err := s.tx.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		if err := s.repo.RestoreCard(ctx, req.Id); err != nil {
+			return err
+		}
+
+		var err error
+		card, err = s.cardRepo.GetByID(ctx, req.Id)
+		if err != nil {
+			return err
+		}
+
+		return s.quota.CheckRestore(ctx, card.DeckID, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.eventSender.SendEvent(ctx, "RestoreCard", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
+	}
+
+	return newCardResponse(s.renderer, card)
+}
+
+func (s *TrashServiceServer) PurgeTrash(ctx context.Context, req *grpc.PurgeTrashRequest) (*grpc.PurgeTrashResponse, error) {
+	ctx, span := tracer.Start(ctx, "PurgeTrash")
+	defer span.End()
+
+	deletedBefore := time.Now()
+	if req.DeletedBefore != "" {
+		var err error
+		deletedBefore, err = time.Parse(time.RFC3339, req.DeletedBefore)
+		if err != nil {
+			return nil, errs.Violation("deleted_before", "must be an RFC 3339 timestamp")
+		}
+	}
+
+	purgedDecks, purgedCards, err := s.repo.PurgeOwner(ctx, interceptors.UserFromContext(ctx), deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.eventSender.SendEvent(ctx, "PurgeTrash", req.String()); err != nil {
+		logger.Error(ctx, "Failed to send event to Kafka", zap.Error(err))
+	}
+
+	return &grpc.PurgeTrashResponse{
+		PurgedDecks: purgedDecks,
+		PurgedCards: purgedCards,
+	}, nil
+}
+
+func formatDeletedAt(deletedAt *time.Time) string {
+	if deletedAt == nil {
+		return ""
+	}
+	return deletedAt.Format(time.RFC3339)
+}
@@ -0,0 +1,328 @@
+//go:build unit
+// +build unit
+
+package handlers
+
+import (
+	"context"
+	"flash-card-manager/internal/app/grpc"
+	"flash-card-manager/internal/app/handlers/utils"
+	"flash-card-manager/internal/app/interceptors"
+	"flash-card-manager/internal/infrastructure/kafka"
+	mock_kafka "flash-card-manager/internal/infrastructure/kafka/mocks"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/render"
+	mock_units "flash-card-manager/pkg/repository/interfaces/mocks"
+	"flash-card-manager/pkg/repository/structs"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type trashMocks struct {
+	trash    *mock_units.MockTrashRepository
+	deck     *mock_units.MockDeckRepository
+	card     *mock_units.MockCardRepository
+	usage    *mock_units.MockUsageRepository
+	producer *mock_kafka.MockProducerInterface
+}
+
+func newTrashServer(mockCtrl *gomock.Controller) (*TrashServiceServer, trashMocks) {
+	return newTrashServerWithQuota(mockCtrl, QuotaLimits{})
+}
+
+func newTrashServerWithQuota(mockCtrl *gomock.Controller, limits QuotaLimits) (*TrashServiceServer, trashMocks) {
+	m := trashMocks{
+		trash:    mock_units.NewMockTrashRepository(mockCtrl),
+		deck:     mock_units.NewMockDeckRepository(mockCtrl),
+		card:     mock_units.NewMockCardRepository(mockCtrl),
+		usage:    mock_units.NewMockUsageRepository(mockCtrl),
+		producer: mock_kafka.NewMockProducerInterface(mockCtrl),
+	}
+	quota := NewQuota(m.usage, limits)
+	server := NewTrashServiceServer(m.trash, m.deck, m.card, inlineTx{}, kafka.NewKafkaEventSender(m.producer), render.NewRenderer(0), quota)
+	return server, m
+}
+
+func TestListTrashGRPC(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	server, m := newTrashServer(mockCtrl)
+
+	ctx := interceptors.WithCaller(context.Background(), interceptors.Caller{User: "alice"})
+	deletedAt := time.Date(2023, 10, 6, 12, 0, 0, 0, time.UTC)
+	m.trash.EXPECT().ListDecks(gomock.Any(), "alice").Return([]structs.TrashedDeck{
+		{Deck: structs.Deck{ID: 1, Title: "Eng words", Version: 4, DeletedAt: &deletedAt}, CardCount: 3},
+	}, nil)
+	m.trash.EXPECT().ListCards(gomock.Any(), "alice").Return([]structs.Card{
+		{ID: 7, Front: "Front", Back: "Back", DeckID: 2, Format: "plain", DeletedAt: &deletedAt},
+	}, nil)
+
+	resp, err := server.ListTrash(ctx, &grpc.ListTrashRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Decks, 1)
+	assert.Equal(t, int64(3), resp.Decks[0].CardCount)
+	assert.Equal(t, int64(4), resp.Decks[0].Deck.Version)
+	assert.Equal(t, "2023-10-06T12:00:00Z", resp.Decks[0].DeletedAt)
+	assert.Len(t, resp.Cards, 1)
+	assert.Equal(t, int64(7), resp.Cards[0].Card.Id)
+}
+
+func TestRestoreDeckGRPC(t *testing.T) {
+	tests := []struct {
+		name               string
+		inputID            int64
+		repoErr            error
+		repoReturn         int64
+		wantErr            bool
+		wantCode           codes.Code
+		expectProducerCall bool
+	}{
+		{
+			name:               "Successful Restore",
+			inputID:            1,
+			repoReturn:         2,
+			wantErr:            false,
+			wantCode:           codes.OK,
+			expectProducerCall: true,
+		},
+		{
+			name:     "Deck Not In Trash",
+			inputID:  1,
+			repoErr:  &errs.Error{Kind: errs.NotFound, Resource: "deck", ID: 1, Message: "deck not found in trash"},
+			wantErr:  true,
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			server, m := newTrashServer(mockCtrl)
+			req := &grpc.RestoreDeckRequest{Id: tt.inputID}
+
+			m.trash.EXPECT().RestoreDeck(gomock.Any(), tt.inputID).Return(tt.repoReturn, tt.repoErr)
+			if tt.repoErr == nil {
+				m.deck.EXPECT().GetByID(gomock.Any(), tt.inputID).Return(&structs.Deck{ID: tt.inputID, Title: "Eng words", Version: 3}, nil)
+			}
+
+			if tt.expectProducerCall {
+				matcher := &utils.GRPCKafkaEventMatcher{
+					ExpectedType:  "RestoreDeck",
+					ExpectedQuery: req.String(),
+				}
+				m.producer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
+			}
+
+			resp, err := server.RestoreDeck(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Nil(t, resp)
+				st, ok := status.FromError(interceptors.StatusError(err))
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, st.Code())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.repoReturn, resp.RestoredCards)
+				assert.Equal(t, "Eng words", resp.Deck.Title)
+				assert.Equal(t, int64(3), resp.Deck.Version)
+			}
+		})
+	}
+}
+
+func TestRestoreCardGRPC(t *testing.T) {
+	tests := []struct {
+		name               string
+		inputID            int64
+		repoErr            error
+		wantErr            bool
+		wantCode           codes.Code
+		expectProducerCall bool
+	}{
+		{
+			name:               "Successful Restore",
+			inputID:            1,
+			wantErr:            false,
+			wantCode:           codes.OK,
+			expectProducerCall: true,
+		},
+		{
+			name:     "Card Not In Trash",
+			inputID:  1,
+			repoErr:  &errs.Error{Kind: errs.NotFound, Resource: "card", ID: 1, Message: "card not found in trash"},
+			wantErr:  true,
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "Deck In Trash",
+			inputID:  1,
+			repoErr:  errs.FailedPreconditionError("card", 1, "deck is in trash"),
+			wantErr:  true,
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			server, m := newTrashServer(mockCtrl)
+			req := &grpc.RestoreCardRequest{Id: tt.inputID}
+
+			m.trash.EXPECT().RestoreCard(gomock.Any(), tt.inputID).Return(tt.repoErr)
+			if tt.repoErr == nil {
+				m.card.EXPECT().GetByID(gomock.Any(), tt.inputID).Return(&structs.Card{ID: tt.inputID, Front: "Front", Format: "plain"}, nil)
+			}
+
+			if tt.expectProducerCall {
+				matcher := &utils.GRPCKafkaEventMatcher{
+					ExpectedType:  "RestoreCard",
+					ExpectedQuery: req.String(),
+				}
+				m.producer.EXPECT().SendSyncMessage(matcher).Return(int32(1), int64(1), nil)
+			}
+
+			resp, err := server.RestoreCard(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Nil(t, resp)
+				st, ok := status.FromError(interceptors.StatusError(err))
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, st.Code())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Front", resp.Front)
+			}
+		})
+	}
+}
+
+func TestRestoreQuota(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  QuotaLimits
+		usage   structs.Usage
+		cards   int64
+		restore func(server *TrashServiceServer) error
+	}{
+		{
+			name:   "Deck Over Decks Limit",
+			limits: QuotaLimits{DecksPerUser: 2},
+			usage:  structs.Usage{Decks: 3},
+			restore: func(server *TrashServiceServer) error {
+				_, err := server.RestoreDeck(context.Background(), &grpc.RestoreDeckRequest{Id: 1})
+				return err
+			},
+		},
+		{
+			name:   "Deck Over Cards Limit",
+			limits: QuotaLimits{CardsPerDeck: 5},
+			cards:  6,
+			restore: func(server *TrashServiceServer) error {
+				_, err := server.RestoreDeck(context.Background(), &grpc.RestoreDeckRequest{Id: 1})
+				return err
+			},
+		},
+		{
+			name:   "Card Over Cards Limit",
+			limits: QuotaLimits{CardsPerDeck: 5},
+			cards:  6,
+			restore: func(server *TrashServiceServer) error {
+				_, err := server.RestoreCard(context.Background(), &grpc.RestoreCardRequest{Id: 7})
+				return err
+			},
+		},
+		{
+			name:   "Card Over Media Limit",
+			limits: QuotaLimits{MediaBytes: 100},
+			usage:  structs.Usage{MediaBytes: 101},
+			restore: func(server *TrashServiceServer) error {
+				_, err := server.RestoreCard(context.Background(), &grpc.RestoreCardRequest{Id: 7})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			server, m := newTrashServerWithQuota(mockCtrl, tt.limits)
+
+			m.trash.EXPECT().RestoreDeck(gomock.Any(), int64(1)).Return(int64(1), nil).AnyTimes()
+			m.trash.EXPECT().RestoreCard(gomock.Any(), int64(7)).Return(nil).AnyTimes()
+			m.card.EXPECT().GetByID(gomock.Any(), int64(7)).Return(&structs.Card{ID: 7, DeckID: 1}, nil).AnyTimes()
+			m.usage.EXPECT().DeckUsage(gomock.Any(), int64(1)).Return(structs.DeckUsage{DeckID: 1, Owner: "alice", Cards: tt.cards}, nil)
+			m.usage.EXPECT().Usage(gomock.Any(), "alice").Return(tt.usage, nil).AnyTimes()
+
+			err := tt.restore(server)
+
+			st, ok := status.FromError(interceptors.StatusError(err))
+			assert.True(t, ok)
+			assert.Equal(t, codes.ResourceExhausted, st.Code())
+		})
+	}
+}
+
+func TestPurgeTrashGRPC(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *grpc.PurgeTrashRequest
+		wantErr  bool
+		wantCode codes.Code
+	}{
+		{
+			name:  "Purge Everything",
+			input: &grpc.PurgeTrashRequest{},
+		},
+		{
+			name:  "Purge Before Timestamp",
+			input: &grpc.PurgeTrashRequest{DeletedBefore: "2023-10-06T12:00:00Z"},
+		},
+		{
+			name:     "Invalid Timestamp",
+			input:    &grpc.PurgeTrashRequest{DeletedBefore: "yesterday"},
+			wantErr:  true,
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			server, m := newTrashServer(mockCtrl)
+
+			if !tt.wantErr {
+				m.trash.EXPECT().PurgeOwner(gomock.Any(), "alice", gomock.Any()).Return(int64(1), int64(4), nil)
+				m.producer.EXPECT().SendSyncMessage(gomock.Any()).Return(int32(1), int64(1), nil)
+			}
+
+			ctx := interceptors.WithCaller(context.Background(), interceptors.Caller{User: "alice"})
+			resp, err := server.PurgeTrash(ctx, tt.input)
+
+			if tt.wantErr {
+				assert.Nil(t, resp)
+				st, ok := status.FromError(interceptors.StatusError(err))
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, st.Code())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), resp.PurgedDecks)
+				assert.Equal(t, int64(4), resp.PurgedCards)
+			}
+		})
+	}
+}
@@ -1,14 +1,22 @@
 package utils
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	pb "flash-card-manager/internal/app/grpc"
+	"flash-card-manager/internal/app/study"
 	"flash-card-manager/pkg/logger"
+	"math/rand"
+	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
-func HandleCommand(ctx context.Context, deckClient pb.DeckServiceClient, cardClient pb.CardServiceClient, cmd string, args []string) error {
+func HandleCommand(ctx context.Context, deckClient pb.DeckServiceClient, cardClient pb.CardServiceClient, trashClient pb.TrashServiceClient, cmd string, args []string) error {
 	switch cmd {
 	case "createDeck":
 		return createDeck(ctx, deckClient, args...)
@@ -16,6 +24,8 @@ func HandleCommand(ctx context.Context, deckClient pb.DeckServiceClient, cardCli
 		return getDeckById(ctx, deckClient, args[0])
 	case "updateDeck":
 		return updateDeck(ctx, deckClient, args...)
+	case "patchDeck":
+		return patchDeck(ctx, deckClient, args...)
 	case "deleteDeck":
 		return deleteDeck(ctx, deckClient, args[0])
 	case "createCard":
@@ -24,8 +34,34 @@ func HandleCommand(ctx context.Context, deckClient pb.DeckServiceClient, cardCli
 		return getCardById(ctx, cardClient, args[0])
 	case "updateCard":
 		return updateCard(ctx, cardClient, args...)
+	case "patchCard":
+		return patchCard(ctx, cardClient, args...)
+	case "importCards":
+		return importCards(ctx, cardClient, args...)
+	case "deleteCards":
+		return deleteCards(ctx, cardClient, args...)
+	case "moveCards":
+		return moveCards(ctx, cardClient, args...)
 	case "deleteCard":
 		return deleteCard(ctx, cardClient, args[0])
+	case "listDeckRevisions":
+		return listDeckRevisions(ctx, deckClient, args[0])
+	case "revertDeck":
+		return revertDeck(ctx, deckClient, args...)
+	case "listCardRevisions":
+		return listCardRevisions(ctx, cardClient, args[0])
+	case "revertCard":
+		return revertCard(ctx, cardClient, args...)
+	case "listTrash":
+		return listTrash(ctx, trashClient)
+	case "restoreDeck":
+		return restoreDeck(ctx, trashClient, args[0])
+	case "restoreCard":
+		return restoreCard(ctx, trashClient, args[0])
+	case "purgeTrash":
+		return purgeTrash(ctx, trashClient, args...)
+	case "study":
+		return studyDeck(ctx, deckClient, args...)
 	default:
 		return fmt.Errorf("unknown command: %s", cmd)
 	}
@@ -93,6 +129,47 @@ func updateDeck(ctx context.Context, client pb.DeckServiceClient, args ...string
 	return nil
 }
 
+// patchDeck updates only the fields given as field=value arguments, e.g.
+// `patchDeck 1 title=Verbs`.
+func patchDeck(ctx context.Context, client pb.DeckServiceClient, args ...string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("patchDeck requires a deck ID and at least one field=value argument")
+	}
+
+	deckId, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid deck ID: %v", err)
+	}
+
+	req := &pb.UpdateDeckRequest{Id: deckId, UpdateMask: &fieldmaskpb.FieldMask{}}
+	for _, arg := range args[1:] {
+		field, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return fmt.Errorf("invalid argument %q, expected field=value", arg)
+		}
+		switch field {
+		case "title":
+			req.Title = value
+		case "description":
+			req.Description = value
+		case "author":
+			req.Author = value
+		default:
+			return fmt.Errorf("unknown deck field: %s", field)
+		}
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, field)
+	}
+
+	resp, err := client.UpdateDeck(ctx, req)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to update deck: %v", err)
+		return err
+	}
+
+	logger.Infof(ctx, "Deck updated: %v", resp)
+	return nil
+}
+
 func deleteDeck(ctx context.Context, client pb.DeckServiceClient, deckIdStr string) error {
 	deckId, err := strconv.ParseInt(deckIdStr, 10, 64)
 	if err != nil {
@@ -110,10 +187,14 @@ func deleteDeck(ctx context.Context, client pb.DeckServiceClient, deckIdStr stri
 }
 
 func createCard(ctx context.Context, client pb.CardServiceClient, args ...string) error {
-	if len(args) != 4 {
-		return fmt.Errorf("createCard requires 4 arguments: front, back, deckId, author")
+	if len(args) != 4 && len(args) != 5 {
+		return fmt.Errorf("createCard requires 4 arguments: front, back, deckId, author [format]")
 	}
 	front, back, author := args[0], args[1], args[3]
+	var format string
+	if len(args) == 5 {
+		format = args[4]
+	}
 	deckId, err := strconv.ParseInt(args[2], 10, 64)
 	if err != nil {
 		return fmt.Errorf("invalid deck ID format: %v", err)
@@ -124,6 +205,7 @@ func createCard(ctx context.Context, client pb.CardServiceClient, args ...string
 		Back:   back,
 		DeckId: deckId,
 		Author: author,
+		Format: format,
 	})
 	if err != nil {
 		logger.Errorf(ctx, "Failed to create card: %v", err)
@@ -151,8 +233,8 @@ func getCardById(ctx context.Context, client pb.CardServiceClient, cardIdStr str
 }
 
 func updateCard(ctx context.Context, client pb.CardServiceClient, args ...string) error {
-	if len(args) != 5 {
-		return fmt.Errorf("updateCard requires 5 arguments: cardId, front, back, deckId, author")
+	if len(args) != 5 && len(args) != 6 {
+		return fmt.Errorf("updateCard requires 5 arguments: cardId, front, back, deckId, author [format]")
 	}
 
 	cardId, err := strconv.ParseInt(args[0], 10, 64)
@@ -164,6 +246,10 @@ func updateCard(ctx context.Context, client pb.CardServiceClient, args ...string
 	if err != nil {
 		return fmt.Errorf("invalid deck ID format: %v", err)
 	}
+	var format string
+	if len(args) == 6 {
+		format = args[5]
+	}
 
 	resp, err := client.UpdateCard(ctx, &pb.UpdateCardRequest{
 		Id:     cardId,
@@ -171,6 +257,7 @@ func updateCard(ctx context.Context, client pb.CardServiceClient, args ...string
 		Back:   back,
 		DeckId: deckId,
 		Author: author,
+		Format: format,
 	})
 	if err != nil {
 		logger.Errorf(ctx, "Failed to update card: %v", err)
@@ -181,6 +268,165 @@ func updateCard(ctx context.Context, client pb.CardServiceClient, args ...string
 	return nil
 }
 
+// patchCard updates only the fields given as field=value arguments, e.g.
+// `patchCard 1 author=Bob deck_id=2`.
+func patchCard(ctx context.Context, client pb.CardServiceClient, args ...string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("patchCard requires a card ID and at least one field=value argument")
+	}
+
+	cardId, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid card ID: %v", err)
+	}
+
+	req := &pb.UpdateCardRequest{Id: cardId, UpdateMask: &fieldmaskpb.FieldMask{}}
+	for _, arg := range args[1:] {
+		field, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return fmt.Errorf("invalid argument %q, expected field=value", arg)
+		}
+		switch field {
+		case "front":
+			req.Front = value
+		case "back":
+			req.Back = value
+		case "author":
+			req.Author = value
+		case "format":
+			req.Format = value
+		case "deck_id":
+			req.DeckId, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid deck ID format: %v", err)
+			}
+		default:
+			return fmt.Errorf("unknown card field: %s", field)
+		}
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, field)
+	}
+
+	resp, err := client.UpdateCard(ctx, req)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to update card: %v", err)
+		return err
+	}
+
+	logger.Infof(ctx, "Card updated: %v", resp)
+	return nil
+}
+
+// importCards creates one card per "front<TAB>back" line of a file in a
+// single BatchCreateCards call.
+func importCards(ctx context.Context, client pb.CardServiceClient, args ...string) error {
+	if len(args) != 3 && len(args) != 4 {
+		return fmt.Errorf("importCards requires 3 arguments: deckId, author, file [format]")
+	}
+
+	deckId, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid deck ID format: %v", err)
+	}
+	author := args[1]
+	var format string
+	if len(args) == 4 {
+		format = args[3]
+	}
+
+	file, err := os.Open(args[2])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	req := &pb.BatchCreateCardsRequest{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		front, back, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok {
+			return fmt.Errorf("line %d: expected front and back separated by a tab", line)
+		}
+		req.Cards = append(req.Cards, &pb.CreateCardRequest{
+			Front:  front,
+			Back:   back,
+			DeckId: deckId,
+			Author: author,
+			Format: format,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	resp, err := client.BatchCreateCards(ctx, req)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to import cards: %v", err)
+		return err
+	}
+
+	logger.Infof(ctx, "Cards imported: %v", resp)
+	return nil
+}
+
+func deleteCards(ctx context.Context, client pb.CardServiceClient, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("deleteCards requires at least one card ID")
+	}
+
+	cardIds, err := parseIDs(args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.BatchDeleteCards(ctx, &pb.BatchDeleteCardsRequest{CardIds: cardIds})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to delete cards: %v", err)
+		return err
+	}
+
+	logger.Infof(ctx, "Cards deleted: %v", resp)
+	return nil
+}
+
+func moveCards(ctx context.Context, client pb.CardServiceClient, args ...string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("moveCards requires a deck ID and at least one card ID")
+	}
+
+	deckId, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid deck ID format: %v", err)
+	}
+	cardIds, err := parseIDs(args[1:])
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.MoveCards(ctx, &pb.MoveCardsRequest{CardIds: cardIds, DeckId: deckId})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to move cards: %v", err)
+		return err
+	}
+
+	logger.Infof(ctx, "Cards moved: %v", resp)
+	return nil
+}
+
+func parseIDs(args []string) ([]int64, error) {
+	ids := make([]int64, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID %q: %v", arg, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
 func deleteCard(ctx context.Context, client pb.CardServiceClient, cardIdStr string) error {
 	cardId, err := strconv.ParseInt(cardIdStr, 10, 64)
 	if err != nil {
@@ -196,3 +442,190 @@ func deleteCard(ctx context.Context, client pb.CardServiceClient, cardIdStr stri
 	logger.Info(ctx, "Card deleted successfully")
 	return nil
 }
+
+func listDeckRevisions(ctx context.Context, client pb.DeckServiceClient, deckIdStr string) error {
+	deckId, err := strconv.ParseInt(deckIdStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid deck ID format: %v", err)
+	}
+
+	resp, err := client.ListDeckRevisions(ctx, &pb.ListDeckRevisionsRequest{DeckId: deckId})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to list deck revisions: %v", err)
+		return err
+	}
+
+	for _, revision := range resp.Revisions {
+		fmt.Printf("revision %d by %s at %s\n%s\n", revision.Id, revision.Editor, revision.CreatedAt, revision.Diff)
+	}
+	return nil
+}
+
+func revertDeck(ctx context.Context, client pb.DeckServiceClient, args ...string) error {
+	if len(args) != 2 && len(args) != 3 {
+		return fmt.Errorf("revertDeck requires 2 arguments: deckId, revisionId [editor]")
+	}
+
+	deckId, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid deck ID format: %v", err)
+	}
+	revisionId, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid revision ID format: %v", err)
+	}
+	var editor string
+	if len(args) == 3 {
+		editor = args[2]
+	}
+
+	resp, err := client.RevertDeck(ctx, &pb.RevertDeckRequest{DeckId: deckId, RevisionId: revisionId, Editor: editor})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to revert deck: %v", err)
+		return err
+	}
+
+	logger.Infof(ctx, "Deck reverted: %v", resp)
+	return nil
+}
+
+func listCardRevisions(ctx context.Context, client pb.CardServiceClient, cardIdStr string) error {
+	cardId, err := strconv.ParseInt(cardIdStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid card ID format: %v", err)
+	}
+
+	resp, err := client.ListCardRevisions(ctx, &pb.ListCardRevisionsRequest{CardId: cardId})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to list card revisions: %v", err)
+		return err
+	}
+
+	for _, revision := range resp.Revisions {
+		fmt.Printf("revision %d by %s at %s\n%s\n", revision.Id, revision.Editor, revision.CreatedAt, revision.Diff)
+	}
+	return nil
+}
+
+func revertCard(ctx context.Context, client pb.CardServiceClient, args ...string) error {
+	if len(args) != 2 && len(args) != 3 {
+		return fmt.Errorf("revertCard requires 2 arguments: cardId, revisionId [editor]")
+	}
+
+	cardId, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid card ID format: %v", err)
+	}
+	revisionId, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid revision ID format: %v", err)
+	}
+	var editor string
+	if len(args) == 3 {
+		editor = args[2]
+	}
+
+	resp, err := client.RevertCard(ctx, &pb.RevertCardRequest{CardId: cardId, RevisionId: revisionId, Editor: editor})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to revert card: %v", err)
+		return err
+	}
+
+	logger.Infof(ctx, "Card reverted: %v", resp)
+	return nil
+}
+
+func listTrash(ctx context.Context, client pb.TrashServiceClient) error {
+	resp, err := client.ListTrash(ctx, &pb.ListTrashRequest{})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to list trash: %v", err)
+		return err
+	}
+
+	logger.Infof(ctx, "Trash: %v", resp)
+	return nil
+}
+
+func restoreDeck(ctx context.Context, client pb.TrashServiceClient, deckIdStr string) error {
+	deckId, err := strconv.ParseInt(deckIdStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid deck ID format: %v", err)
+	}
+
+	resp, err := client.RestoreDeck(ctx, &pb.RestoreDeckRequest{Id: deckId})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to restore deck: %v", err)
+		return err
+	}
+
+	logger.Infof(ctx, "Deck restored: %v", resp)
+	return nil
+}
+
+func restoreCard(ctx context.Context, client pb.TrashServiceClient, cardIdStr string) error {
+	cardId, err := strconv.ParseInt(cardIdStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid card ID format: %v", err)
+	}
+
+	resp, err := client.RestoreCard(ctx, &pb.RestoreCardRequest{Id: cardId})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to restore card: %v", err)
+		return err
+	}
+
+	logger.Infof(ctx, "Card restored: %v", resp)
+	return nil
+}
+
+func purgeTrash(ctx context.Context, client pb.TrashServiceClient, args ...string) error {
+	var deletedBefore string
+	if len(args) > 0 {
+		deletedBefore = args[0]
+	}
+
+	resp, err := client.PurgeTrash(ctx, &pb.PurgeTrashRequest{DeletedBefore: deletedBefore})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to purge trash: %v", err)
+		return err
+	}
+
+	logger.Infof(ctx, "Trash purged: %v", resp)
+	return nil
+}
+
+// studyDeck reviews the cards of a deck full screen. The server does not
+// schedule reviews yet, so the cards come in random order and the grades
+// stay in the session.
+func studyDeck(ctx context.Context, client pb.DeckServiceClient, args ...string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("study requires 1 argument: deck_id")
+	}
+	deckId, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid deck ID format: %v", err)
+	}
+
+	resp, err := client.GetDeckById(ctx, &pb.GetDeckByIdRequest{Id: deckId})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get deck: %v", err)
+		return err
+	}
+	if len(resp.Cards) == 0 {
+		return fmt.Errorf("deck %d has no cards to study", deckId)
+	}
+
+	cards := make([]study.Card, 0, len(resp.Cards))
+	for _, card := range resp.Cards {
+		cards = append(cards, study.Card{ID: card.Id, Front: card.Front, Back: card.Back})
+	}
+	cards = study.Shuffle(cards, rand.New(rand.NewSource(time.Now().UnixNano())))
+
+	session := study.NewSession(cards)
+	if err := study.RunTerminal(session, resp.Deck.GetTitle(), os.Stdin, os.Stdout); err != nil {
+		return fmt.Errorf("study session failed: %w", err)
+	}
+
+	study.PrintStats(os.Stdout, session.Stats())
+	return nil
+}
@@ -0,0 +1,112 @@
+// Package health reports whether the server is ready to serve through the
+// standard grpc.health.v1 service.
+package health
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/logger"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Check reports whether a dependency is usable.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs the checks periodically and reports the server as SERVING,
+// both overall ("") and for each of its services, while they all pass.
+type Checker struct {
+	server   *health.Server
+	services []string
+	interval time.Duration
+	checks   []namedCheck
+}
+
+// NewChecker returns a Checker that checks every interval, each time giving
+// the checks interval to complete. The server is NOT_SERVING until the first
+// round passes.
+func NewChecker(interval time.Duration, services ...string) *Checker {
+	c := &Checker{
+		server:   health.NewServer(),
+		services: append([]string{""}, services...),
+		interval: interval,
+	}
+	c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// Server returns the grpc.health.v1 implementation to register on the gRPC
+// server.
+func (c *Checker) Server() healthpb.HealthServer {
+	return c.server
+}
+
+// Add registers a check. Add all checks before Run.
+func (c *Checker) Add(name string, check Check) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Check runs every check once and returns their failures.
+func (c *Checker) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+
+	var errs []error
+	for _, nc := range c.checks {
+		if err := nc.check(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", nc.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run checks right away and then every interval until ctx is cancelled.
+func (c *Checker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	ready := false
+	for {
+		err := c.Check(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		switch {
+		case err == nil && !ready:
+			logger.Infof(ctx, "Ready to serve")
+			c.setStatus(healthpb.HealthCheckResponse_SERVING)
+		case err != nil && ready:
+			logger.Errorf(ctx, "Not ready to serve: %v", err)
+			c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+		}
+		ready = err == nil
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown reports NOT_SERVING for good, so that load balancers stop sending
+// requests while the server drains the ones in flight.
+func (c *Checker) Shutdown(context.Context) error {
+	c.server.Shutdown()
+	return nil
+}
+
+func (c *Checker) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+}
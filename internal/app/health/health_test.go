@@ -0,0 +1,64 @@
+//go:build unit
+// +build unit
+
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func status(t *testing.T, c *Checker, service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := c.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return resp.Status
+}
+
+func TestChecker(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+
+	c := NewChecker(10*time.Millisecond, "grpc.DeckService")
+	c.Add("database", func(context.Context) error { return nil })
+	c.Add("kafka", func(context.Context) error {
+		if failing.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(t, c, ""))
+	assert.EqualError(t, c.Check(context.Background()), "kafka: connection refused")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+
+	failing.Store(false)
+	assert.Eventually(t, func() bool {
+		return status(t, c, "") == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(t, c, "grpc.DeckService"))
+
+	failing.Store(true)
+	assert.Eventually(t, func() bool {
+		return status(t, c, "grpc.DeckService") == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 5*time.Millisecond)
+
+	failing.Store(false)
+	assert.Eventually(t, func() bool {
+		return status(t, c, "") == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	require.NoError(t, c.Shutdown(context.Background()))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(t, c, ""))
+}
@@ -0,0 +1,34 @@
+package interceptors
+
+import (
+	"context"
+	"flash-card-manager/pkg/repository/cache"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// CacheControlHeader is the metadata key that lets a request skip the
+// repository cache. The gateway maps it from Cache-Control.
+const CacheControlHeader = "cache-control"
+
+// UnaryServerCacheControl makes the repositories read past the cache for
+// requests with "no-cache" or "no-store" in their cache-control metadata.
+func UnaryServerCacheControl() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if noCache(firstMetadata(ctx, CacheControlHeader)) {
+			ctx = cache.WithBypass(ctx)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func noCache(cacheControl string) bool {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		switch strings.ToLower(strings.TrimSpace(directive)) {
+		case "no-cache", "no-store":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+//go:build unit
+// +build unit
+
+package interceptors
+
+import (
+	"context"
+	"flash-card-manager/pkg/repository/cache"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnaryServerCacheControl(t *testing.T) {
+	interceptor := UnaryServerCacheControl()
+	info := &grpc.UnaryServerInfo{FullMethod: "/grpc.DeckService/GetDeckById"}
+
+	for header, bypassed := range map[string]bool{
+		"":                    false,
+		"max-age=60":          false,
+		"no-cache":            true,
+		"max-age=0, No-Store": true,
+	} {
+		ctx := context.Background()
+		if header != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(CacheControlHeader, header))
+		}
+
+		_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.Equal(t, bypassed, cache.Bypassed(ctx), header)
+			return nil, nil
+		})
+		require.NoError(t, err)
+	}
+}
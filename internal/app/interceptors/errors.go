@@ -0,0 +1,110 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/logger"
+	"strconv"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+var kindCodes = map[errs.Kind]codes.Code{
+	errs.NotFound:           codes.NotFound,
+	errs.AlreadyExists:      codes.AlreadyExists,
+	errs.FailedPrecondition: codes.FailedPrecondition,
+	errs.InvalidArgument:    codes.InvalidArgument,
+	errs.Conflict:           codes.Aborted,
+	errs.QuotaExceeded:      codes.ResourceExhausted,
+}
+
+// UnaryServerErrors converts the errors handlers return into statuses. See
+// StatusError. Errors that end up as Internal are logged, since their cause
+// is not passed on to the client.
+func UnaryServerErrors() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		statusErr := StatusError(err)
+		if status.Code(statusErr) == codes.Internal {
+			logger.Error(ctx, "RPC failed with an internal error",
+				zap.String("grpc.method", info.FullMethod),
+				zap.String("grpc.code", codes.Internal.String()),
+				zap.Error(err),
+			)
+		}
+		return resp, statusErr
+	}
+}
+
+// StatusError converts err into a gRPC status error. Statuses pass through
+// unchanged, *errs.Error gets the matching code along with a ResourceInfo,
+// BadRequest or QuotaFailure detail, and anything else becomes a bare
+// Internal.
+func StatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	var domainErr *errs.Error
+	if !errors.As(err, &domainErr) {
+		return status.Error(codes.Internal, "Internal error")
+	}
+
+	code, ok := kindCodes[domainErr.Kind]
+	if !ok {
+		code = codes.Internal
+	}
+	st := status.New(code, domainErr.Error())
+
+	var details []protoadapt.MessageV1
+	if len(domainErr.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range domainErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if domainErr.Kind == errs.QuotaExceeded {
+		details = append(details, &errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{{
+				Subject:     domainErr.Resource,
+				Description: domainErr.Error(),
+			}},
+		})
+	} else if domainErr.Resource != "" {
+		resource := &errdetails.ResourceInfo{
+			ResourceType: domainErr.Resource,
+			Description:  domainErr.Error(),
+		}
+		if domainErr.ID != 0 {
+			resource.ResourceName = strconv.FormatInt(domainErr.ID, 10)
+		}
+		details = append(details, resource)
+	}
+
+	if len(details) > 0 {
+		if withDetails, err := st.WithDetails(details...); err == nil {
+			st = withDetails
+		}
+	}
+	return st.Err()
+}
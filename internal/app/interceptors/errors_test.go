@@ -0,0 +1,131 @@
+//go:build unit
+// +build unit
+
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/errs"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+		wantMsg  string
+	}{
+		{
+			name:     "Not Found",
+			err:      fmt.Errorf("get: %w", errs.NotFoundError("deck", 7)),
+			wantCode: codes.NotFound,
+			wantMsg:  "deck not found",
+		},
+		{
+			name:     "Conflict",
+			err:      errs.ConflictError("card", 1),
+			wantCode: codes.Aborted,
+			wantMsg:  "version mismatch",
+		},
+		{
+			name:     "Failed Precondition",
+			err:      errs.FailedPreconditionError("card", 1, "deck is in trash"),
+			wantCode: codes.FailedPrecondition,
+			wantMsg:  "deck is in trash",
+		},
+		{
+			name:     "Status Passes Through",
+			err:      status.Error(codes.InvalidArgument, "Invalid ID format"),
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "Invalid ID format",
+		},
+		{
+			name:     "Canceled",
+			err:      fmt.Errorf("query: %w", context.Canceled),
+			wantCode: codes.Canceled,
+		},
+		{
+			name:     "Unknown Error Is Hidden",
+			err:      errors.New("connection refused"),
+			wantCode: codes.Internal,
+			wantMsg:  "Internal error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(StatusError(tt.err))
+			assert.Equal(t, tt.wantCode, st.Code())
+			if tt.wantMsg != "" {
+				assert.Equal(t, tt.wantMsg, st.Message())
+			}
+		})
+	}
+}
+
+func TestStatusErrorDetails(t *testing.T) {
+	st := status.Convert(StatusError(errs.NotFoundError("deck", 7)))
+	if assert.Len(t, st.Details(), 1) {
+		resource, ok := st.Details()[0].(*errdetails.ResourceInfo)
+		assert.True(t, ok)
+		assert.Equal(t, "deck", resource.ResourceType)
+		assert.Equal(t, "7", resource.ResourceName)
+	}
+
+	invalid := errs.InvalidArgumentError(errs.FieldViolation{Field: "front", Description: "must not be empty"})
+	st = status.Convert(StatusError(invalid.(*errs.Error).In("cards[2]")))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "cards[2]: front: must not be empty", st.Message())
+	if assert.Len(t, st.Details(), 1) {
+		badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+		assert.True(t, ok)
+		assert.Equal(t, "cards[2].front", badRequest.FieldViolations[0].Field)
+		assert.Equal(t, "must not be empty", badRequest.FieldViolations[0].Description)
+	}
+
+	st = status.Convert(StatusError(errs.QuotaExceededError("deck", 3, "deck 3 is full: 100 cards")))
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		quota, ok := st.Details()[0].(*errdetails.QuotaFailure)
+		assert.True(t, ok)
+		assert.Equal(t, "deck", quota.Violations[0].Subject)
+		assert.Equal(t, "deck 3 is full: 100 cards", quota.Violations[0].Description)
+	}
+}
+
+func TestUnaryServerErrors(t *testing.T) {
+	interceptor := UnaryServerErrors()
+	info := &grpc.UnaryServerInfo{FullMethod: "/grpc.CardService/GetCardById"}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errs.NotFoundError("card", 1)
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	resp, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	logs := observeLogs(t)
+	_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("connection refused")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	if entries := logs.All(); assert.Len(t, entries, 1) {
+		fields := entries[0].ContextMap()
+		assert.Equal(t, info.FullMethod, fields["grpc.method"])
+		assert.Equal(t, "Internal", fields["grpc.code"])
+		assert.Equal(t, "connection refused", fields["error"])
+	}
+}
@@ -0,0 +1,226 @@
+package interceptors
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/logger"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// IdempotencyKeyHeader is the metadata key clients put the idempotency key
+// in. The gateway forwards the Idempotency-Key HTTP header as this.
+const IdempotencyKeyHeader = "idempotency-key"
+
+const maxIdempotencyKeyLength = 255
+
+// Idempotency makes retries of the given methods safe: a request carrying an
+// idempotency key is handled once, and retries with the same key and payload
+// get the stored response back. Keys belong to the caller and the method they
+// were sent to, so nobody else can replay or block them.
+type Idempotency struct {
+	store   interfaces.IdempotencyRepository
+	ttl     time.Duration
+	lease   time.Duration
+	methods map[string]bool
+	now     func() time.Time
+}
+
+// NewIdempotency applies to the methods given by their full names, e.g.
+// "/grpc.CardService/CreateCard". Responses are remembered for ttl; a key
+// whose first request has not finished is held for lease only.
+func NewIdempotency(store interfaces.IdempotencyRepository, ttl, lease time.Duration, methods ...string) *Idempotency {
+	i := &Idempotency{store: store, ttl: ttl, lease: lease, methods: make(map[string]bool, len(methods)), now: time.Now}
+	for _, method := range methods {
+		i.methods[method] = true
+	}
+	return i
+}
+
+// UnaryServerInterceptor claims the key before calling the handler and saves
+// the response and its header metadata afterwards. Failed requests, and those
+// whose response could not be saved, release the key so that they can be
+// retried. A key reused with a different payload fails with
+// FailedPrecondition, and one whose first request is still running with
+// Unavailable.
+func (i *Idempotency) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		key := idempotencyKey(ctx)
+		msg, ok := req.(proto.Message)
+		if key == "" || !ok || !i.methods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			return nil, errs.Violation(IdempotencyKeyHeader, fmt.Sprintf("must be at most %d characters", maxIdempotencyKeyLength))
+		}
+
+		hash, err := requestHash(info.FullMethod, msg)
+		if err != nil {
+			return nil, err
+		}
+
+		claim := structs.IdempotencyRecord{
+			Key:         storageKey(ctx, info.FullMethod, key),
+			RequestHash: hash,
+			ExpiresAt:   i.now().Add(i.lease),
+		}
+		held, err := i.store.Claim(ctx, claim)
+		if err != nil {
+			return nil, err
+		}
+		if held != nil {
+			return replay(ctx, held, hash)
+		}
+
+		var header *headerRecorder
+		if stream := grpc.ServerTransportStreamFromContext(ctx); stream != nil {
+			header = &headerRecorder{ServerTransportStream: stream}
+			ctx = grpc.NewContextWithServerTransportStream(ctx, header)
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			i.release(ctx, claim.Key)
+			return nil, err
+		}
+
+		if err := i.complete(ctx, claim, resp, header); err != nil {
+			logger.Error(ctx, "Failed to store the response for an idempotency key", zap.Error(err))
+			i.release(ctx, claim.Key)
+		}
+		return resp, nil
+	}
+}
+
+func (i *Idempotency) complete(ctx context.Context, claim structs.IdempotencyRecord, resp interface{}, header *headerRecorder) error {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return fmt.Errorf("response %T is not a proto message", resp)
+	}
+
+	packed, err := anypb.New(msg)
+	if err != nil {
+		return err
+	}
+	claim.Response, err = proto.Marshal(packed)
+	if err != nil {
+		return err
+	}
+	if header != nil && len(header.md) > 0 {
+		if claim.Header, err = json.Marshal(header.md); err != nil {
+			return err
+		}
+	}
+
+	claim.ExpiresAt = i.now().Add(i.ttl)
+	return i.store.Complete(ctx, claim)
+}
+
+func (i *Idempotency) release(ctx context.Context, key string) {
+	if err := i.store.Release(ctx, key); err != nil {
+		logger.Error(ctx, "Failed to release an idempotency key", zap.Error(err))
+	}
+}
+
+// headerRecorder keeps a copy of the header metadata the handler sends, so
+// that it can be sent again with a replayed response.
+type headerRecorder struct {
+	grpc.ServerTransportStream
+	md metadata.MD
+}
+
+func (r *headerRecorder) SetHeader(md metadata.MD) error {
+	if err := r.ServerTransportStream.SetHeader(md); err != nil {
+		return err
+	}
+	r.md = metadata.Join(r.md, md)
+	return nil
+}
+
+func (r *headerRecorder) SendHeader(md metadata.MD) error {
+	if err := r.ServerTransportStream.SendHeader(md); err != nil {
+		return err
+	}
+	r.md = metadata.Join(r.md, md)
+	return nil
+}
+
+// replay answers a retry from the record holding its key.
+func replay(ctx context.Context, held *structs.IdempotencyRecord, hash []byte) (interface{}, error) {
+	if !bytes.Equal(held.RequestHash, hash) {
+		return nil, &errs.Error{Kind: errs.FailedPrecondition, Message: "idempotency key was already used for a different request"}
+	}
+	if held.Response == nil {
+		return nil, status.Error(codes.Unavailable, "A request with this idempotency key is still in progress")
+	}
+
+	if held.Header != nil {
+		var header metadata.MD
+		if err := json.Unmarshal(held.Header, &header); err != nil {
+			return nil, err
+		}
+		if err := grpc.SetHeader(ctx, header); err != nil {
+			return nil, err
+		}
+	}
+
+	var packed anypb.Any
+	if err := proto.Unmarshal(held.Response, &packed); err != nil {
+		return nil, err
+	}
+	return packed.UnmarshalNew()
+}
+
+func idempotencyKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(IdempotencyKeyHeader)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// storageKey is the key stored for a client's key: it is scoped to the caller
+// and the method, so that the same key from another caller or for another
+// method is a different one.
+func storageKey(ctx context.Context, method, key string) string {
+	h := sha256.New()
+	for _, part := range []string{CallerFromContext(ctx).Key(), method, key} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// requestHash identifies the payload of a request, including the method it
+// was sent to.
+func requestHash(method string, req proto.Message) ([]byte, error) {
+	payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write(payload)
+	return h.Sum(nil), nil
+}
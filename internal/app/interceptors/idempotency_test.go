@@ -0,0 +1,197 @@
+//go:build unit
+// +build unit
+
+package interceptors
+
+import (
+	"context"
+	"errors"
+	pb "flash-card-manager/internal/app/grpc"
+	mock_units "flash-card-manager/pkg/repository/interfaces/mocks"
+	"flash-card-manager/pkg/repository/memory"
+	"flash-card-manager/pkg/repository/structs"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestIdempotency(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: pb.CardService_CreateCard_FullMethodName}
+	req := &pb.CreateCardRequest{Front: "F", Back: "B", DeckId: 1, Author: "A"}
+	withKey := func(key string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, key))
+	}
+
+	t.Run("Replays Response", func(t *testing.T) {
+		interceptor := NewIdempotency(memory.NewIdempotency(), time.Hour, time.Minute, info.FullMethod).UnaryServerInterceptor()
+		calls := 0
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls++
+			return &pb.CardResponse{Id: int64(calls)}, nil
+		}
+
+		first, err := interceptor(withKey("k"), req, info, handler)
+		require.NoError(t, err)
+		second, err := interceptor(withKey("k"), proto.Clone(req), info, handler)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, calls)
+		assert.True(t, proto.Equal(first.(proto.Message), second.(proto.Message)))
+
+		_, err = interceptor(withKey("other"), req, info, handler)
+		require.NoError(t, err)
+		_, err = interceptor(context.Background(), req, info, handler)
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Different Payload", func(t *testing.T) {
+		interceptor := NewIdempotency(memory.NewIdempotency(), time.Hour, time.Minute, info.FullMethod).UnaryServerInterceptor()
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &pb.CardResponse{Id: 1}, nil
+		}
+
+		_, err := interceptor(withKey("k"), req, info, handler)
+		require.NoError(t, err)
+		_, err = interceptor(withKey("k"), &pb.CreateCardRequest{Front: "Other", Back: "B", DeckId: 1, Author: "A"}, info, handler)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(StatusError(err)))
+	})
+
+	t.Run("Released After Failure", func(t *testing.T) {
+		interceptor := NewIdempotency(memory.NewIdempotency(), time.Hour, time.Minute, info.FullMethod).UnaryServerInterceptor()
+		calls := 0
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("failed")
+			}
+			return &pb.CardResponse{Id: 1}, nil
+		}
+
+		_, err := interceptor(withKey("k"), req, info, handler)
+		assert.Error(t, err)
+		_, err = interceptor(withKey("k"), req, info, handler)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("In Progress", func(t *testing.T) {
+		store := memory.NewIdempotency()
+		interceptor := NewIdempotency(store, time.Hour, time.Minute, info.FullMethod).UnaryServerInterceptor()
+		hash, err := requestHash(info.FullMethod, req)
+		require.NoError(t, err)
+		_, err = store.Claim(context.Background(), structs.IdempotencyRecord{Key: storageKey(withKey("k"), info.FullMethod, "k"), RequestHash: hash, ExpiresAt: time.Now().Add(time.Minute)})
+		require.NoError(t, err)
+
+		_, err = interceptor(withKey("k"), req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			t.Fatal("handler called while the first request is in progress")
+			return nil, nil
+		})
+		assert.Equal(t, codes.Unavailable, status.Code(StatusError(err)))
+	})
+
+	t.Run("Expired Key", func(t *testing.T) {
+		interceptor := NewIdempotency(memory.NewIdempotency(), -time.Second, time.Minute, info.FullMethod).UnaryServerInterceptor()
+		calls := 0
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls++
+			return &pb.CardResponse{Id: 1}, nil
+		}
+
+		_, err := interceptor(withKey("k"), req, info, handler)
+		require.NoError(t, err)
+		_, err = interceptor(withKey("k"), req, info, handler)
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Abandoned Claim", func(t *testing.T) {
+		store := memory.NewIdempotency()
+		interceptor := NewIdempotency(store, time.Hour, time.Minute, info.FullMethod).UnaryServerInterceptor()
+		hash, err := requestHash(info.FullMethod, req)
+		require.NoError(t, err)
+		_, err = store.Claim(context.Background(), structs.IdempotencyRecord{Key: storageKey(withKey("k"), info.FullMethod, "k"), RequestHash: hash, ExpiresAt: time.Now().Add(-time.Second)})
+		require.NoError(t, err)
+
+		calls := 0
+		_, err = interceptor(withKey("k"), req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls++
+			return &pb.CardResponse{Id: 1}, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls, "a claim whose lease ran out is taken over")
+	})
+
+	t.Run("Lease And Failed Complete", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		store := mock_units.NewMockIdempotencyRepository(mockCtrl)
+		idempotency := NewIdempotency(store, time.Hour, time.Minute, info.FullMethod)
+		now := time.Now()
+		idempotency.now = func() time.Time { return now }
+		key := storageKey(withKey("k"), info.FullMethod, "k")
+
+		store.EXPECT().Claim(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, record structs.IdempotencyRecord) (*structs.IdempotencyRecord, error) {
+			assert.Equal(t, now.Add(time.Minute), record.ExpiresAt, "a pending claim is held for the lease")
+			return nil, nil
+		})
+		store.EXPECT().Complete(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, record structs.IdempotencyRecord) error {
+			assert.Equal(t, now.Add(time.Hour), record.ExpiresAt, "a response is kept for the TTL")
+			return errors.New("database is down")
+		})
+		store.EXPECT().Release(gomock.Any(), key).Return(nil)
+
+		resp, err := idempotency.UnaryServerInterceptor()(withKey("k"), req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &pb.CardResponse{Id: 1}, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), resp.(*pb.CardResponse).Id)
+	})
+
+	t.Run("Scoped To Caller And Method", func(t *testing.T) {
+		deckInfo := &grpc.UnaryServerInfo{FullMethod: pb.DeckService_CreateDeck_FullMethodName}
+		interceptor := NewIdempotency(memory.NewIdempotency(), time.Hour, time.Minute, info.FullMethod, deckInfo.FullMethod).UnaryServerInterceptor()
+		calls := 0
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			calls++
+			return &pb.CardResponse{Id: int64(calls)}, nil
+		}
+
+		_, err := interceptor(WithCaller(withKey("k"), Caller{User: "alice"}), req, info, handler)
+		require.NoError(t, err)
+		_, err = interceptor(WithCaller(withKey("k"), Caller{User: "bob"}), req, info, handler)
+		require.NoError(t, err)
+		_, err = interceptor(WithCaller(withKey("k"), Caller{User: "alice"}), &pb.CreateDeckRequest{Title: "T"}, deckInfo, handler)
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Replays Header", func(t *testing.T) {
+		interceptor := NewIdempotency(memory.NewIdempotency(), time.Hour, time.Minute, info.FullMethod).UnaryServerInterceptor()
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			require.NoError(t, grpc.SetHeader(ctx, metadata.Pairs("etag", `"1"`)))
+			return &pb.CardResponse{Id: 1}, nil
+		}
+
+		var first, second runtime.ServerTransportStream
+		_, err := interceptor(grpc.NewContextWithServerTransportStream(withKey("k"), &first), req, info, handler)
+		require.NoError(t, err)
+		_, err = interceptor(grpc.NewContextWithServerTransportStream(withKey("k"), &second), req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			t.Fatal("handler called for a retry")
+			return nil, nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{`"1"`}, first.Header().Get("etag"))
+		assert.Equal(t, []string{`"1"`}, second.Header().Get("etag"))
+	})
+}
@@ -0,0 +1,145 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// ForwardedUserHeader is the metadata key of the user a trusted proxy
+	// authenticated with a client certificate. The gateway sets it; from
+	// other peers it is ignored.
+	ForwardedUserHeader = "x-forwarded-user"
+
+	forwardedForHeader = "x-forwarded-for"
+	// inProcessNetwork is the network of the in-memory connection of the
+	// embedded gateway.
+	inProcessNetwork = "bufconn"
+)
+
+// Caller is who a request comes from, as far as the server can verify it.
+type Caller struct {
+	// User is the common name of the client certificate the TLS handshake
+	// verified, or the one a trusted proxy verified. It is "" for anonymous
+	// callers.
+	User string
+	// IP is the address of the client: the peer's, or behind a trusted proxy
+	// the one the proxy saw.
+	IP string
+}
+
+// Key names the caller for rate limiting: the user if there is one, else the
+// IP.
+func (c Caller) Key() string {
+	if c.User != "" {
+		return "user:" + c.User
+	}
+	return "ip:" + c.IP
+}
+
+type callerKey struct{}
+
+// WithCaller returns a copy of ctx that carries caller.
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the caller UnaryServerIdentity found, or else
+// works it out from the peer alone, trusting only the in-process gateway.
+func CallerFromContext(ctx context.Context) Caller {
+	if caller, ok := ctx.Value(callerKey{}).(Caller); ok {
+		return caller
+	}
+	return identify(ctx, nil)
+}
+
+// UserFromContext returns the verified user of the request, or "" if the
+// caller is anonymous.
+func UserFromContext(ctx context.Context) string {
+	return CallerFromContext(ctx).User
+}
+
+// UnaryServerIdentity puts the Caller of each request into the context. The
+// x-forwarded-for and x-forwarded-user metadata are believed only from the
+// in-process gateway and from peers in trusted, such as a separate gateway;
+// anyone else could send any value. Put it first in the chain.
+func UnaryServerIdentity(trusted []*net.IPNet) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(WithCaller(ctx, identify(ctx, trusted)), req)
+	}
+}
+
+// StreamServerIdentity is UnaryServerIdentity for streams.
+func StreamServerIdentity(trusted []*net.IPNet) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = WithCaller(ss.Context(), identify(ss.Context(), trusted))
+		return handler(srv, wrapped)
+	}
+}
+
+func identify(ctx context.Context, trusted []*net.IPNet) Caller {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return Caller{}
+	}
+
+	ip := p.Addr.String()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	if p.Addr.Network() == inProcessNetwork || contains(trusted, net.ParseIP(ip)) {
+		caller := Caller{User: lastMetadata(ctx, ForwardedUserHeader), IP: ip}
+		// The proxy appends the address it saw to what its client sent.
+		if forwarded := lastMetadata(ctx, forwardedForHeader); forwarded != "" {
+			addrs := strings.Split(forwarded, ",")
+			caller.IP = strings.TrimSpace(addrs[len(addrs)-1])
+		}
+		return caller
+	}
+
+	caller := Caller{IP: ip}
+	if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		caller.User = CertificateUser(info.State)
+	}
+	return caller
+}
+
+// CertificateUser returns the common name of the client certificate verified
+// in a TLS handshake, or "" if there was none.
+func CertificateUser(state tls.ConnectionState) string {
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return state.VerifiedChains[0][0].Subject.CommonName
+}
+
+func contains(nets []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastMetadata returns the last value of key, which is the one a proxy added
+// after those its client sent.
+func lastMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[len(values)-1]
+	}
+	return ""
+}
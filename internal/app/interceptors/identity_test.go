@@ -0,0 +1,94 @@
+//go:build unit
+// +build unit
+
+package interceptors
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// inProcessAddr is the address of a peer on the embedded gateway's in-memory
+// connection.
+type inProcessAddr struct{}
+
+func (inProcessAddr) Network() string { return inProcessNetwork }
+func (inProcessAddr) String() string  { return inProcessNetwork }
+
+func TestIdentify(t *testing.T) {
+	_, trusted, _ := net.ParseCIDR("10.0.0.0/24")
+	spoofed := []string{forwardedForHeader, "192.0.2.1", ForwardedUserHeader, "mallory"}
+	verified := credentials.TLSInfo{State: tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "alice"}}}},
+	}}
+
+	tests := []struct {
+		name     string
+		addr     net.Addr
+		authInfo credentials.AuthInfo
+		pairs    []string
+		want     Caller
+	}{
+		{
+			name: "Peer",
+			addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.7"), Port: 5000},
+			want: Caller{IP: "192.0.2.7"},
+		},
+		{
+			name:  "Untrusted Peer Forwarding",
+			addr:  &net.TCPAddr{IP: net.ParseIP("192.0.2.7"), Port: 5000},
+			pairs: spoofed,
+			want:  Caller{IP: "192.0.2.7"},
+		},
+		{
+			name:     "Client Certificate",
+			addr:     &net.TCPAddr{IP: net.ParseIP("192.0.2.7"), Port: 5000},
+			authInfo: verified,
+			pairs:    spoofed,
+			want:     Caller{User: "alice", IP: "192.0.2.7"},
+		},
+		{
+			name:  "Embedded Gateway",
+			addr:  inProcessAddr{},
+			pairs: []string{forwardedForHeader, "192.0.2.1, 192.0.2.7", ForwardedUserHeader, "bob"},
+			want:  Caller{User: "bob", IP: "192.0.2.7"},
+		},
+		{
+			name: "Trusted Proxy",
+			addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 5000},
+			// The values the proxy's client sent come before the proxy's own.
+			pairs: append(spoofed, forwardedForHeader, "192.0.2.7", ForwardedUserHeader, "bob"),
+			want:  Caller{User: "bob", IP: "192.0.2.7"},
+		},
+		{
+			name:     "Trusted Proxy Without User",
+			addr:     &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 5000},
+			authInfo: verified,
+			want:     Caller{IP: "10.0.0.5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tt.addr, AuthInfo: tt.authInfo})
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(tt.pairs...))
+
+			var got Caller
+			_, err := UnaryServerIdentity([]*net.IPNet{trusted})(ctx, nil, nil, func(ctx context.Context, req interface{}) (interface{}, error) {
+				got = CallerFromContext(ctx)
+				return nil, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package interceptors
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"flash-card-manager/pkg/logger"
+	"time"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RequestIDHeader is the metadata key of the request ID. The server
+	// takes it from the request or generates one, and returns it in the
+	// response headers. The gateway maps it to X-Request-Id.
+	RequestIDHeader = "x-request-id"
+)
+
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the ID of the request being served.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// UnaryServerLogging puts a logger with the method, request ID, user and trace
+// ID of the request into the context, and writes one access log line when the
+// request completes. Put it first in the chain, so that it sees the final
+// codes and every other interceptor logs with the request's fields.
+func UnaryServerLogging() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx = withRequestLogger(ctx, info.FullMethod)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, RequestIDFromContext(ctx)))
+
+		resp, err := handler(ctx, req)
+
+		logAccess(ctx, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerLogging is UnaryServerLogging for streams.
+func StreamServerLogging() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := withRequestLogger(ss.Context(), info.FullMethod)
+		_ = ss.SetHeader(metadata.Pairs(RequestIDHeader, RequestIDFromContext(ctx)))
+
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		err := handler(srv, wrapped)
+
+		logAccess(ctx, start, err)
+		return err
+	}
+}
+
+func withRequestLogger(ctx context.Context, method string) context.Context {
+	id := firstMetadata(ctx, RequestIDHeader)
+	if id == "" || len(id) > maxRequestIDLength {
+		id = newRequestID()
+	}
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+
+	fields := []zap.Field{
+		zap.String("grpc.method", method),
+		zap.String("request_id", id),
+	}
+	if user := UserFromContext(ctx); user != "" {
+		fields = append(fields, zap.String("user", user))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		fields = append(fields, zap.String("trace_id", sc.TraceID().String()))
+	}
+	return logger.ToContext(ctx, logger.FromContext(ctx).With(fields...))
+}
+
+func logAccess(ctx context.Context, start time.Time, err error) {
+	code := status.Code(err)
+	fields := []zap.Field{
+		zap.Duration("duration", time.Since(start)),
+		zap.String("grpc.code", code.String()),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		fields = append(fields, zap.String("peer", p.Addr.String()))
+	}
+
+	switch code {
+	case codes.OK:
+		logger.Info(ctx, "RPC finished", fields...)
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+		logger.Error(ctx, "RPC failed", append(fields, zap.Error(err))...)
+	default:
+		logger.Warn(ctx, "RPC failed", append(fields, zap.Error(err))...)
+	}
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
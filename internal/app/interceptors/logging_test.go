@@ -0,0 +1,91 @@
+//go:build unit
+// +build unit
+
+package interceptors
+
+import (
+	"context"
+	"flash-card-manager/pkg/logger"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger.SetGlobal(zap.New(core))
+	t.Cleanup(func() { logger.SetGlobal(zap.NewNop()) })
+	return logs
+}
+
+func TestUnaryServerLogging(t *testing.T) {
+	interceptor := UnaryServerLogging()
+	info := &grpc.UnaryServerInfo{FullMethod: "/grpc.CardService/CreateCard"}
+
+	t.Run("PropagatesRequestID", func(t *testing.T) {
+		logs := observeLogs(t)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, "req-1"))
+		ctx = WithCaller(ctx, Caller{User: "alice", IP: "10.0.0.1"})
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 5000}})
+
+		_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.Equal(t, "req-1", RequestIDFromContext(ctx))
+			logger.Info(ctx, "inside handler")
+			return "card", nil
+		})
+		require.NoError(t, err)
+
+		entries := logs.All()
+		require.Len(t, entries, 2)
+		for _, entry := range entries {
+			fields := entry.ContextMap()
+			assert.Equal(t, "req-1", fields["request_id"])
+			assert.Equal(t, "alice", fields["user"])
+			assert.Equal(t, info.FullMethod, fields["grpc.method"])
+		}
+
+		access := entries[1]
+		assert.Equal(t, zapcore.InfoLevel, access.Level)
+		assert.Equal(t, "OK", access.ContextMap()["grpc.code"])
+		assert.Equal(t, "10.0.0.1:5000", access.ContextMap()["peer"])
+		assert.Contains(t, access.ContextMap(), "duration")
+	})
+
+	t.Run("GeneratesRequestID", func(t *testing.T) {
+		logs := observeLogs(t)
+
+		var id string
+		_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			id = RequestIDFromContext(ctx)
+			return nil, status.Error(codes.NotFound, "deck not found")
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		assert.Len(t, id, 32)
+		access := logs.All()[0]
+		assert.Equal(t, zapcore.WarnLevel, access.Level)
+		assert.Equal(t, id, access.ContextMap()["request_id"])
+		assert.Equal(t, "NotFound", access.ContextMap()["grpc.code"])
+	})
+
+	t.Run("ServerErrors", func(t *testing.T) {
+		logs := observeLogs(t)
+
+		_, _ = interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.Internal, "internal error")
+		})
+
+		assert.Equal(t, zapcore.ErrorLevel, logs.All()[0].Level)
+	})
+}
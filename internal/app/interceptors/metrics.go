@@ -0,0 +1,66 @@
+package interceptors
+
+import (
+	"context"
+	"flash-card-manager/pkg/metrics"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcStarted = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "grpc_server_started_total",
+		Help:      "RPCs started on the server.",
+	}, []string{"method", "type"})
+
+	grpcHandled = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "grpc_server_handled_total",
+		Help:      "RPCs completed on the server, by status code.",
+	}, []string{"method", "type", "code"})
+
+	grpcHandlingSeconds = promauto.With(metrics.Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Name:      "grpc_server_handling_seconds",
+		Help:      "How long RPCs took to complete on the server.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "type"})
+)
+
+// UnaryServerMetrics counts RPCs and measures their latency per method. Put it
+// before UnaryServerErrors in the chain, so that it sees the final codes.
+func UnaryServerMetrics() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		grpcStarted.WithLabelValues(info.FullMethod, "unary").Inc()
+
+		resp, err := handler(ctx, req)
+
+		observeRPC(info.FullMethod, "unary", start, err)
+		return resp, err
+	}
+}
+
+// StreamServerMetrics is UnaryServerMetrics for streams. The latency is that
+// of the whole stream.
+func StreamServerMetrics() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		grpcStarted.WithLabelValues(info.FullMethod, "stream").Inc()
+
+		err := handler(srv, ss)
+
+		observeRPC(info.FullMethod, "stream", start, err)
+		return err
+	}
+}
+
+func observeRPC(method, rpcType string, start time.Time, err error) {
+	grpcHandled.WithLabelValues(method, rpcType, status.Code(err).String()).Inc()
+	grpcHandlingSeconds.WithLabelValues(method, rpcType).Observe(time.Since(start).Seconds())
+}
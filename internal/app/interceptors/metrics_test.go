@@ -0,0 +1,35 @@
+//go:build unit
+// +build unit
+
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerMetrics(t *testing.T) {
+	interceptor := UnaryServerMetrics()
+	info := &grpc.UnaryServerInfo{FullMethod: "/grpc.DeckService/GetDeckById"}
+
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return "deck", nil }
+	notFound := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "deck not found")
+	}
+
+	_, _ = interceptor(context.Background(), nil, info, ok)
+	_, _ = interceptor(context.Background(), nil, info, ok)
+	_, err := interceptor(context.Background(), nil, info, notFound)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	assert.Equal(t, 3.0, testutil.ToFloat64(grpcStarted.WithLabelValues(info.FullMethod, "unary")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(grpcHandled.WithLabelValues(info.FullMethod, "unary", "OK")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(grpcHandled.WithLabelValues(info.FullMethod, "unary", "NotFound")))
+	assert.Equal(t, 1, testutil.CollectAndCount(grpcHandlingSeconds))
+}
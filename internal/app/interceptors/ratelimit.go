@@ -0,0 +1,185 @@
+package interceptors
+
+import (
+	"context"
+	"flash-card-manager/pkg/metrics"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	// RetryAfterHeader is the metadata key of the number of seconds a rate
+	// limited caller should wait. The gateway maps it to Retry-After.
+	RetryAfterHeader = "retry-after"
+
+	healthService = "/grpc.health.v1.Health/"
+
+	// sweepInterval is how often buckets that have filled up again are
+	// dropped.
+	sweepInterval = time.Minute
+)
+
+var rateLimited = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Name:      "grpc_server_rate_limited_total",
+	Help:      "RPCs rejected by the rate limiter.",
+}, []string{"method"})
+
+// RateLimit is a token bucket: Rate tokens per second, up to Burst at once.
+type RateLimit struct {
+	Rate  float64
+	Burst int64
+}
+
+// RateLimiter keeps a token bucket per caller and method, and rejects requests
+// with ResourceExhausted once the bucket is empty. A caller is the verified
+// user, else the client IP, as UnaryServerIdentity found them; nothing the
+// client merely claims counts. Health checks are never limited.
+type RateLimiter struct {
+	limit   RateLimit
+	methods map[string]RateLimit
+	now     func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+type bucketKey struct {
+	method string
+	caller string
+}
+
+type bucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter applies limit to every method, except those in methods. Its
+// keys are full method names ("/grpc.CardService/CreateCard"), or the same
+// without the leading slash or the service.
+func NewRateLimiter(limit RateLimit, methods map[string]RateLimit) *RateLimiter {
+	return &RateLimiter{
+		limit:   limit,
+		methods: methods,
+		now:     time.Now,
+		buckets: make(map[bucketKey]*bucket),
+	}
+}
+
+// UnaryServerInterceptor rate limits unary calls. Put it before the
+// interceptors that do real work, such as idempotency.
+func (l *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rate limits opening streams; the messages on a
+// stream are not counted.
+func (l *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (l *RateLimiter) check(ctx context.Context, method string) error {
+	if strings.HasPrefix(method, healthService) {
+		return nil
+	}
+
+	wait := l.take(bucketKey{method: method, caller: CallerFromContext(ctx).Key()})
+	if wait == 0 {
+		return nil
+	}
+	rateLimited.WithLabelValues(method).Inc()
+
+	seconds := int64(math.Ceil(wait.Seconds()))
+	// SetHeader only fails outside of a gRPC call, e.g. in unit tests.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, strconv.FormatInt(seconds, 10)))
+
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("rate limit exceeded, retry in %ds", seconds))
+	if withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// take removes a token from the bucket of key. It returns zero if there was
+// one, or else how long until there is.
+func (l *RateLimiter) take(key bucketKey) time.Duration {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		limit := l.limitFor(key.method)
+		b = &bucket{limit: limit, tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+	b.refill(now)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration(math.Ceil((1 - b.tokens) / b.limit.Rate * float64(time.Second)))
+}
+
+// sweep drops the buckets that are full again, which is the state a new
+// bucket starts in anyway.
+func (l *RateLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if b.refill(now); b.tokens >= float64(b.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+func (l *RateLimiter) limitFor(method string) RateLimit {
+	name := strings.TrimPrefix(method, "/")
+	candidates := []string{method, name}
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		candidates = append(candidates, name[i+1:])
+	}
+	for _, candidate := range candidates {
+		if limit, ok := l.methods[candidate]; ok {
+			return limit
+		}
+	}
+	return l.limit
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+		b.last = now
+	}
+}
@@ -0,0 +1,122 @@
+//go:build unit
+// +build unit
+
+package interceptors
+
+import (
+	"context"
+	pb "flash-card-manager/internal/app/grpc"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimiter(t *testing.T) {
+	createCard := &grpc.UnaryServerInfo{FullMethod: pb.CardService_CreateCard_FullMethodName}
+	getCard := &grpc.UnaryServerInfo{FullMethod: pb.CardService_GetCardById_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &pb.CardResponse{}, nil
+	}
+	from := func(ip string, pairs ...string) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 5000}})
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(pairs...))
+	}
+	as := func(user string) context.Context {
+		return WithCaller(from("10.0.0.1"), Caller{User: user, IP: "10.0.0.1"})
+	}
+
+	newLimiter := func() (*RateLimiter, *time.Time) {
+		now := time.Unix(1700000000, 0)
+		limiter := NewRateLimiter(RateLimit{Rate: 100, Burst: 100}, map[string]RateLimit{"CreateCard": {Rate: 0.5, Burst: 2}})
+		limiter.now = func() time.Time { return now }
+		return limiter, &now
+	}
+
+	t.Run("Per Method", func(t *testing.T) {
+		limiter, now := newLimiter()
+		interceptor := limiter.UnaryServerInterceptor()
+		ctx := as("alice")
+
+		for i := 0; i < 2; i++ {
+			_, err := interceptor(ctx, nil, createCard, handler)
+			require.NoError(t, err)
+		}
+
+		_, err := interceptor(ctx, nil, createCard, handler)
+		st := status.Convert(err)
+		assert.Equal(t, codes.ResourceExhausted, st.Code())
+		assert.Equal(t, "rate limit exceeded, retry in 2s", st.Message())
+		if assert.Len(t, st.Details(), 1) {
+			retry := st.Details()[0].(*errdetails.RetryInfo)
+			assert.Equal(t, 2*time.Second, retry.RetryDelay.AsDuration())
+		}
+
+		// Other methods have their own buckets.
+		_, err = interceptor(ctx, nil, getCard, handler)
+		assert.NoError(t, err)
+
+		*now = now.Add(time.Second)
+		_, err = interceptor(ctx, nil, createCard, handler)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		*now = now.Add(time.Second)
+		_, err = interceptor(ctx, nil, createCard, handler)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Per Caller", func(t *testing.T) {
+		limiter, _ := newLimiter()
+		interceptor := limiter.UnaryServerInterceptor()
+		exhaust := func(ctx context.Context) error {
+			var err error
+			for i := 0; i < 3 && err == nil; i++ {
+				_, err = interceptor(ctx, nil, createCard, handler)
+			}
+			return err
+		}
+
+		assert.Error(t, exhaust(as("alice")))
+		assert.Error(t, exhaust(as("bob")))
+		// Without a verified user the peer's IP counts, whatever the client
+		// claims to be.
+		assert.Error(t, exhaust(from("10.0.0.1")))
+		_, err := interceptor(from("10.0.0.1", "x-user-id", "mallory", forwardedForHeader, "192.0.2.9"), nil, createCard, handler)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		_, err = interceptor(from("10.0.0.2"), nil, createCard, handler)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Health Is Not Limited", func(t *testing.T) {
+		limiter := NewRateLimiter(RateLimit{Rate: 1, Burst: 1}, nil)
+		interceptor := limiter.UnaryServerInterceptor()
+		check := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+		for i := 0; i < 5; i++ {
+			_, err := interceptor(from("10.0.0.1"), nil, check, handler)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("Sweep", func(t *testing.T) {
+		limiter, now := newLimiter()
+		interceptor := limiter.UnaryServerInterceptor()
+		_, err := interceptor(as("alice"), nil, createCard, handler)
+		require.NoError(t, err)
+		_, err = interceptor(as("bob"), nil, getCard, handler)
+		require.NoError(t, err)
+		assert.Len(t, limiter.buckets, 2)
+
+		*now = now.Add(sweepInterval)
+		_, err = interceptor(as("carol"), nil, getCard, handler)
+		require.NoError(t, err)
+		assert.Len(t, limiter.buckets, 1)
+	})
+}
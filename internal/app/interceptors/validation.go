@@ -0,0 +1,23 @@
+package interceptors
+
+import (
+	"context"
+	"flash-card-manager/internal/app/validation"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnaryServerValidation rejects requests that break the rules registered for
+// their message type before they reach the handler. The violations come back
+// as an errs.InvalidArgument error, so it belongs inside UnaryServerErrors.
+func UnaryServerValidation(rules *validation.Registry) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if err := rules.Validate(msg); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,44 @@
+//go:build unit
+// +build unit
+
+package interceptors
+
+import (
+	"context"
+	pb "flash-card-manager/internal/app/grpc"
+	"flash-card-manager/internal/app/validation"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerValidation(t *testing.T) {
+	interceptor := UnaryServerValidation(validation.Rules())
+	info := &grpc.UnaryServerInfo{FullMethod: "/grpc.CardService/CreateCard"}
+
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return &pb.CardResponse{}, nil
+	}
+
+	_, err := interceptor(context.Background(), &pb.CreateCardRequest{Front: "F", Back: "B", DeckId: 1}, info, handler)
+	assert.False(t, called)
+
+	st := status.Convert(StatusError(err))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+		assert.True(t, ok)
+		assert.Equal(t, "author", badRequest.FieldViolations[0].Field)
+		assert.Equal(t, "is required", badRequest.FieldViolations[0].Description)
+	}
+
+	_, err = interceptor(context.Background(), &pb.CreateCardRequest{Front: "F", Back: "B", DeckId: 1, Author: "A"}, info, handler)
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
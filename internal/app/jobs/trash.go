@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"context"
+	"flash-card-manager/pkg/logger"
+	"flash-card-manager/pkg/repository/interfaces"
+	"time"
+)
+
+// TrashPurger periodically hard-deletes decks and cards that have been in the
+// trash for longer than the retention window.
+type TrashPurger struct {
+	repo      interfaces.TrashRepository
+	retention time.Duration
+	interval  time.Duration
+	now       func() time.Time
+}
+
+func NewTrashPurger(repo interfaces.TrashRepository, retention, interval time.Duration) *TrashPurger {
+	return &TrashPurger{repo: repo, retention: retention, interval: interval, now: time.Now}
+}
+
+// Run purges the trash once right away and then every interval until ctx is
+// cancelled.
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.Purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *TrashPurger) Purge(ctx context.Context) {
+	decks, cards, err := p.repo.Purge(ctx, p.now().Add(-p.retention))
+	if err != nil {
+		logger.Errorf(ctx, "Failed to purge trash: %v", err)
+		return
+	}
+
+	if decks > 0 || cards > 0 {
+		logger.Infof(ctx, "Purged %d decks and %d cards from trash", decks, cards)
+	}
+}
@@ -0,0 +1,139 @@
+// Package lifecycle runs the long-lived parts of the server and shuts them down
+// in order when the process is asked to stop.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/logger"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Manager starts components as they are added and stops them in reverse
+// order, so everything is stopped before what it depends on.
+type Manager struct {
+	timeout    time.Duration
+	components []*component
+	failed     chan error
+}
+
+type component struct {
+	name   string
+	stop   func(ctx context.Context) error
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New returns a Manager that gives the components timeout in total to stop.
+func New(timeout time.Duration) *Manager {
+	return &Manager{timeout: timeout, failed: make(chan error, 1)}
+}
+
+// Add registers a component. A non-nil run is started right away in its own
+// goroutine and its context is cancelled when the component is stopped; an
+// error from run shuts the whole Manager down. stop, if not nil, is called
+// at shutdown before waiting for run to return.
+func (m *Manager) Add(name string, run, stop func(ctx context.Context) error) {
+	c := &component{name: name, stop: stop}
+	m.components = append(m.components, c)
+	if run == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	go func() {
+		defer close(c.done)
+		if err := run(ctx); err != nil && ctx.Err() == nil {
+			select {
+			case m.failed <- fmt.Errorf("%s: %w", name, err):
+			default:
+			}
+		}
+	}()
+}
+
+// OnStop registers a resource that only needs releasing at shutdown.
+func (m *Manager) OnStop(name string, stop func(ctx context.Context) error) {
+	m.Add(name, nil, stop)
+}
+
+// Run blocks until ctx is cancelled, the process receives SIGINT or SIGTERM,
+// or a component fails, and then stops every component. It returns the
+// failure together with any errors from stopping.
+func (m *Manager) Run(ctx context.Context) error {
+	ctx, stopSignals := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	var cause error
+	select {
+	case <-ctx.Done():
+		logger.Infof(ctx, "Shutting down")
+	case cause = <-m.failed:
+		logger.Errorf(ctx, "Shutting down after failure: %v", cause)
+	}
+
+	return errors.Join(cause, m.Stop())
+}
+
+// Stop stops the components in reverse order within the timeout.
+func (m *Manager) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(m.components) - 1; i >= 0; i-- {
+		c := m.components[i]
+		if c.cancel != nil {
+			c.cancel()
+		}
+		if c.stop != nil {
+			if err := c.stop(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("stop %s: %w", c.name, err))
+			}
+		}
+		if c.done != nil {
+			select {
+			case <-c.done:
+			case <-ctx.Done():
+				errs = append(errs, fmt.Errorf("stop %s: %w", c.name, ctx.Err()))
+			}
+		}
+	}
+	m.components = nil
+
+	return errors.Join(errs...)
+}
+
+// Closer adapts Close methods without a context to stop functions.
+func Closer(close func() error) func(ctx context.Context) error {
+	return func(context.Context) error {
+		return close()
+	}
+}
+
+// GracefulStop lets the server finish in-flight requests, and closes the
+// remaining connections if that takes longer than ctx allows.
+func GracefulStop(server *grpc.Server) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			server.Stop()
+			return ctx.Err()
+		}
+	}
+}
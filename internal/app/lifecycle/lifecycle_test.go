@@ -0,0 +1,88 @@
+//go:build unit
+// +build unit
+
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestManager(t *testing.T) {
+	t.Run("StopsInReverseOrder", func(t *testing.T) {
+		var stopped []string
+		record := func(name string) func(context.Context) error {
+			return func(context.Context) error {
+				stopped = append(stopped, name)
+				return nil
+			}
+		}
+
+		m := New(time.Second)
+		m.OnStop("database", record("database"))
+		m.Add("consumer", func(ctx context.Context) error {
+			<-ctx.Done()
+			stopped = append(stopped, "consumer done")
+			return nil
+		}, nil)
+		m.Add("server", func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}, record("server"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.NoError(t, m.Run(ctx))
+
+		assert.Equal(t, []string{"server", "consumer done", "database"}, stopped)
+	})
+
+	t.Run("FailureShutsDown", func(t *testing.T) {
+		failure := errors.New("broker went away")
+		released := false
+
+		m := New(time.Second)
+		m.OnStop("database", func(context.Context) error {
+			released = true
+			return nil
+		})
+		m.Add("consumer", func(context.Context) error { return failure }, nil)
+
+		err := m.Run(context.Background())
+		assert.ErrorIs(t, err, failure)
+		assert.True(t, released)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		m := New(10 * time.Millisecond)
+		m.Add("stuck", func(context.Context) error {
+			select {}
+		}, nil)
+
+		err := m.Stop()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestGracefulStop(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	m := New(time.Second)
+	m.Add("grpc server", func(context.Context) error {
+		return server.Serve(listener)
+	}, GracefulStop(server))
+
+	require.NoError(t, m.Stop())
+
+	_, err = net.DialTimeout("tcp", listener.Addr().String(), 100*time.Millisecond)
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+// Package migrate applies the embedded migrations to the database the server
+// runs against.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/scripts/migrations"
+
+	"github.com/pressly/goose/v3"
+)
+
+// lockID is the Postgres advisory lock held while migrations run, so replicas
+// started together apply them one at a time.
+const lockID = 7_301_338_204
+
+// Commands lists what Run accepts.
+var Commands = []string{"up", "down", "status", "redo", "seed"}
+
+type Migrator struct {
+	database db.DatabaseInterface
+	sqlDB    *sql.DB
+	dialect  string
+	dir      string
+	close    func() error
+}
+
+// New prepares migrations for a database opened with db.Open. Close the
+// Migrator when done; the database itself stays open.
+func New(database db.DatabaseInterface) (*Migrator, error) {
+	switch d := database.(type) {
+	case *db.SQLiteDatabase:
+		return &Migrator{database: d, sqlDB: d.DB(), dialect: "sqlite3", dir: migrations.SQLiteDir, close: func() error { return nil }}, nil
+	case *db.Database:
+		sqlDB := d.OpenSQL()
+		return &Migrator{database: d, sqlDB: sqlDB, dialect: "postgres", dir: migrations.PostgresDir, close: sqlDB.Close}, nil
+	default:
+		return nil, fmt.Errorf("migrations are not supported for %T", database)
+	}
+}
+
+func (m *Migrator) Close() error {
+	return m.close()
+}
+
+// Run executes one of Commands. Everything but status holds the advisory lock
+// on Postgres.
+func (m *Migrator) Run(ctx context.Context, command string) error {
+	goose.SetBaseFS(migrations.FS)
+	if err := goose.SetDialect(m.dialect); err != nil {
+		return err
+	}
+
+	switch command {
+	case "up":
+		return m.locked(ctx, func() error { return goose.UpContext(ctx, m.sqlDB, m.dir) })
+	case "down":
+		return m.locked(ctx, func() error { return goose.DownContext(ctx, m.sqlDB, m.dir) })
+	case "redo":
+		return m.locked(ctx, func() error { return goose.RedoContext(ctx, m.sqlDB, m.dir) })
+	case "status":
+		return goose.StatusContext(ctx, m.sqlDB, m.dir)
+	case "seed":
+		return m.locked(ctx, func() error { return m.Seed(ctx) })
+	default:
+		return fmt.Errorf("unknown migrate command %q, want one of %v", command, Commands)
+	}
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.Run(ctx, "up")
+}
+
+// Seed fills the database with test data, unless it already has decks.
+func (m *Migrator) Seed(ctx context.Context) error {
+	return m.database.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		var exists bool
+		if err := m.database.ExecQueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM decks)").Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+
+		_, err := m.database.Exec(ctx, migrations.Seed)
+		return err
+	})
+}
+
+// locked runs fn under the advisory lock on Postgres. SQLite serializes
+// writers on its own.
+func (m *Migrator) locked(ctx context.Context, fn func() error) error {
+	if m.dialect != "postgres" {
+		return fn()
+	}
+
+	conn, err := m.sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockID)
+
+	return fn()
+}
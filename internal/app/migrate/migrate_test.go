@@ -0,0 +1,87 @@
+//go:build unit
+// +build unit
+
+package migrate
+
+import (
+	"context"
+	"flash-card-manager/pkg/db"
+	"path/filepath"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func newMigrator(t *testing.T) (*Migrator, *db.SQLiteDatabase) {
+	database, err := db.NewSQLite(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(database.Close)
+
+	migrator, err := New(database)
+	require.NoError(t, err)
+	t.Cleanup(func() { migrator.Close() })
+
+	goose.SetLogger(goose.NopLogger())
+	return migrator, database
+}
+
+func count(t *testing.T, database db.DatabaseInterface, table string) int {
+	var n int
+	require.NoError(t, database.ExecQueryRow(context.Background(), "SELECT count(*) FROM "+table).Scan(&n))
+	return n
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("UpDownRedo", func(t *testing.T) {
+		migrator, database := newMigrator(t)
+
+		require.NoError(t, migrator.Up(ctx))
+		require.NoError(t, migrator.Run(ctx, "status"))
+		require.Zero(t, count(t, database, "decks"))
+
+		require.NoError(t, migrator.Run(ctx, "redo"))
+		require.Zero(t, count(t, database, "decks"))
+
+		require.NoError(t, migrator.Run(ctx, "down"))
+		var columns int
+		require.NoError(t, database.ExecQueryRow(ctx, "SELECT count(*) FROM pragma_table_info('idempotency_keys') WHERE name='response_header'").Scan(&columns))
+		require.Zero(t, columns)
+
+		require.NoError(t, migrator.Run(ctx, "down"))
+		require.NoError(t, database.ExecQueryRow(ctx, "SELECT count(*) FROM pragma_table_info('decks') WHERE name='owner'").Scan(&columns))
+		require.Zero(t, columns)
+
+		require.NoError(t, migrator.Run(ctx, "down"))
+		var tables int
+		require.NoError(t, database.ExecQueryRow(ctx, "SELECT count(*) FROM sqlite_master WHERE name='decks'").Scan(&tables))
+		require.Zero(t, tables)
+	})
+
+	t.Run("UpIsIdempotent", func(t *testing.T) {
+		migrator, _ := newMigrator(t)
+
+		require.NoError(t, migrator.Up(ctx))
+		require.NoError(t, migrator.Up(ctx))
+	})
+
+	t.Run("Seed", func(t *testing.T) {
+		migrator, database := newMigrator(t)
+		require.NoError(t, migrator.Up(ctx))
+
+		require.NoError(t, migrator.Run(ctx, "seed"))
+		require.Equal(t, 2, count(t, database, "decks"))
+		require.Equal(t, 5, count(t, database, "cards"))
+
+		require.NoError(t, migrator.Run(ctx, "seed"))
+		require.Equal(t, 2, count(t, database, "decks"))
+	})
+
+	t.Run("UnknownCommand", func(t *testing.T) {
+		migrator, _ := newMigrator(t)
+
+		require.Error(t, migrator.Run(ctx, "sideways"))
+	})
+}
@@ -0,0 +1,163 @@
+// Package study runs a review session over the cards of a deck in the
+// terminal.
+package study
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Grade is how well a card was remembered, from Again to Easy.
+type Grade int
+
+const (
+	Again Grade = iota + 1
+	Hard
+	Good
+	Easy
+)
+
+func (g Grade) String() string {
+	switch g {
+	case Again:
+		return "again"
+	case Hard:
+		return "hard"
+	case Good:
+		return "good"
+	case Easy:
+		return "easy"
+	default:
+		return "unknown"
+	}
+}
+
+// Card is what a session shows of a card.
+type Card struct {
+	ID    int64
+	Front string
+	Back  string
+}
+
+// Shuffle returns cards in random order, for servers that do not schedule
+// reviews.
+func Shuffle(cards []Card, rng *rand.Rand) []Card {
+	shuffled := append([]Card(nil), cards...)
+	rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	return shuffled
+}
+
+// Session goes through the cards in order. A card graded Again is shown once
+// more at the end.
+type Session struct {
+	queue    []Card
+	repeat   []bool
+	pos      int
+	revealed bool
+	answers  []answer
+	started  time.Time
+	now      func() time.Time
+}
+
+type answer struct {
+	card  Card
+	grade Grade
+}
+
+func NewSession(cards []Card) *Session {
+	s := &Session{queue: append([]Card(nil), cards...), repeat: make([]bool, len(cards)), now: time.Now}
+	s.started = s.now()
+	return s
+}
+
+// Current returns the card being shown, or false once the session is done.
+func (s *Session) Current() (Card, bool) {
+	if s.pos >= len(s.queue) {
+		return Card{}, false
+	}
+	return s.queue[s.pos], true
+}
+
+// Revealed reports whether the back of the current card is shown.
+func (s *Session) Revealed() bool {
+	return s.revealed
+}
+
+// Reveal shows the back of the current card.
+func (s *Session) Reveal() {
+	if s.pos < len(s.queue) {
+		s.revealed = true
+	}
+}
+
+// Answer grades the current card and moves to the next one. Only a revealed
+// card can be graded.
+func (s *Session) Answer(g Grade) bool {
+	card, ok := s.Current()
+	if !ok || !s.revealed || g < Again || g > Easy {
+		return false
+	}
+
+	s.answers = append(s.answers, answer{card: card, grade: g})
+	if g == Again {
+		s.queue = append(s.queue, card)
+		s.repeat = append(s.repeat, true)
+	}
+	s.pos++
+	s.revealed = false
+	return true
+}
+
+// Undo takes back the last answer and shows its card again, revealed, so that
+// it can be graded anew.
+func (s *Session) Undo() bool {
+	if len(s.answers) == 0 {
+		return false
+	}
+
+	last := s.answers[len(s.answers)-1]
+	s.answers = s.answers[:len(s.answers)-1]
+	if last.grade == Again {
+		s.queue = s.queue[:len(s.queue)-1]
+		s.repeat = s.repeat[:len(s.repeat)-1]
+	}
+	s.pos--
+	s.revealed = true
+	return true
+}
+
+// Remaining returns how many cards are left to show, and how many of those
+// are repeats of cards graded Again.
+func (s *Session) Remaining() (left, again int) {
+	for i := s.pos; i < len(s.queue); i++ {
+		left++
+		if s.repeat[i] {
+			again++
+		}
+	}
+	return left, again
+}
+
+// Stats summarizes a session.
+type Stats struct {
+	// Reviews counts every answer, Cards the distinct cards answered.
+	Reviews  int
+	Cards    int
+	Grades   map[Grade]int
+	Left     int
+	Duration time.Duration
+}
+
+func (s *Session) Stats() Stats {
+	stats := Stats{Reviews: len(s.answers), Grades: map[Grade]int{}, Duration: s.now().Sub(s.started)}
+	seen := map[int64]bool{}
+	for _, a := range s.answers {
+		stats.Grades[a.grade]++
+		if !seen[a.card.ID] {
+			seen[a.card.ID] = true
+			stats.Cards++
+		}
+	}
+	stats.Left, _ = s.Remaining()
+	return stats
+}
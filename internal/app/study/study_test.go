@@ -0,0 +1,110 @@
+//go:build unit
+// +build unit
+
+package study
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var cards = []Card{{ID: 1, Front: "one", Back: "uno"}, {ID: 2, Front: "two", Back: "dos"}}
+
+func TestSession(t *testing.T) {
+	s := NewSession(cards)
+
+	card, ok := s.Current()
+	require.True(t, ok)
+	assert.Equal(t, int64(1), card.ID)
+	assert.False(t, s.Answer(Good), "a hidden card cannot be graded")
+
+	s.Reveal()
+	require.True(t, s.Answer(Again))
+	left, again := s.Remaining()
+	assert.Equal(t, 2, left)
+	assert.Equal(t, 1, again)
+
+	// Undoing an Again drops the repeat and shows the card revealed.
+	require.True(t, s.Undo())
+	card, _ = s.Current()
+	assert.Equal(t, int64(1), card.ID)
+	assert.True(t, s.Revealed())
+	left, again = s.Remaining()
+	assert.Equal(t, 2, left)
+	assert.Equal(t, 0, again)
+
+	require.True(t, s.Answer(Again))
+	s.Reveal()
+	require.True(t, s.Answer(Easy))
+	card, _ = s.Current()
+	assert.Equal(t, int64(1), card.ID, "the card graded Again comes back")
+	s.Reveal()
+	require.True(t, s.Answer(Good))
+
+	_, ok = s.Current()
+	assert.False(t, ok)
+	stats := s.Stats()
+	assert.Equal(t, 3, stats.Reviews)
+	assert.Equal(t, 2, stats.Cards)
+	assert.Equal(t, 0, stats.Left)
+	assert.Equal(t, map[Grade]int{Again: 1, Good: 1, Easy: 1}, stats.Grades)
+}
+
+func TestShuffle(t *testing.T) {
+	shuffled := Shuffle(cards, rand.New(rand.NewSource(1)))
+	assert.ElementsMatch(t, cards, shuffled)
+	assert.Equal(t, int64(1), cards[0].ID, "the input is left as is")
+}
+
+func TestRun(t *testing.T) {
+	s := NewSession(cards)
+	now := s.started
+	s.now = func() time.Time { return now.Add(90 * time.Second) }
+
+	var out bytes.Buffer
+	// Reveal and fail the first card, take it back and pass it, pass the
+	// second one, then leave from the completion screen.
+	require.NoError(t, Run(s, "Spanish", strings.NewReader(" 1u3\r4x"), &out, 40))
+
+	screen := out.String()
+	assert.Contains(t, screen, "Spanish")
+	assert.Contains(t, screen, "Left: 2 (again: 1)")
+	assert.Contains(t, screen, "uno")
+	assert.Contains(t, screen, "All cards reviewed.")
+
+	stats := s.Stats()
+	assert.Equal(t, 2, stats.Reviews)
+	assert.Equal(t, map[Grade]int{Good: 1, Easy: 1}, stats.Grades)
+
+	var summary bytes.Buffer
+	PrintStats(&summary, stats)
+	assert.Contains(t, summary.String(), "Reviewed 2 cards (2 answers) in 1m30s.")
+}
+
+func TestRunQuit(t *testing.T) {
+	s := NewSession(cards)
+	require.NoError(t, Run(s, "Spanish", strings.NewReader(" q3"), &bytes.Buffer{}, 40))
+	assert.Equal(t, 0, s.Stats().Reviews)
+	assert.Equal(t, 2, s.Stats().Left)
+}
+
+func TestRunIgnoresEscapeSequences(t *testing.T) {
+	s := NewSession(cards)
+	// The arrow keys in both their CSI and SS3 forms, a CSI with parameters,
+	// and a bare Escape followed by a key that still counts.
+	require.NoError(t, Run(s, "Spanish", strings.NewReader("\x1b[A\x1bOB\x1b[1;5C\x1b 3q"), &bytes.Buffer{}, 40))
+	assert.Equal(t, 1, s.Stats().Reviews)
+	assert.Equal(t, map[Grade]int{Good: 1}, s.Stats().Grades)
+}
+
+func TestWriteWrapped(t *testing.T) {
+	var b strings.Builder
+	writeWrapped(&b, "the quick brown fox\njumps", 10)
+	assert.Equal(t, "the quick\r\nbrown fox\r\njumps\r\n", b.String())
+}
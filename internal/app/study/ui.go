@@ -0,0 +1,181 @@
+package study
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const (
+	clearScreen     = "\x1b[2J\x1b[H"
+	enterAltScreen  = "\x1b[?1049h\x1b[?25l"
+	leaveAltScreen  = "\x1b[?25h\x1b[?1049l"
+	defaultWidth    = 80
+	ctrlC, ctrlD    = 3, 4
+	escape          = 27
+	revealHint      = "[space] show answer   [u] undo   [q] quit"
+	gradeHint       = "[1] again   [2] hard   [3] good   [4] easy   [u] undo   [q] quit"
+	doneHint        = "[u] undo   any other key to finish"
+	lineBreak       = "\r\n"
+	separatorMaxLen = 60
+)
+
+// RunTerminal runs Run full screen on a terminal, in raw mode so that every
+// key counts without Enter. When in is not a terminal, e.g. a pipe, the keys
+// are read as they come.
+func RunTerminal(s *Session, title string, in, out *os.File) error {
+	width := defaultWidth
+	if fd := int(in.Fd()); term.IsTerminal(fd) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("failed to set up the terminal: %w", err)
+		}
+		defer term.Restore(fd, state)
+	}
+	if w, _, err := term.GetSize(int(out.Fd())); err == nil && w > 0 {
+		width = w
+	}
+
+	fmt.Fprint(out, enterAltScreen)
+	defer fmt.Fprint(out, leaveAltScreen)
+	return Run(s, title, in, out, width)
+}
+
+// Run draws the session on out and applies the keys read from in until the
+// session is done or the user quits: space or Enter reveals the back, 1 to 4
+// grade the card, u undoes the last answer and q or Ctrl-C quit. Escape
+// sequences, such as those of the arrow keys, are ignored.
+func Run(s *Session, title string, in io.Reader, out io.Writer, width int) error {
+	keys := bufio.NewReader(in)
+	for {
+		if err := draw(out, s, title, width); err != nil {
+			return err
+		}
+
+		key, err := keys.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		_, active := s.Current()
+		switch {
+		case key == 'q' || key == ctrlC || key == ctrlD:
+			return nil
+		case key == escape:
+			err := skipEscapeSequence(keys)
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		case key == 'u':
+			s.Undo()
+		case !active:
+			return nil
+		case key == ' ' || key == '\r' || key == '\n':
+			s.Reveal()
+		case key >= '1' && key <= '4':
+			s.Answer(Grade(key - '0'))
+		}
+	}
+}
+
+// skipEscapeSequence reads the rest of an escape sequence, e.g. the CSI
+// "ESC [ A" or the SS3 "ESC O A" of the up arrow, so that its bytes are not
+// taken for keys. After a bare Escape the next key is left to be read.
+func skipEscapeSequence(keys *bufio.Reader) error {
+	key, err := keys.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch key {
+	case '[':
+		// Parameters and intermediates run up to a final byte in 0x40-0x7e.
+		for {
+			key, err := keys.ReadByte()
+			if err != nil {
+				return err
+			}
+			if key >= 0x40 && key <= 0x7e {
+				return nil
+			}
+		}
+	case 'O':
+		_, err := keys.ReadByte()
+		return err
+	default:
+		return keys.UnreadByte()
+	}
+}
+
+func draw(out io.Writer, s *Session, title string, width int) error {
+	var b strings.Builder
+	b.WriteString(clearScreen)
+
+	left, again := s.Remaining()
+	stats := s.Stats()
+	b.WriteString(title + lineBreak)
+	fmt.Fprintf(&b, "Left: %d (again: %d)   Reviewed: %d%s", left, again, stats.Reviews, lineBreak)
+	separator := strings.Repeat("─", min(width, separatorMaxLen))
+	b.WriteString(separator + lineBreak + lineBreak)
+
+	card, ok := s.Current()
+	switch {
+	case !ok:
+		b.WriteString("All cards reviewed." + lineBreak + lineBreak)
+		b.WriteString(doneHint + lineBreak)
+	case !s.Revealed():
+		writeWrapped(&b, card.Front, width)
+		b.WriteString(lineBreak + revealHint + lineBreak)
+	default:
+		writeWrapped(&b, card.Front, width)
+		b.WriteString(lineBreak + separator + lineBreak + lineBreak)
+		writeWrapped(&b, card.Back, width)
+		b.WriteString(lineBreak + gradeHint + lineBreak)
+	}
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// writeWrapped writes text broken into lines of at most width characters,
+// keeping the line breaks it already has.
+func writeWrapped(b *strings.Builder, text string, width int) {
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		n := 0
+		for i, word := range strings.Fields(line) {
+			length := len([]rune(word))
+			if i > 0 && n+1+length > width {
+				b.WriteString(lineBreak)
+				n = 0
+			} else if i > 0 {
+				b.WriteByte(' ')
+				n++
+			}
+			b.WriteString(word)
+			n += length
+		}
+		b.WriteString(lineBreak)
+	}
+}
+
+// PrintStats writes the summary of a session.
+func PrintStats(out io.Writer, stats Stats) {
+	fmt.Fprintf(out, "Reviewed %d cards (%d answers) in %s.\n", stats.Cards, stats.Reviews, stats.Duration.Round(1e9))
+	for g := Again; g <= Easy; g++ {
+		fmt.Fprintf(out, "  %-6s %d\n", g.String()+":", stats.Grades[g])
+	}
+	if stats.Left > 0 {
+		fmt.Fprintf(out, "%d cards left.\n", stats.Left)
+	}
+}
@@ -0,0 +1,183 @@
+// Package tlsconfig builds the TLS settings of the server, the gateway and the
+// client from the configuration.
+package tlsconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flash-card-manager/internal/config"
+	"flash-card-manager/pkg/logger"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// KeyPair is a certificate and its key, loaded from PEM files and reloaded
+// when either file changes.
+type KeyPair struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// LoadKeyPair loads certFile and keyFile. Run checks them for changes every
+// interval.
+func LoadKeyPair(certFile, keyFile string, interval time.Duration) (*KeyPair, error) {
+	p := &KeyPair{certFile: certFile, keyFile: keyFile, interval: interval}
+	if _, err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Reload loads the files again if their contents changed and reports whether
+// it did. On error the previous certificate stays in use.
+func (p *KeyPair) Reload() (bool, error) {
+	certPEM, err := os.ReadFile(p.certFile)
+	if err != nil {
+		return false, err
+	}
+	keyPEM, err := os.ReadFile(p.keyFile)
+	if err != nil {
+		return false, err
+	}
+
+	p.mu.RLock()
+	unchanged := bytes.Equal(certPEM, p.certPEM) && bytes.Equal(keyPEM, p.keyPEM)
+	p.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return false, fmt.Errorf("failed to load %s: %w", p.certFile, err)
+	}
+
+	p.mu.Lock()
+	p.cert, p.certPEM, p.keyPEM = &cert, certPEM, keyPEM
+	p.mu.Unlock()
+	return true, nil
+}
+
+// Run reloads the certificate every interval until ctx is done. A certificate
+// that fails to load is logged and the previous one is kept, so that a
+// half-written renewal does not take the server down.
+func (p *KeyPair) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		reloaded, err := p.Reload()
+		switch {
+		case err != nil:
+			logger.Errorf(ctx, "Failed to reload TLS certificate: %v", err)
+		case reloaded:
+			logger.Infof(ctx, "Reloaded TLS certificate %s", p.certFile)
+		}
+	}
+}
+
+// Certificate returns the certificate loaded last.
+func (p *KeyPair) Certificate() *tls.Certificate {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.cert
+}
+
+// Server returns the TLS configuration of the gRPC server and the gateway,
+// and the key pair it serves, which the caller keeps up to date with Run.
+// With cfg.ClientCAFile clients must present a certificate signed by one of
+// its CAs.
+func Server(cfg config.TLS) (*tls.Config, *KeyPair, error) {
+	pair, err := LoadKeyPair(cfg.CertFile, cfg.KeyFile, cfg.ReloadInterval)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return pair.Certificate(), nil
+		},
+	}
+	if cfg.ClientCAFile != "" {
+		pool, err := loadCertPool(cfg.ClientCAFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, pair, nil
+}
+
+// Client returns the TLS configuration for dialing cfg.Target. The client
+// certificate, if any, is checked for changes on every handshake, so that a
+// long-running gateway picks up a renewed one.
+func Client(cfg config.GRPC) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: cfg.ServerName}
+	if cfg.CAFile != "" {
+		pool, err := loadCertPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.ClientCertFile != "" {
+		pair, err := LoadKeyPair(cfg.ClientCertFile, cfg.ClientKeyFile, 0)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if _, err := pair.Reload(); err != nil {
+				logger.Errorf(context.Background(), "Failed to reload TLS client certificate: %v", err)
+			}
+			return pair.Certificate(), nil
+		}
+	}
+	return tlsConfig, nil
+}
+
+// DialCredentials returns the transport credentials for dialing cfg.Target:
+// TLS when cfg.TLS is set, plaintext otherwise.
+func DialCredentials(cfg config.GRPC) (credentials.TransportCredentials, error) {
+	if !cfg.TLS {
+		return insecure.NewCredentials(), nil
+	}
+	tlsConfig, err := Client(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("%s: no certificates found", file)
+	}
+	return pool, nil
+}
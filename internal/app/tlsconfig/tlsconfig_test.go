@@ -0,0 +1,208 @@
+//go:build unit
+// +build unit
+
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"flash-card-manager/internal/config"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// authority is a CA made up for a test, which issues certificates into the
+// test's temporary directory.
+type authority struct {
+	t    *testing.T
+	dir  string
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newAuthority(t *testing.T) *authority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	a := &authority{t: t, dir: t.TempDir(), cert: cert, key: key}
+	a.file = a.write("ca.pem", "CERTIFICATE", der)
+	return a
+}
+
+// issue writes a certificate for localhost with the given serial number and
+// its key as name.pem and name-key.pem.
+func (a *authority) issue(name string, serial int64, usage x509.ExtKeyUsage) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(a.t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.cert, &key.PublicKey, a.key)
+	require.NoError(a.t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(a.t, err)
+
+	return a.write(name+".pem", "CERTIFICATE", der), a.write(name+"-key.pem", "EC PRIVATE KEY", keyDER)
+}
+
+func (a *authority) write(name, blockType string, der []byte) string {
+	file := filepath.Join(a.dir, name)
+	require.NoError(a.t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return file
+}
+
+// serve starts a gRPC server with only the health service, over TLS.
+func serve(t *testing.T, cfg config.TLS) (string, *KeyPair) {
+	tlsConfig, certificate, err := Server(cfg)
+	require.NoError(t, err)
+
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String(), certificate
+}
+
+func check(t *testing.T, addr string, cfg config.GRPC) error {
+	creds, err := DialCredentials(cfg)
+	require.NoError(t, err)
+
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestTLS(t *testing.T) {
+	ca := newAuthority(t)
+	certFile, keyFile := ca.issue("server", 2, x509.ExtKeyUsageServerAuth)
+	addr, _ := serve(t, config.TLS{CertFile: certFile, KeyFile: keyFile, ReloadInterval: time.Minute})
+
+	assert.NoError(t, check(t, addr, config.GRPC{TLS: true, CAFile: ca.file, ServerName: "localhost"}))
+
+	// The server's CA is not among the system roots.
+	assert.Error(t, check(t, addr, config.GRPC{TLS: true, ServerName: "localhost"}))
+	assert.Error(t, check(t, addr, config.GRPC{}))
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newAuthority(t)
+	certFile, keyFile := ca.issue("server", 2, x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue("client", 3, x509.ExtKeyUsageClientAuth)
+	addr, _ := serve(t, config.TLS{CertFile: certFile, KeyFile: keyFile, ClientCAFile: ca.file, ReloadInterval: time.Minute})
+
+	assert.NoError(t, check(t, addr, config.GRPC{
+		TLS: true, CAFile: ca.file, ServerName: "localhost", ClientCertFile: clientCert, ClientKeyFile: clientKey,
+	}))
+
+	assert.Error(t, check(t, addr, config.GRPC{TLS: true, CAFile: ca.file, ServerName: "localhost"}))
+
+	// A certificate from another CA is rejected too.
+	other := newAuthority(t)
+	otherCert, otherKey := other.issue("client", 3, x509.ExtKeyUsageClientAuth)
+	assert.Error(t, check(t, addr, config.GRPC{
+		TLS: true, CAFile: ca.file, ServerName: "localhost", ClientCertFile: otherCert, ClientKeyFile: otherKey,
+	}))
+}
+
+func TestReload(t *testing.T) {
+	ca := newAuthority(t)
+	certFile, keyFile := ca.issue("server", 2, x509.ExtKeyUsageServerAuth)
+	addr, certificate := serve(t, config.TLS{CertFile: certFile, KeyFile: keyFile, ReloadInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = certificate.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	served := func() int64 {
+		conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: "localhost", RootCAs: pool(ca.cert), NextProtos: []string{"h2"}})
+		require.NoError(t, err)
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+	}
+	assert.Equal(t, int64(2), served())
+
+	ca.issue("server", 4, x509.ExtKeyUsageServerAuth)
+	assert.Eventually(t, func() bool { return served() == 4 }, 5*time.Second, 10*time.Millisecond)
+
+	// A broken file is not picked up, and the server keeps going.
+	require.NoError(t, os.WriteFile(certFile, []byte("not a certificate"), 0o600))
+	reloaded, err := certificate.Reload()
+	assert.Error(t, err)
+	assert.False(t, reloaded)
+	assert.Equal(t, int64(4), served())
+}
+
+func TestServerInvalid(t *testing.T) {
+	ca := newAuthority(t)
+	certFile, keyFile := ca.issue("server", 2, x509.ExtKeyUsageServerAuth)
+
+	_, _, err := Server(config.TLS{CertFile: certFile, KeyFile: filepath.Join(ca.dir, "missing.pem")})
+	assert.Error(t, err)
+
+	_, _, err = Server(config.TLS{CertFile: certFile, KeyFile: keyFile, ClientCAFile: keyFile})
+	assert.ErrorContains(t, err, "no certificates found")
+}
+
+func pool(certs ...*x509.Certificate) *x509.CertPool {
+	p := x509.NewCertPool()
+	for _, cert := range certs {
+		p.AddCert(cert)
+	}
+	return p
+}
@@ -0,0 +1,58 @@
+// Package tracing sets up OpenTelemetry for the binaries in cmd/.
+package tracing
+
+import (
+	"context"
+	"flash-card-manager/internal/config"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// Init installs the global tracer provider, which exports spans over OTLP,
+// and the W3C trace context propagator. The returned function flushes the
+// spans that are still buffered and stops the exporter.
+func Init(ctx context.Context, service string, cfg config.Tracing) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if cfg.Disabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(service)))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(Sampler(cfg)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Sampler records SampleRatio of the traces. With ParentBased, spans whose
+// caller already decided follow that decision instead.
+func Sampler(cfg config.Tracing) sdktrace.Sampler {
+	sampler := sdktrace.TraceIDRatioBased(cfg.SampleRatio)
+	if cfg.ParentBased {
+		return sdktrace.ParentBased(sampler)
+	}
+	return sampler
+}
@@ -0,0 +1,219 @@
+package validation
+
+import (
+	"flash-card-manager/internal/app/grpc"
+	"flash-card-manager/pkg/render"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Limits on request fields.
+const (
+	MaxCardSideLength    = 10000
+	MaxTitleLength       = 200
+	MaxDescriptionLength = 2000
+	MaxAuthorLength      = 100
+	// MaxBatchSize caps the number of items in one batch request.
+	MaxBatchSize = 1000
+	// MaxPageSize caps the number of items in one page of a list.
+	MaxPageSize = 100
+)
+
+// Rules returns a registry with the rules for every request of the card,
+// deck and trash services.
+func Rules() *Registry {
+	r := NewRegistry()
+
+	Register(r, createCard)
+	Register(r, func(req *grpc.GetCardByIdRequest, v *Violations) {
+		v.ID("id", req.Id)
+	})
+	Register(r, updateCard)
+	Register(r, func(req *grpc.DeleteCardRequest, v *Violations) {
+		v.ID("id", req.Id)
+	})
+	Register(r, func(req *grpc.ListCardRevisionsRequest, v *Violations) {
+		v.ID("card_id", req.CardId)
+		page(req.PageSize, req.PageToken, v)
+	})
+	Register(r, func(req *grpc.RevertCardRequest, v *Violations) {
+		v.ID("card_id", req.CardId)
+		v.ID("revision_id", req.RevisionId)
+		v.MaxLen("editor", req.Editor, MaxAuthorLength)
+	})
+	Register(r, func(req *grpc.BatchCreateCardsRequest, v *Violations) {
+		v.Size("cards", len(req.Cards), MaxBatchSize)
+		for i, item := range req.Cards {
+			createCard(item, v.In(indexed("cards", i)))
+		}
+	})
+	Register(r, func(req *grpc.BatchUpdateCardsRequest, v *Violations) {
+		v.Size("cards", len(req.Cards), MaxBatchSize)
+		v.MaxLen("editor", req.Editor, MaxAuthorLength)
+		for i, item := range req.Cards {
+			if len(item.GetUpdateMask().GetPaths()) > 0 {
+				v.In(indexed("cards", i)).Add("update_mask", "is not supported in batches")
+				continue
+			}
+			updateCard(item, v.In(indexed("cards", i)))
+		}
+	})
+	Register(r, func(req *grpc.BatchDeleteCardsRequest, v *Violations) {
+		cardIDs(req.CardIds, v)
+	})
+	Register(r, func(req *grpc.MoveCardsRequest, v *Violations) {
+		cardIDs(req.CardIds, v)
+		v.ID("deck_id", req.DeckId)
+		v.MaxLen("editor", req.Editor, MaxAuthorLength)
+	})
+
+	Register(r, func(req *grpc.CreateDeckRequest, v *Violations) {
+		v.Text("title", req.Title, MaxTitleLength)
+		v.Text("description", req.Description, MaxDescriptionLength)
+		v.Text("author", req.Author, MaxAuthorLength)
+	})
+	Register(r, func(req *grpc.GetDeckByIdRequest, v *Violations) {
+		v.ID("id", req.Id)
+	})
+	Register(r, updateDeck)
+	Register(r, func(req *grpc.DeleteDeckRequest, v *Violations) {
+		v.ID("id", req.Id)
+	})
+	Register(r, func(req *grpc.ListDeckRevisionsRequest, v *Violations) {
+		v.ID("deck_id", req.DeckId)
+	})
+	Register(r, func(req *grpc.RevertDeckRequest, v *Violations) {
+		v.ID("deck_id", req.DeckId)
+		v.ID("revision_id", req.RevisionId)
+		v.MaxLen("editor", req.Editor, MaxAuthorLength)
+	})
+
+	Register(r, func(req *grpc.ListTrashRequest, v *Violations) {})
+	Register(r, func(req *grpc.RestoreDeckRequest, v *Violations) {
+		v.ID("id", req.Id)
+	})
+	Register(r, func(req *grpc.RestoreCardRequest, v *Violations) {
+		v.ID("id", req.Id)
+	})
+	Register(r, func(req *grpc.PurgeTrashRequest, v *Violations) {
+		if req.DeletedBefore == "" {
+			return
+		}
+		if _, err := time.Parse(time.RFC3339, req.DeletedBefore); err != nil {
+			v.Add("deleted_before", "must be an RFC 3339 timestamp")
+		}
+	})
+
+	Register(r, func(req *grpc.GetUsageRequest, v *Violations) {
+		if req.DeckId != 0 {
+			v.ID("deck_id", req.DeckId)
+		}
+	})
+
+	return r
+}
+
+func createCard(req *grpc.CreateCardRequest, v *Violations) {
+	v.Text("front", req.Front, MaxCardSideLength)
+	v.Text("back", req.Back, MaxCardSideLength)
+	v.ID("deck_id", req.DeckId)
+	v.Text("author", req.Author, MaxAuthorLength)
+	format(req.Format, v)
+}
+
+// updateCard checks every field of a full update, or only the masked ones
+// of a partial update.
+func updateCard(req *grpc.UpdateCardRequest, v *Violations) {
+	v.ID("id", req.Id)
+	v.NonNegative("expected_version", req.ExpectedVersion)
+	v.MaxLen("editor", req.Editor, MaxAuthorLength)
+
+	for _, field := range maskedFields(req.UpdateMask, v, "front", "back", "deck_id", "author", "format") {
+		switch field {
+		case "front":
+			v.Text("front", req.Front, MaxCardSideLength)
+		case "back":
+			v.Text("back", req.Back, MaxCardSideLength)
+		case "deck_id":
+			v.ID("deck_id", req.DeckId)
+		case "author":
+			v.Text("author", req.Author, MaxAuthorLength)
+		case "format":
+			format(req.Format, v)
+		}
+	}
+}
+
+func updateDeck(req *grpc.UpdateDeckRequest, v *Violations) {
+	v.ID("id", req.Id)
+	v.NonNegative("expected_version", req.ExpectedVersion)
+	v.MaxLen("editor", req.Editor, MaxAuthorLength)
+
+	for _, field := range maskedFields(req.UpdateMask, v, "title", "description", "author") {
+		switch field {
+		case "title":
+			v.Text("title", req.Title, MaxTitleLength)
+		case "description":
+			v.Text("description", req.Description, MaxDescriptionLength)
+		case "author":
+			v.Text("author", req.Author, MaxAuthorLength)
+		}
+	}
+}
+
+// maskedFields returns the fields an update changes: those in the mask, or
+// all of the updatable ones when there is no mask. Paths that cannot be
+// updated are reported as violations of update_mask.
+func maskedFields(mask *fieldmaskpb.FieldMask, v *Violations, updatable ...string) []string {
+	if len(mask.GetPaths()) == 0 {
+		return updatable
+	}
+
+	allowed := make(map[string]bool, len(updatable))
+	for _, field := range updatable {
+		allowed[field] = true
+	}
+
+	fields := make([]string, 0, len(mask.Paths))
+	for _, path := range mask.Paths {
+		if !allowed[path] {
+			v.Addf("update_mask", "field %q cannot be updated", path)
+			continue
+		}
+		fields = append(fields, path)
+	}
+	return fields
+}
+
+func format(value string, v *Violations) {
+	if _, err := render.ParseFormat(value); err != nil {
+		v.Add("format", err.Error())
+	}
+}
+
+// page checks the paging fields of a list request. Page tokens are the ID of
+// the last item of the previous page.
+func page(size int32, token string, v *Violations) {
+	if size < 0 || size > MaxPageSize {
+		v.Addf("page_size", "must be between 0 and %d", MaxPageSize)
+	}
+	if token == "" {
+		return
+	}
+	if id, err := strconv.ParseInt(token, 10, 64); err != nil || id <= 0 {
+		v.Add("page_token", "is not a page token from this list")
+	}
+}
+
+func cardIDs(ids []int64, v *Violations) {
+	v.Size("card_ids", len(ids), MaxBatchSize)
+	for i, id := range ids {
+		v.ID(indexed("card_ids", i), id)
+	}
+}
+
+func indexed(field string, i int) string {
+	return field + "[" + strconv.Itoa(i) + "]"
+}
@@ -0,0 +1,193 @@
+//go:build unit
+// +build unit
+
+package validation
+
+import (
+	"errors"
+	"flash-card-manager/internal/app/grpc"
+	"flash-card-manager/pkg/errs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestRulesCoverEveryRequest(t *testing.T) {
+	rules := Rules()
+	for _, file := range []protoreflect.FileDescriptor{grpc.File_card_proto, grpc.File_deck_proto, grpc.File_trash_proto, grpc.File_usage_proto} {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				input := methods.Get(j).Input().FullName()
+				assert.True(t, rules.Has(input), "no rule for %s", input)
+			}
+		}
+	}
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      proto.Message
+		wantFields []string
+	}{
+		{
+			name:  "Valid Card",
+			input: &grpc.CreateCardRequest{Front: "F", Back: "B", DeckId: 1, Author: "Author", Format: "markdown"},
+		},
+		{
+			name:       "Empty Card",
+			input:      &grpc.CreateCardRequest{},
+			wantFields: []string{"front", "back", "deck_id", "author"},
+		},
+		{
+			name:       "Unknown Format",
+			input:      &grpc.CreateCardRequest{Front: "F", Back: "B", DeckId: 1, Author: "Author", Format: "rtf"},
+			wantFields: []string{"format"},
+		},
+		{
+			name:       "Front Too Long",
+			input:      &grpc.CreateCardRequest{Front: strings.Repeat("я", MaxCardSideLength+1), Back: "B", DeckId: 1, Author: "Author"},
+			wantFields: []string{"front"},
+		},
+		{
+			name:       "Invalid Card ID",
+			input:      &grpc.GetCardByIdRequest{Id: -1},
+			wantFields: []string{"id"},
+		},
+		{
+			name:       "Full Update Checks Every Field",
+			input:      &grpc.UpdateCardRequest{Id: 1, Front: "F"},
+			wantFields: []string{"back", "deck_id", "author"},
+		},
+		{
+			name:  "Patch Checks Only Masked Fields",
+			input: &grpc.UpdateCardRequest{Id: 1, DeckId: 2, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"deck_id"}}},
+		},
+		{
+			name:       "Empty Masked Field",
+			input:      &grpc.UpdateCardRequest{Id: 1, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"front"}}},
+			wantFields: []string{"front"},
+		},
+		{
+			name:       "Unknown Masked Field",
+			input:      &grpc.UpdateCardRequest{Id: 1, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"created_at"}}},
+			wantFields: []string{"update_mask"},
+		},
+		{
+			name:       "Negative Expected Version",
+			input:      &grpc.UpdateDeckRequest{Id: 1, Title: "T", Description: "D", Author: "A", ExpectedVersion: -1},
+			wantFields: []string{"expected_version"},
+		},
+		{
+			name:       "Invalid Revision ID",
+			input:      &grpc.RevertCardRequest{CardId: 1},
+			wantFields: []string{"revision_id"},
+		},
+		{
+			name:  "Revision Page",
+			input: &grpc.ListCardRevisionsRequest{CardId: 1, PageSize: MaxPageSize, PageToken: "12"},
+		},
+		{
+			name:       "Invalid Revision Page",
+			input:      &grpc.ListCardRevisionsRequest{CardId: 1, PageSize: MaxPageSize + 1, PageToken: "abc"},
+			wantFields: []string{"page_size", "page_token"},
+		},
+		{
+			name:       "Empty Deck",
+			input:      &grpc.CreateDeckRequest{},
+			wantFields: []string{"title", "description", "author"},
+		},
+		{
+			name:       "Title Too Long",
+			input:      &grpc.CreateDeckRequest{Title: strings.Repeat("t", MaxTitleLength+1), Description: "D", Author: "A"},
+			wantFields: []string{"title"},
+		},
+		{
+			name:       "Unknown Deck Mask Field",
+			input:      &grpc.UpdateDeckRequest{Id: 1, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"id"}}},
+			wantFields: []string{"update_mask"},
+		},
+		{
+			name:       "Invalid Deck ID",
+			input:      &grpc.RevertDeckRequest{RevisionId: 4},
+			wantFields: []string{"deck_id"},
+		},
+		{
+			name:       "Empty Batch",
+			input:      &grpc.BatchCreateCardsRequest{},
+			wantFields: []string{"cards"},
+		},
+		{
+			name: "Invalid Batch Item",
+			input: &grpc.BatchCreateCardsRequest{Cards: []*grpc.CreateCardRequest{
+				{Front: "F", Back: "B", DeckId: 1, Author: "A"},
+				{Front: "F", DeckId: 1, Author: "A"},
+			}},
+			wantFields: []string{"cards[1].back"},
+		},
+		{
+			name: "Masked Batch Update",
+			input: &grpc.BatchUpdateCardsRequest{Cards: []*grpc.UpdateCardRequest{
+				{Id: 1, Front: "F", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"front"}}},
+			}},
+			wantFields: []string{"cards[0].update_mask"},
+		},
+		{
+			name:       "Invalid Moved Card",
+			input:      &grpc.MoveCardsRequest{CardIds: []int64{1, -2}, DeckId: 5},
+			wantFields: []string{"card_ids[1]"},
+		},
+		{
+			name:       "Move Without Deck",
+			input:      &grpc.MoveCardsRequest{CardIds: []int64{1}},
+			wantFields: []string{"deck_id"},
+		},
+		{
+			name:       "Invalid Trash ID",
+			input:      &grpc.RestoreDeckRequest{Id: -1},
+			wantFields: []string{"id"},
+		},
+		{
+			name:       "Invalid Timestamp",
+			input:      &grpc.PurgeTrashRequest{DeletedBefore: "yesterday"},
+			wantFields: []string{"deleted_before"},
+		},
+		{
+			name:  "Usage Without Deck",
+			input: &grpc.GetUsageRequest{},
+		},
+		{
+			name:       "Invalid Usage Deck",
+			input:      &grpc.GetUsageRequest{DeckId: -1},
+			wantFields: []string{"deck_id"},
+		},
+	}
+
+	rules := Rules()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rules.Validate(tt.input)
+
+			if len(tt.wantFields) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var domainErr *errs.Error
+			if assert.True(t, errors.As(err, &domainErr)) {
+				assert.Equal(t, errs.InvalidArgument, domainErr.Kind)
+				var fields []string
+				for _, v := range domainErr.Violations {
+					fields = append(fields, v.Field)
+				}
+				assert.Equal(t, tt.wantFields, fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Package validation checks incoming requests against rules registered per
+// message type, so that handlers only see well-formed input.
+package validation
+
+import (
+	"flash-card-manager/pkg/errs"
+	"fmt"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Rule checks a request message and records what is wrong with it in v.
+type Rule func(msg proto.Message, v *Violations)
+
+// Registry maps request message types to their rules.
+type Registry struct {
+	rules map[protoreflect.FullName]Rule
+}
+
+func NewRegistry() *Registry {
+	return &Registry{rules: make(map[protoreflect.FullName]Rule)}
+}
+
+// Register sets the rule for messages of type T, replacing any earlier one.
+func Register[T proto.Message](r *Registry, rule func(msg T, v *Violations)) {
+	var zero T
+	r.rules[zero.ProtoReflect().Descriptor().FullName()] = func(msg proto.Message, v *Violations) {
+		rule(msg.(T), v)
+	}
+}
+
+// Has reports whether a rule is registered for messages with the given name.
+func (r *Registry) Has(name protoreflect.FullName) bool {
+	_, ok := r.rules[name]
+	return ok
+}
+
+// Validate runs the rule registered for msg, if any, and returns an
+// errs.InvalidArgument error listing every violation it found.
+func (r *Registry) Validate(msg proto.Message) error {
+	rule, ok := r.rules[msg.ProtoReflect().Descriptor().FullName()]
+	if !ok {
+		return nil
+	}
+
+	v := &Violations{list: new([]errs.FieldViolation)}
+	rule(msg, v)
+	if len(*v.list) == 0 {
+		return nil
+	}
+	return errs.InvalidArgumentError(*v.list...)
+}
+
+// Violations collects the field violations of one request. Fields are named
+// by their proto path, e.g. "cards[2].deck_id".
+type Violations struct {
+	prefix string
+	list   *[]errs.FieldViolation
+}
+
+// Add records that field is invalid for the given reason.
+func (v *Violations) Add(field, description string) {
+	*v.list = append(*v.list, errs.FieldViolation{Field: v.prefix + field, Description: description})
+}
+
+// Addf is Add with a formatted description.
+func (v *Violations) Addf(field, format string, args ...interface{}) {
+	v.Add(field, fmt.Sprintf(format, args...))
+}
+
+// In returns Violations for the message nested in field, such as an item of
+// a batch, that record into the same list.
+func (v *Violations) In(field string) *Violations {
+	return &Violations{prefix: v.prefix + field + ".", list: v.list}
+}
+
+// Required checks that value is not empty.
+func (v *Violations) Required(field, value string) {
+	if value == "" {
+		v.Add(field, "is required")
+	}
+}
+
+// MaxLen checks that value has at most max characters.
+func (v *Violations) MaxLen(field, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		v.Addf(field, "must be at most %d characters", max)
+	}
+}
+
+// Text checks a required string field with a length limit.
+func (v *Violations) Text(field, value string, max int) {
+	if value == "" {
+		v.Add(field, "is required")
+		return
+	}
+	v.MaxLen(field, value, max)
+}
+
+// ID checks that value is a valid, that is positive, identifier.
+func (v *Violations) ID(field string, value int64) {
+	if value <= 0 {
+		v.Add(field, "must be a positive ID")
+	}
+}
+
+// NonNegative checks that value is not below zero.
+func (v *Violations) NonNegative(field string, value int64) {
+	if value < 0 {
+		v.Add(field, "must not be negative")
+	}
+}
+
+// Size checks that a repeated field has between 1 and max items.
+func (v *Violations) Size(field string, n, max int) {
+	switch {
+	case n == 0:
+		v.Add(field, "must not be empty")
+	case n > max:
+		v.Addf(field, "must have at most %d items", max)
+	}
+}
@@ -0,0 +1,387 @@
+// Package config loads the settings shared by the binaries in cmd/. Each value
+// comes from, in increasing order of precedence: the defaults below, a YAML or
+// TOML file, environment variables and command-line flags.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type Config struct {
+	GRPC        GRPC        `yaml:"grpc" toml:"grpc"`
+	Gateway     Gateway     `yaml:"gateway" toml:"gateway"`
+	TLS         TLS         `yaml:"tls" toml:"tls"`
+	Storage     Storage     `yaml:"storage" toml:"storage"`
+	Kafka       Kafka       `yaml:"kafka" toml:"kafka"`
+	Tracing     Tracing     `yaml:"tracing" toml:"tracing"`
+	Trash       Trash       `yaml:"trash" toml:"trash"`
+	Idempotency Idempotency `yaml:"idempotency" toml:"idempotency"`
+	Shutdown    Shutdown    `yaml:"shutdown" toml:"shutdown"`
+	Metrics     Metrics     `yaml:"metrics" toml:"metrics"`
+	Health      Health      `yaml:"health" toml:"health"`
+	Log         Log         `yaml:"log" toml:"log"`
+	RateLimit   RateLimit   `yaml:"rate_limit" toml:"rate_limit"`
+	Quota       Quota       `yaml:"quota" toml:"quota"`
+	Cache       Cache       `yaml:"cache" toml:"cache"`
+}
+
+type GRPC struct {
+	// Listen is the address the server accepts connections on.
+	Listen string `yaml:"listen" toml:"listen"`
+	// Target is the address the gateway and the client dial.
+	Target string `yaml:"target" toml:"target"`
+	// TLS makes the gateway and the client dial Target over TLS.
+	TLS bool `yaml:"tls" toml:"tls"`
+	// CAFile is the PEM bundle the server's certificate is verified against;
+	// empty means the system roots.
+	CAFile string `yaml:"ca_file" toml:"ca_file"`
+	// ClientCertFile and ClientKeyFile are presented to a server that
+	// requires mutual TLS.
+	ClientCertFile string `yaml:"client_cert_file" toml:"client_cert_file"`
+	ClientKeyFile  string `yaml:"client_key_file" toml:"client_key_file"`
+	// ServerName is the name the server's certificate must be valid for, by
+	// default the host of Target.
+	ServerName string `yaml:"server_name" toml:"server_name"`
+	// TrustedProxies are the IPs or CIDRs of proxies, such as a separate
+	// gateway, whose x-forwarded-for and x-forwarded-user the server
+	// believes. The embedded gateway is always trusted.
+	TrustedProxies []string `yaml:"trusted_proxies" toml:"trusted_proxies"`
+}
+
+// TrustedProxyNets parses TrustedProxies; a plain IP is a network of one
+// address.
+func (g GRPC) TrustedProxyNets() ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(g.TrustedProxies))
+	for _, item := range g.TrustedProxies {
+		item = strings.TrimSpace(item)
+		if ip := net.ParseIP(item); ip != nil {
+			bits := 8 * len(ip.To16())
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an IP or a CIDR", item)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+type Gateway struct {
+	// Listen is the address of the separate gateway binary.
+	Listen string `yaml:"listen" toml:"listen"`
+	// Embedded makes the server itself serve the REST gateway on grpc.listen,
+	// next to gRPC.
+	Embedded bool `yaml:"embedded" toml:"embedded"`
+}
+
+type TLS struct {
+	// CertFile and KeyFile turn on TLS for the gRPC server and the gateway.
+	// They are reloaded when they change, so a renewed certificate is picked
+	// up without a restart.
+	CertFile string `yaml:"cert_file" toml:"cert_file"`
+	KeyFile  string `yaml:"key_file" toml:"key_file"`
+	// ClientCAFile makes the server require client certificates signed by a
+	// CA from this PEM bundle (mutual TLS).
+	ClientCAFile string `yaml:"client_ca_file" toml:"client_ca_file"`
+	// ReloadInterval is how often the certificate files are checked for
+	// changes.
+	ReloadInterval time.Duration `yaml:"reload_interval" toml:"reload_interval"`
+}
+
+// Enabled reports whether the server side of TLS is configured.
+func (t TLS) Enabled() bool {
+	return t.CertFile != ""
+}
+
+type Storage struct {
+	// DSN is "memory", a Postgres DSN or "sqlite:///path/to/file.db".
+	DSN string `yaml:"dsn" toml:"dsn"`
+	// Migrate applies pending migrations before the server starts.
+	Migrate bool `yaml:"migrate" toml:"migrate"`
+}
+
+type Kafka struct {
+	Brokers []string `yaml:"brokers" toml:"brokers"`
+}
+
+type Tracing struct {
+	Disabled bool `yaml:"disabled" toml:"disabled"`
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string `yaml:"endpoint" toml:"endpoint"`
+	Insecure bool   `yaml:"insecure" toml:"insecure"`
+	// SampleRatio is the share of new traces that are recorded, from 0 to 1.
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio"`
+	// ParentBased follows the caller's sampling decision when there is one.
+	ParentBased bool `yaml:"parent_based" toml:"parent_based"`
+}
+
+type Trash struct {
+	Retention     time.Duration `yaml:"retention" toml:"retention"`
+	PurgeInterval time.Duration `yaml:"purge_interval" toml:"purge_interval"`
+}
+
+type Idempotency struct {
+	TTL time.Duration `yaml:"ttl" toml:"ttl"`
+	// Lease is how long a key stays claimed while its first request runs.
+	// A request that outlives it, e.g. on a server that crashed, can be
+	// retried then instead of after TTL.
+	Lease time.Duration `yaml:"lease" toml:"lease"`
+}
+
+type Metrics struct {
+	// Listen is the address of the /metrics endpoint; empty turns it off.
+	Listen string `yaml:"listen" toml:"listen"`
+}
+
+type Health struct {
+	// Interval is how often the dependencies are checked for readiness. A
+	// check that takes longer fails.
+	Interval time.Duration `yaml:"interval" toml:"interval"`
+}
+
+type Log struct {
+	// Level is the lowest level written: "debug", "info", "warn" or "error".
+	Level string `yaml:"level" toml:"level"`
+	// Encoding is "json" or "console".
+	Encoding string `yaml:"encoding" toml:"encoding"`
+}
+
+type RateLimit struct {
+	Disabled bool `yaml:"disabled" toml:"disabled"`
+	// Rate is how many requests per second each caller may make to a method
+	// without its own limit, and Burst how many it may make at once.
+	Rate  float64 `yaml:"rate" toml:"rate"`
+	Burst int64   `yaml:"burst" toml:"burst"`
+	// Methods overrides Rate and Burst per method, as "Method=rate:burst"
+	// items, e.g. "CreateCard=5:20". The method is the RPC name, with or
+	// without the service.
+	Methods []string `yaml:"methods" toml:"methods"`
+}
+
+// MethodLimit is a parsed RateLimit.Methods item.
+type MethodLimit struct {
+	Method string
+	Rate   float64
+	Burst  int64
+}
+
+// MethodLimits parses Methods.
+func (r RateLimit) MethodLimits() ([]MethodLimit, error) {
+	limits := make([]MethodLimit, 0, len(r.Methods))
+	for _, item := range r.Methods {
+		method, limit, ok := strings.Cut(item, "=")
+		rate, burst, ok2 := strings.Cut(limit, ":")
+		if !ok || !ok2 || method == "" {
+			return nil, fmt.Errorf("%q is not Method=rate:burst", item)
+		}
+		l := MethodLimit{Method: method}
+		var err error
+		if l.Rate, err = strconv.ParseFloat(rate, 64); err != nil || l.Rate <= 0 {
+			return nil, fmt.Errorf("%q: rate must be a positive number", item)
+		}
+		if l.Burst, err = strconv.ParseInt(burst, 10, 64); err != nil || l.Burst <= 0 {
+			return nil, fmt.Errorf("%q: burst must be a positive integer", item)
+		}
+		limits = append(limits, l)
+	}
+	return limits, nil
+}
+
+// Quota limits what each user may store. Zero means no limit.
+type Quota struct {
+	DecksPerUser int64 `yaml:"decks_per_user" toml:"decks_per_user"`
+	CardsPerDeck int64 `yaml:"cards_per_deck" toml:"cards_per_deck"`
+	// MediaBytes bounds the total size of the front and back of all the
+	// cards in a user's decks.
+	MediaBytes int64 `yaml:"media_bytes" toml:"media_bytes"`
+}
+
+// Cache configures the cache of deck and card reads.
+type Cache struct {
+	Disabled bool `yaml:"disabled" toml:"disabled"`
+	// Size is how many entries the in-process cache holds.
+	Size int64         `yaml:"size" toml:"size"`
+	TTL  time.Duration `yaml:"ttl" toml:"ttl"`
+	// RedisURL, e.g. "redis://:password@localhost:6379/0", moves the cache to
+	// a Redis-compatible server shared by the replicas.
+	RedisURL string `yaml:"redis_url" toml:"redis_url"`
+}
+
+type Shutdown struct {
+	// Timeout bounds the whole shutdown, including draining requests.
+	Timeout time.Duration `yaml:"timeout" toml:"timeout"`
+}
+
+// Default is the configuration before any file, variable or flag is applied.
+func Default() *Config {
+	return &Config{
+		GRPC:        GRPC{Listen: ":9000", Target: "localhost:9000"},
+		Gateway:     Gateway{Listen: ":8080"},
+		TLS:         TLS{ReloadInterval: 30 * time.Second},
+		Kafka:       Kafka{Brokers: []string{"localhost:9092"}},
+		Tracing:     Tracing{Endpoint: "localhost:4317", Insecure: true, SampleRatio: 1, ParentBased: true},
+		Trash:       Trash{Retention: 30 * 24 * time.Hour, PurgeInterval: time.Hour},
+		Idempotency: Idempotency{TTL: 24 * time.Hour, Lease: time.Minute},
+		Shutdown:    Shutdown{Timeout: 15 * time.Second},
+		Metrics:     Metrics{Listen: ":9090"},
+		Health:      Health{Interval: 5 * time.Second},
+		Log:         Log{Level: "info", Encoding: "json"},
+		RateLimit: RateLimit{
+			Rate:    50,
+			Burst:   100,
+			Methods: []string{"CreateCard=5:20", "BatchCreateCards=1:5", "CreateDeck=1:10"},
+		},
+		Quota: Quota{DecksPerUser: 1000, CardsPerDeck: 10000, MediaBytes: 100 << 20},
+		Cache: Cache{Size: 10000, TTL: 5 * time.Minute},
+	}
+}
+
+// Validate reports every invalid setting at once. Settings named in required
+// must also be set.
+func (c *Config) Validate(required ...string) error {
+	var errs []error
+	for _, key := range required {
+		s, ok := lookup(key)
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown setting %s", key))
+			continue
+		}
+		if format(s.value(c)) == "" {
+			errs = append(errs, fmt.Errorf("%s is required (flag -%s, env %s)", key, s.flag, s.env))
+		}
+	}
+
+	addrs := []struct{ key, addr string }{
+		{"grpc.listen", c.GRPC.Listen},
+		{"grpc.target", c.GRPC.Target},
+		{"gateway.listen", c.Gateway.Listen},
+	}
+	if !c.Tracing.Disabled {
+		addrs = append(addrs, struct{ key, addr string }{"tracing.endpoint", c.Tracing.Endpoint})
+	}
+	if c.Metrics.Listen != "" {
+		addrs = append(addrs, struct{ key, addr string }{"metrics.listen", c.Metrics.Listen})
+	}
+	for _, broker := range c.Kafka.Brokers {
+		addrs = append(addrs, struct{ key, addr string }{"kafka.brokers", broker})
+	}
+	for _, a := range addrs {
+		if _, _, err := net.SplitHostPort(a.addr); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", a.key, err))
+		}
+	}
+
+	if _, err := zapcore.ParseLevel(c.Log.Level); err != nil {
+		errs = append(errs, fmt.Errorf("log.level: %w", err))
+	}
+	if c.Log.Encoding != "json" && c.Log.Encoding != "console" {
+		errs = append(errs, fmt.Errorf("log.encoding must be json or console"))
+	}
+
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		errs = append(errs, fmt.Errorf("tls.cert_file and tls.key_file must be set together"))
+	}
+	if c.TLS.ClientCAFile != "" && !c.TLS.Enabled() {
+		errs = append(errs, fmt.Errorf("tls.client_ca_file requires tls.cert_file"))
+	}
+	if (c.GRPC.ClientCertFile == "") != (c.GRPC.ClientKeyFile == "") {
+		errs = append(errs, fmt.Errorf("grpc.client_cert_file and grpc.client_key_file must be set together"))
+	}
+	if !c.GRPC.TLS && (c.GRPC.CAFile != "" || c.GRPC.ClientCertFile != "" || c.GRPC.ServerName != "") {
+		errs = append(errs, fmt.Errorf("grpc.ca_file, grpc.client_cert_file and grpc.server_name require grpc.tls"))
+	}
+
+	if _, err := c.GRPC.TrustedProxyNets(); err != nil {
+		errs = append(errs, fmt.Errorf("grpc.trusted_proxies: %w", err))
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("tracing.sample_ratio must be between 0 and 1"))
+	}
+
+	if c.RateLimit.Rate <= 0 {
+		errs = append(errs, fmt.Errorf("rate_limit.rate must be positive"))
+	}
+	if c.RateLimit.Burst <= 0 {
+		errs = append(errs, fmt.Errorf("rate_limit.burst must be positive"))
+	}
+	if _, err := c.RateLimit.MethodLimits(); err != nil {
+		errs = append(errs, fmt.Errorf("rate_limit.methods: %w", err))
+	}
+	if c.Quota.DecksPerUser < 0 || c.Quota.CardsPerDeck < 0 || c.Quota.MediaBytes < 0 {
+		errs = append(errs, fmt.Errorf("quota limits must not be negative"))
+	}
+
+	if c.Cache.Size <= 0 {
+		errs = append(errs, fmt.Errorf("cache.size must be positive"))
+	}
+	if c.Cache.RedisURL != "" {
+		if u, err := url.Parse(c.Cache.RedisURL); err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("cache.redis_url must be a redis:// or rediss:// URL"))
+		}
+	}
+
+	durations := []struct {
+		key string
+		d   time.Duration
+	}{
+		{"trash.retention", c.Trash.Retention},
+		{"trash.purge_interval", c.Trash.PurgeInterval},
+		{"idempotency.ttl", c.Idempotency.TTL},
+		{"idempotency.lease", c.Idempotency.Lease},
+		{"shutdown.timeout", c.Shutdown.Timeout},
+		{"health.interval", c.Health.Interval},
+		{"tls.reload_interval", c.TLS.ReloadInterval},
+		{"cache.ttl", c.Cache.TTL},
+	}
+	for _, d := range durations {
+		if d.d <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", d.key))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Print writes the effective configuration as "key = value" lines, with
+// passwords replaced by "xxxxx".
+func (c *Config) Print(w io.Writer) error {
+	for _, s := range settings {
+		value := format(s.value(c))
+		if s.secret {
+			value = redactDSN(value)
+		}
+		if _, err := fmt.Fprintf(w, "%s = %s\n", s.key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var dsnPassword = regexp.MustCompile(`(password=)(?:'[^']*'|[^\s&]+)`)
+
+// redactDSN hides the password in both URL and key=value Postgres DSNs.
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "xxxxx")
+			return u.String()
+		}
+	}
+	return dsnPassword.ReplaceAllString(dsn, "${1}xxxxx")
+}
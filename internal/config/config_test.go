@@ -0,0 +1,189 @@
+//go:build unit
+// +build unit
+
+package config
+
+import (
+	"bytes"
+	"flag"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func load(t *testing.T, env map[string]string, args ...string) (*Config, error) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	loader := NewLoader(fs, "storage.dsn")
+	loader.lookup = func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+	require.NoError(t, fs.Parse(args))
+	return loader.Load()
+}
+
+func TestLoad(t *testing.T) {
+	yamlFile := writeFile(t, "config.yaml", `
+grpc:
+  listen: ":9100"
+storage:
+  dsn: memory
+kafka:
+  brokers: [kafka-1:9092, kafka-2:9092]
+trash:
+  retention: 48h
+`)
+	tomlFile := writeFile(t, "config.toml", `
+[grpc]
+listen = ":9100"
+
+[storage]
+dsn = "memory"
+
+[kafka]
+brokers = ["kafka-1:9092", "kafka-2:9092"]
+
+[trash]
+retention = "48h"
+`)
+
+	for _, file := range []string{yamlFile, tomlFile} {
+		t.Run(filepath.Ext(file), func(t *testing.T) {
+			cfg, err := load(t, nil, "-config", file)
+			require.NoError(t, err)
+
+			assert.Equal(t, ":9100", cfg.GRPC.Listen)
+			assert.Equal(t, "memory", cfg.Storage.DSN)
+			assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, cfg.Kafka.Brokers)
+			assert.Equal(t, 48*time.Hour, cfg.Trash.Retention)
+			assert.Equal(t, time.Hour, cfg.Trash.PurgeInterval)
+		})
+	}
+
+	t.Run("Precedence", func(t *testing.T) {
+		env := map[string]string{"CONFIG_FILE": yamlFile, "GRPC_LISTEN": ":9200", "TRASH_RETENTION": "72h"}
+
+		cfg, err := load(t, env, "-trash-retention", "96h", "-migrate")
+		require.NoError(t, err)
+
+		assert.Equal(t, ":9200", cfg.GRPC.Listen)
+		assert.Equal(t, 96*time.Hour, cfg.Trash.Retention)
+		assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, cfg.Kafka.Brokers)
+		assert.True(t, cfg.Storage.Migrate)
+	})
+
+	t.Run("LegacyVariables", func(t *testing.T) {
+		env := map[string]string{"DBHOST": "db", "DBPORT": "5432", "DBUSER": "test", "DBPASSWORD": "test", "DBNAME": "test", "KAFKA_HOST": "kafka", "KAFKA_PORT": "9092"}
+
+		cfg, err := load(t, env)
+		require.NoError(t, err)
+
+		assert.Equal(t, "host=db port=5432 user=test password=test dbname=test sslmode=disable", cfg.Storage.DSN)
+		assert.Equal(t, []string{"kafka:9092"}, cfg.Kafka.Brokers)
+	})
+
+	t.Run("UnknownKey", func(t *testing.T) {
+		_, err := load(t, nil, "-config", writeFile(t, "config.yaml", "grpc:\n  lisen: \":9100\"\n"))
+		assert.ErrorContains(t, err, "lisen")
+
+		_, err = load(t, nil, "-config", writeFile(t, "config.toml", "[grpc]\nlisen = \":9100\"\n"))
+		assert.ErrorContains(t, err, "grpc.lisen")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := load(t, map[string]string{"GATEWAY_LISTEN": "8080", "IDEMPOTENCY_TTL": "-1h", "IDEMPOTENCY_LEASE": "0s", "LOG_LEVEL": "loud"}, "-log-encoding", "xml")
+		assert.ErrorContains(t, err, "storage.dsn is required")
+		assert.ErrorContains(t, err, "gateway.listen")
+		assert.ErrorContains(t, err, "idempotency.ttl must be positive")
+		assert.ErrorContains(t, err, "idempotency.lease must be positive")
+		assert.ErrorContains(t, err, "log.level")
+		assert.ErrorContains(t, err, "log.encoding must be json or console")
+	})
+
+	t.Run("TracingDisabled", func(t *testing.T) {
+		_, err := load(t, map[string]string{"DB_DSN": "memory"}, "-tracing-endpoint", "")
+		assert.ErrorContains(t, err, "tracing.endpoint")
+
+		_, err = load(t, map[string]string{"DB_DSN": "memory", "TRACING_DISABLED": "true"}, "-tracing-endpoint", "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("PrintInvalid", func(t *testing.T) {
+		cfg, err := load(t, map[string]string{"IDEMPOTENCY_TTL": "-1h"}, "-print-config")
+		require.NoError(t, err)
+		assert.Equal(t, -time.Hour, cfg.Idempotency.TTL)
+	})
+
+	t.Run("InvalidTLS", func(t *testing.T) {
+		_, err := load(t, map[string]string{"DB_DSN": "memory", "TLS_CERT_FILE": "server.pem", "GRPC_CA_FILE": "ca.pem"}, "-tls-client-cert", "client.pem")
+		assert.ErrorContains(t, err, "tls.cert_file and tls.key_file must be set together")
+		assert.ErrorContains(t, err, "grpc.client_cert_file and grpc.client_key_file must be set together")
+		assert.ErrorContains(t, err, "require grpc.tls")
+	})
+
+	t.Run("InvalidRateLimit", func(t *testing.T) {
+		_, err := load(t, map[string]string{"DB_DSN": "memory", "RATE_LIMIT_BURST": "0", "QUOTA_MEDIA_BYTES": "-1"}, "-rate-limit-methods", "CreateCard=5:20,CreateDeck=fast:1")
+		assert.ErrorContains(t, err, "rate_limit.burst must be positive")
+		assert.ErrorContains(t, err, `"CreateDeck=fast:1": rate must be a positive number`)
+		assert.ErrorContains(t, err, "quota limits must not be negative")
+
+		cfg, err := load(t, map[string]string{"DB_DSN": "memory"}, "-rate-limit-methods", "grpc.CardService/CreateCard=0.5:2")
+		require.NoError(t, err)
+		limits, err := cfg.RateLimit.MethodLimits()
+		require.NoError(t, err)
+		assert.Equal(t, []MethodLimit{{Method: "grpc.CardService/CreateCard", Rate: 0.5, Burst: 2}}, limits)
+	})
+
+	t.Run("TrustedProxies", func(t *testing.T) {
+		_, err := load(t, map[string]string{"DB_DSN": "memory", "GRPC_TRUSTED_PROXIES": "10.0.0.1,gateway"})
+		assert.ErrorContains(t, err, `grpc.trusted_proxies: "gateway" is not an IP or a CIDR`)
+
+		cfg, err := load(t, map[string]string{"DB_DSN": "memory"}, "-trusted-proxies", "10.0.0.1,192.0.2.0/24")
+		require.NoError(t, err)
+		nets, err := cfg.GRPC.TrustedProxyNets()
+		require.NoError(t, err)
+		require.Len(t, nets, 2)
+		assert.Equal(t, "10.0.0.1/32", nets[0].String())
+		assert.True(t, nets[1].Contains(net.ParseIP("192.0.2.7")))
+	})
+
+	t.Run("InvalidCache", func(t *testing.T) {
+		_, err := load(t, map[string]string{"DB_DSN": "memory", "CACHE_SIZE": "0", "CACHE_REDIS_URL": "localhost:6379"})
+		assert.ErrorContains(t, err, "cache.size must be positive")
+		assert.ErrorContains(t, err, "cache.redis_url must be a redis://")
+	})
+
+	t.Run("BadValue", func(t *testing.T) {
+		_, err := load(t, map[string]string{"DB_DSN": "memory", "TRASH_RETENTION": "a month"})
+		assert.ErrorContains(t, err, "TRASH_RETENTION")
+	})
+}
+
+func TestPrint(t *testing.T) {
+	for dsn, want := range map[string]string{
+		"memory": "memory",
+		"host=db user=test password=secret dbname=test":    "host=db user=test password=xxxxx dbname=test",
+		"postgres://test:secret@db:5432/test":              "postgres://test:xxxxx@db:5432/test",
+		"postgres://db/test?user=test&password=secret&x=1": "postgres://db/test?user=test&password=xxxxx&x=1",
+	} {
+		cfg := Default()
+		cfg.Storage.DSN = dsn
+
+		var out bytes.Buffer
+		require.NoError(t, cfg.Print(&out))
+
+		assert.Contains(t, out.String(), "storage.dsn = "+want+"\n")
+		assert.NotContains(t, out.String(), "secret")
+	}
+}
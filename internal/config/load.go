@@ -0,0 +1,345 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// setting ties a Config field to its key in files and Print, its flag and its
+// environment variable.
+type setting struct {
+	key    string
+	flag   string
+	env    string
+	usage  string
+	secret bool
+	value  func(c *Config) interface{}
+}
+
+var settings = []setting{
+	{key: "grpc.listen", flag: "listen", env: "GRPC_LISTEN", usage: "the address the gRPC server listens on",
+		value: func(c *Config) interface{} { return &c.GRPC.Listen }},
+	{key: "grpc.target", flag: "addr", env: "GRPC_TARGET", usage: "the address to connect to the gRPC server",
+		value: func(c *Config) interface{} { return &c.GRPC.Target }},
+	{key: "grpc.tls", flag: "tls", env: "GRPC_TLS", usage: "dial the gRPC server over TLS",
+		value: func(c *Config) interface{} { return &c.GRPC.TLS }},
+	{key: "grpc.ca_file", flag: "tls-ca", env: "GRPC_CA_FILE", usage: "the PEM bundle of CAs to verify the gRPC server with, the system roots if empty",
+		value: func(c *Config) interface{} { return &c.GRPC.CAFile }},
+	{key: "grpc.client_cert_file", flag: "tls-client-cert", env: "GRPC_CLIENT_CERT_FILE", usage: "the client certificate for servers that require mutual TLS",
+		value: func(c *Config) interface{} { return &c.GRPC.ClientCertFile }},
+	{key: "grpc.client_key_file", flag: "tls-client-key", env: "GRPC_CLIENT_KEY_FILE", usage: "the key of the client certificate",
+		value: func(c *Config) interface{} { return &c.GRPC.ClientKeyFile }},
+	{key: "grpc.server_name", flag: "tls-server-name", env: "GRPC_SERVER_NAME", usage: "the name the server certificate must be valid for, the host of the address by default",
+		value: func(c *Config) interface{} { return &c.GRPC.ServerName }},
+	{key: "grpc.trusted_proxies", flag: "trusted-proxies", env: "GRPC_TRUSTED_PROXIES", usage: "comma-separated IPs or CIDRs of proxies whose x-forwarded-for and x-forwarded-user are believed",
+		value: func(c *Config) interface{} { return &c.GRPC.TrustedProxies }},
+	{key: "gateway.listen", flag: "gateway-listen", env: "GATEWAY_LISTEN", usage: "the address the HTTP gateway listens on",
+		value: func(c *Config) interface{} { return &c.Gateway.Listen }},
+	{key: "gateway.embedded", flag: "gateway-embedded", env: "GATEWAY_EMBEDDED", usage: "serve the HTTP gateway on the gRPC port of the server",
+		value: func(c *Config) interface{} { return &c.Gateway.Embedded }},
+	{key: "tls.cert_file", flag: "tls-cert", env: "TLS_CERT_FILE", usage: "the server certificate, turns on TLS for gRPC and the gateway",
+		value: func(c *Config) interface{} { return &c.TLS.CertFile }},
+	{key: "tls.key_file", flag: "tls-key", env: "TLS_KEY_FILE", usage: "the key of the server certificate",
+		value: func(c *Config) interface{} { return &c.TLS.KeyFile }},
+	{key: "tls.client_ca_file", flag: "tls-client-ca", env: "TLS_CLIENT_CA_FILE", usage: "the PEM bundle of CAs client certificates must be signed by, turns on mutual TLS",
+		value: func(c *Config) interface{} { return &c.TLS.ClientCAFile }},
+	{key: "tls.reload_interval", flag: "tls-reload-interval", env: "TLS_RELOAD_INTERVAL", usage: "how often the certificate files are checked for changes",
+		value: func(c *Config) interface{} { return &c.TLS.ReloadInterval }},
+	{key: "storage.dsn", flag: "storage", env: "DB_DSN", usage: "memory, or the DSN of the database: a Postgres DSN or sqlite:///path/to/file.db", secret: true,
+		value: func(c *Config) interface{} { return &c.Storage.DSN }},
+	{key: "storage.migrate", flag: "migrate", env: "DB_MIGRATE", usage: "apply pending migrations before serving",
+		value: func(c *Config) interface{} { return &c.Storage.Migrate }},
+	{key: "kafka.brokers", flag: "kafka-brokers", env: "KAFKA_BROKERS", usage: "comma-separated Kafka brokers",
+		value: func(c *Config) interface{} { return &c.Kafka.Brokers }},
+	{key: "tracing.disabled", flag: "tracing-disabled", env: "TRACING_DISABLED", usage: "turn tracing off",
+		value: func(c *Config) interface{} { return &c.Tracing.Disabled }},
+	{key: "tracing.endpoint", flag: "tracing-endpoint", env: "TRACING_ENDPOINT", usage: "the host:port of the OTLP gRPC collector",
+		value: func(c *Config) interface{} { return &c.Tracing.Endpoint }},
+	{key: "tracing.insecure", flag: "tracing-insecure", env: "TRACING_INSECURE", usage: "export spans without TLS",
+		value: func(c *Config) interface{} { return &c.Tracing.Insecure }},
+	{key: "tracing.sample_ratio", flag: "tracing-sample-ratio", env: "TRACING_SAMPLE_RATIO", usage: "the share of new traces to record, from 0 to 1",
+		value: func(c *Config) interface{} { return &c.Tracing.SampleRatio }},
+	{key: "tracing.parent_based", flag: "tracing-parent-based", env: "TRACING_PARENT_BASED", usage: "follow the caller's sampling decision",
+		value: func(c *Config) interface{} { return &c.Tracing.ParentBased }},
+	{key: "trash.retention", flag: "trash-retention", env: "TRASH_RETENTION", usage: "how long deleted decks and cards stay in the trash",
+		value: func(c *Config) interface{} { return &c.Trash.Retention }},
+	{key: "trash.purge_interval", flag: "trash-purge-interval", env: "TRASH_PURGE_INTERVAL", usage: "how often the trash is purged",
+		value: func(c *Config) interface{} { return &c.Trash.PurgeInterval }},
+	{key: "idempotency.ttl", flag: "idempotency-ttl", env: "IDEMPOTENCY_TTL", usage: "how long idempotency keys are remembered",
+		value: func(c *Config) interface{} { return &c.Idempotency.TTL }},
+	{key: "idempotency.lease", flag: "idempotency-lease", env: "IDEMPOTENCY_LEASE", usage: "how long an idempotency key stays claimed while its first request runs",
+		value: func(c *Config) interface{} { return &c.Idempotency.Lease }},
+	{key: "shutdown.timeout", flag: "shutdown-timeout", env: "SHUTDOWN_TIMEOUT", usage: "how long the server may take to shut down",
+		value: func(c *Config) interface{} { return &c.Shutdown.Timeout }},
+	{key: "metrics.listen", flag: "metrics-listen", env: "METRICS_LISTEN", usage: "the address of the /metrics endpoint, empty to turn it off",
+		value: func(c *Config) interface{} { return &c.Metrics.Listen }},
+	{key: "health.interval", flag: "health-interval", env: "HEALTH_INTERVAL", usage: "how often readiness is checked",
+		value: func(c *Config) interface{} { return &c.Health.Interval }},
+	{key: "log.level", flag: "log-level", env: "LOG_LEVEL", usage: "the lowest level logged: debug, info, warn or error",
+		value: func(c *Config) interface{} { return &c.Log.Level }},
+	{key: "log.encoding", flag: "log-encoding", env: "LOG_ENCODING", usage: "the log format: json or console",
+		value: func(c *Config) interface{} { return &c.Log.Encoding }},
+	{key: "rate_limit.disabled", flag: "rate-limit-disabled", env: "RATE_LIMIT_DISABLED", usage: "turn rate limiting off",
+		value: func(c *Config) interface{} { return &c.RateLimit.Disabled }},
+	{key: "rate_limit.rate", flag: "rate-limit-rate", env: "RATE_LIMIT_RATE", usage: "requests per second each caller may make to a method",
+		value: func(c *Config) interface{} { return &c.RateLimit.Rate }},
+	{key: "rate_limit.burst", flag: "rate-limit-burst", env: "RATE_LIMIT_BURST", usage: "requests each caller may make to a method at once",
+		value: func(c *Config) interface{} { return &c.RateLimit.Burst }},
+	{key: "rate_limit.methods", flag: "rate-limit-methods", env: "RATE_LIMIT_METHODS", usage: "comma-separated per-method limits as Method=rate:burst",
+		value: func(c *Config) interface{} { return &c.RateLimit.Methods }},
+	{key: "quota.decks_per_user", flag: "quota-decks-per-user", env: "QUOTA_DECKS_PER_USER", usage: "how many decks a user may have, 0 for no limit",
+		value: func(c *Config) interface{} { return &c.Quota.DecksPerUser }},
+	{key: "quota.cards_per_deck", flag: "quota-cards-per-deck", env: "QUOTA_CARDS_PER_DECK", usage: "how many cards a deck may hold, 0 for no limit",
+		value: func(c *Config) interface{} { return &c.Quota.CardsPerDeck }},
+	{key: "quota.media_bytes", flag: "quota-media-bytes", env: "QUOTA_MEDIA_BYTES", usage: "how many bytes of card content a user may store, 0 for no limit",
+		value: func(c *Config) interface{} { return &c.Quota.MediaBytes }},
+	{key: "cache.disabled", flag: "cache-disabled", env: "CACHE_DISABLED", usage: "turn the cache of deck and card reads off",
+		value: func(c *Config) interface{} { return &c.Cache.Disabled }},
+	{key: "cache.size", flag: "cache-size", env: "CACHE_SIZE", usage: "how many entries the in-process cache holds",
+		value: func(c *Config) interface{} { return &c.Cache.Size }},
+	{key: "cache.ttl", flag: "cache-ttl", env: "CACHE_TTL", usage: "how long a cached read is served",
+		value: func(c *Config) interface{} { return &c.Cache.TTL }},
+	{key: "cache.redis_url", flag: "cache-redis-url", env: "CACHE_REDIS_URL", usage: "a redis:// URL to cache in a shared Redis-compatible server instead of in process", secret: true,
+		value: func(c *Config) interface{} { return &c.Cache.RedisURL }},
+}
+
+func lookup(key string) (setting, bool) {
+	for _, s := range settings {
+		if s.key == key {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+// Loader registers the configuration flags on a flag set and, once the flags
+// are parsed, builds the Config.
+type Loader struct {
+	file     string
+	print    bool
+	flags    []flagValue
+	required []string
+	lookup   func(string) (string, bool)
+}
+
+type flagValue struct {
+	setting setting
+	raw     string
+}
+
+// NewLoader adds -config, -print-config and a flag per setting to fs. Load
+// fails unless the settings named in required are set.
+func NewLoader(fs *flag.FlagSet, required ...string) *Loader {
+	l := &Loader{required: required, lookup: os.LookupEnv}
+
+	fs.StringVar(&l.file, "config", "", "a YAML or TOML file with settings (env CONFIG_FILE)")
+	fs.BoolVar(&l.print, "print-config", false, "print the effective configuration and exit")
+
+	for _, s := range settings {
+		s := s
+		usage := fmt.Sprintf("%s (env %s)", s.usage, s.env)
+		parse := func(raw string) error {
+			// Check the value now, so that a bad flag is reported by Parse.
+			if err := set(s.value(Default()), raw); err != nil {
+				return err
+			}
+			l.flags = append(l.flags, flagValue{setting: s, raw: raw})
+			return nil
+		}
+
+		if _, ok := s.value(&Config{}).(*bool); ok {
+			fs.BoolFunc(s.flag, usage, parse)
+		} else {
+			fs.Func(s.flag, usage, parse)
+		}
+	}
+
+	return l
+}
+
+// PrintRequested reports whether -print-config was given.
+func (l *Loader) PrintRequested() bool {
+	return l.print
+}
+
+// Load applies the file, the environment and the flags to the defaults and
+// validates the result. Variables from a .env file in the working directory
+// are used unless already set. With -print-config the result is not
+// validated, so that the configuration can be printed to find what is wrong
+// with it.
+func (l *Loader) Load() (*Config, error) {
+	_ = godotenv.Load()
+
+	cfg := Default()
+
+	file := l.file
+	if file == "" {
+		file, _ = l.lookup("CONFIG_FILE")
+	}
+	if file != "" {
+		if err := loadFile(cfg, file); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := loadEnv(cfg, l.lookup); err != nil {
+		return nil, err
+	}
+
+	for _, f := range l.flags {
+		if err := set(f.setting.value(cfg), f.raw); err != nil {
+			return nil, fmt.Errorf("flag -%s: %w", f.setting.flag, err)
+		}
+	}
+
+	if l.print {
+		return cfg, nil
+	}
+	if err := cfg.Validate(l.required...); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	return cfg, nil
+}
+
+// FromEnv is the configuration from the defaults and the environment alone,
+// for tests that talk to the services from docker-compose.
+func FromEnv() (*Config, error) {
+	cfg := Default()
+	if err := loadEnv(cfg, os.LookupEnv); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadFile decodes a YAML or TOML file, chosen by its extension. Unknown keys
+// are errors, so a typo does not silently leave a default in place.
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("%s: unknown key %s", path, undecoded[0])
+		}
+	default:
+		return fmt.Errorf("%s: unsupported config format, want .yaml, .yml or .toml", path)
+	}
+
+	return nil
+}
+
+// loadEnv applies the variables of every setting. The older DBHOST, DBPORT,
+// DBUSER, DBPASSWORD, DBNAME and KAFKA_HOST, KAFKA_PORT variables are still
+// understood, with DB_DSN and KAFKA_BROKERS taking precedence over them.
+func loadEnv(cfg *Config, lookup func(string) (string, bool)) error {
+	getenv := func(key string) string {
+		value, _ := lookup(key)
+		return value
+	}
+
+	if host := getenv("DBHOST"); host != "" {
+		cfg.Storage.DSN = fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+			host, getenv("DBPORT"), getenv("DBUSER"), getenv("DBPASSWORD"), getenv("DBNAME"))
+	}
+	if host, port := getenv("KAFKA_HOST"), getenv("KAFKA_PORT"); host != "" && port != "" {
+		cfg.Kafka.Brokers = []string{host + ":" + port}
+	}
+
+	for _, s := range settings {
+		raw, ok := lookup(s.env)
+		if !ok || raw == "" {
+			continue
+		}
+		if err := set(s.value(cfg), raw); err != nil {
+			return fmt.Errorf("%s: %w", s.env, err)
+		}
+	}
+
+	return nil
+}
+
+func set(field interface{}, raw string) error {
+	switch field := field.(type) {
+	case *string:
+		*field = raw
+	case *bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		*field = v
+	case *float64:
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		*field = v
+	case *int64:
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		*field = v
+	case *time.Duration:
+		v, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		*field = v
+	case *[]string:
+		*field = nil
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*field = append(*field, item)
+			}
+		}
+	default:
+		panic(fmt.Sprintf("config: unsupported field type %T", field))
+	}
+	return nil
+}
+
+func format(field interface{}) string {
+	switch field := field.(type) {
+	case *string:
+		return *field
+	case *bool:
+		return strconv.FormatBool(*field)
+	case *float64:
+		return strconv.FormatFloat(*field, 'g', -1, 64)
+	case *int64:
+		return strconv.FormatInt(*field, 10)
+	case *time.Duration:
+		return field.String()
+	case *[]string:
+		return strings.Join(*field, ",")
+	default:
+		panic(fmt.Sprintf("config: unsupported field type %T", field))
+	}
+}
@@ -3,49 +3,28 @@ package kafka
 import (
 	"errors"
 	"fmt"
-	"flash-card-manager/pkg/logger"
-	"os"
-
-	"github.com/joho/godotenv"
 )
 
-func LoadKafkaConfig() ([]string, error) {
-	err := godotenv.Load("/home/art4m/prj/route256/flash-card-manager/.env")
-	if err != nil {
-		return nil, errors.New("error loading .env file")
-	}
-
-	kafkaHost := os.Getenv("KAFKA_HOST")
-	if kafkaHost == "" {
-		return nil, errors.New("KAFKA_HOST must be set in .env file")
-	}
+// Topics are the topics the service publishes events to and consumes.
+var Topics = []string{"Card", "Deck"}
 
-	kafkaPort := os.Getenv("KAFKA_PORT")
-	if kafkaPort == "" {
-		return nil, errors.New("KAFKA_PORT must be set in .env file")
+// InitializeKafka connects a producer and a consumer to the brokers. Run
+// Consume for each of Topics to start consuming.
+func InitializeKafka(brokers []string) (*Producer, *Consumer, error) {
+	if len(brokers) == 0 {
+		return nil, nil, errors.New("no Kafka brokers configured")
 	}
 
-	return []string{fmt.Sprintf("%s:%s", kafkaHost, kafkaPort)}, nil
-}
-
-func InitializeKafka() (*Producer, *Consumer, error) {
-	kafkaAddress, err := LoadKafkaConfig()
-	if err != nil {
-		logger.GetLogger().Sugar().Errorf("Error init kafka: %v", err)
-	}
-
-	producer, err := NewProducer(kafkaAddress)
+	producer, err := NewProducer(brokers)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	consumer, err := NewConsumer(kafkaAddress)
+	consumer, err := NewConsumer(brokers)
 	if err != nil {
+		producer.Close()
 		return nil, nil, fmt.Errorf("failed to create Kafka consumer: %w", err)
 	}
 
-	go consumer.Consume("Card")
-	go consumer.Consume("Deck")
-
 	return producer, consumer, nil
 }
@@ -1,18 +1,26 @@
 package kafka
 
 import (
+	"context"
 	"encoding/json"
+	"flash-card-manager/pkg/logger"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/IBM/sarama"
+	"go.uber.org/zap"
 )
 
 type Consumer struct {
 	brokers        []string
 	SingleConsumer sarama.Consumer
+	handlers       []EventHandler
 }
 
+// EventHandler is called with every event the consumer receives.
+type EventHandler func(ctx context.Context, event Event)
+
 func NewConsumer(brokers []string) (*Consumer, error) {
 	config := sarama.NewConfig()
 	config.Consumer.Return.Errors = false
@@ -34,30 +42,55 @@ func NewConsumer(brokers []string) (*Consumer, error) {
 
 }
 
-func (c *Consumer) Consume(topic string) {
+// Consume logs the events of the topic until ctx is cancelled.
+func (c *Consumer) Consume(ctx context.Context, topic string) error {
 	partitionConsumer, err := c.SingleConsumer.ConsumePartition(topic, 0, sarama.OffsetOldest)
 	if err != nil {
-		fmt.Println("Error starting the partition consumer:", err)
-		return
+		return fmt.Errorf("failed to consume topic %s: %w", topic, err)
 	}
 	defer partitionConsumer.Close()
 
 	for {
 		select {
+		case <-ctx.Done():
+			return nil
 		case msg := <-partitionConsumer.Messages():
-			c.handleMessage(msg)
+			consumerLag.WithLabelValues(msg.Topic, strconv.Itoa(int(msg.Partition))).Set(float64(partitionConsumer.HighWaterMarkOffset() - msg.Offset - 1))
+			c.handleMessage(ctx, msg)
 		case err := <-partitionConsumer.Errors():
-			fmt.Println("Error while consuming from Kafka:", err)
+			logger.Error(ctx, "Failed to consume from Kafka", zap.String("topic", topic), zap.Error(err))
 		}
 	}
 }
 
-func (c *Consumer) handleMessage(msg *sarama.ConsumerMessage) {
+// Handle adds h to the handlers of the events. Call it before Consume.
+func (c *Consumer) Handle(h EventHandler) {
+	c.handlers = append(c.handlers, h)
+}
+
+func (c *Consumer) Close() error {
+	return c.SingleConsumer.Close()
+}
+
+func (c *Consumer) handleMessage(ctx context.Context, msg *sarama.ConsumerMessage) {
+	ctx, span := startConsumerSpan(ctx, msg)
+
 	var event Event
 	err := json.Unmarshal(msg.Value, &event)
+	endSpan(span, err)
 	if err != nil {
-		fmt.Println("Error unmarshalling Kafka message:", err)
+		logger.Error(ctx, "Failed to unmarshal Kafka message", zap.String("topic", msg.Topic), zap.Int64("offset", msg.Offset), zap.Error(err))
 		return
 	}
-	fmt.Printf("Received message at %v of type %s: %s\n", event.Timestamp, event.Type, event.Query)
+	// The query holds the request, card content included, so it is not
+	// logged.
+	logger.Info(ctx, "Received Kafka event",
+		zap.String("topic", msg.Topic),
+		zap.Int64("offset", msg.Offset),
+		zap.String("type", event.Type),
+		zap.Time("timestamp", event.Timestamp),
+	)
+	for _, h := range c.handlers {
+		h(ctx, event)
+	}
 }
@@ -0,0 +1,36 @@
+//go:build unit
+// +build unit
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"flash-card-manager/pkg/logger"
+	"fmt"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestHandleMessageDoesNotLogQuery(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger.SetGlobal(zap.New(core))
+	t.Cleanup(func() { logger.SetGlobal(zap.NewNop()) })
+
+	value, err := json.Marshal(NewEvent("CreateCard", `front:"secret"`))
+	require.NoError(t, err)
+	(&Consumer{}).handleMessage(context.Background(), &sarama.ConsumerMessage{Topic: "events", Value: value})
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "CreateCard", entries[0].ContextMap()["type"])
+	for _, value := range entries[0].ContextMap() {
+		assert.NotContains(t, fmt.Sprint(value), "secret")
+	}
+}
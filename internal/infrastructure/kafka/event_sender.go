@@ -1,13 +1,19 @@
 package kafka
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/IBM/sarama"
 )
 
 type EventSender interface {
-	SendEvent(eventType, query string) error
+	// SendEvent publishes an event. The trace context of ctx travels in the
+	// message headers.
+	SendEvent(ctx context.Context, eventType, query string) error
+	// SendEvents publishes one event of the given type per query in a single
+	// round trip.
+	SendEvents(ctx context.Context, eventType string, queries []string) error
 }
 
 type KafkaEventSender struct {
@@ -18,21 +24,51 @@ func NewKafkaEventSender(producer ProducerInterface) *KafkaEventSender {
 	return &KafkaEventSender{producer: producer}
 }
 
-func (s *KafkaEventSender) SendEvent(eventType, query string) error {
-
-	event := NewEvent(eventType, query)
-	eventBytes, err := json.Marshal(event)
+func (s *KafkaEventSender) SendEvent(ctx context.Context, eventType, query string) (err error) {
+	msg, err := newEventMessage(eventType, query)
 	if err != nil {
 		return err
 	}
-	msg := &sarama.ProducerMessage{
-		Topic: "Card",
-		Value: sarama.ByteEncoder(eventBytes),
-	}
+
+	_, span := startProducerSpan(ctx, msg)
+	defer func() { endSpan(span, err) }()
 
 	_, _, err = s.producer.SendSyncMessage(msg)
+	return err
+}
+
+func (s *KafkaEventSender) SendEvents(ctx context.Context, eventType string, queries []string) (err error) {
+	if len(queries) == 0 {
+		return nil
+	}
+
+	msgs := make([]*sarama.ProducerMessage, 0, len(queries))
+	for _, query := range queries {
+		msg, err := newEventMessage(eventType, query)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+
+	// One span covers the batch; every message carries its context.
+	_, span := startProducerSpan(ctx, msgs[0])
+	defer func() { endSpan(span, err) }()
+	for _, msg := range msgs[1:] {
+		msg.Headers = append(msg.Headers, msgs[0].Headers...)
+	}
+
+	return s.producer.SendSyncMessages(msgs)
+}
+
+func newEventMessage(eventType, query string) (*sarama.ProducerMessage, error) {
+	eventBytes, err := json.Marshal(NewEvent(eventType, query))
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+
+	return &sarama.ProducerMessage{
+		Topic: "Card",
+		Value: sarama.ByteEncoder(eventBytes),
+	}, nil
 }
@@ -0,0 +1,29 @@
+package kafka
+
+import (
+	"flash-card-manager/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	producerSendSeconds = promauto.With(metrics.Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Name:      "kafka_producer_send_seconds",
+		Help:      "How long a synchronous send took, for one message or a batch.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"topic"})
+
+	producerErrors = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "kafka_producer_errors_total",
+		Help:      "Sends that failed.",
+	}, []string{"topic"})
+
+	consumerLag = promauto.With(metrics.Registry).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Name:      "kafka_consumer_lag",
+		Help:      "Messages in the partition the consumer has not read yet.",
+	}, []string{"topic", "partition"})
+)
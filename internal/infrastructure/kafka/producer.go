@@ -2,13 +2,16 @@
 package kafka
 
 import (
-	"fmt"
+	"context"
+	"time"
+
 	"github.com/IBM/sarama"
 	"github.com/pkg/errors"
 )
 
 type Producer struct {
 	brokers      []string
+	client       sarama.Client
 	syncProducer sarama.SyncProducer
 }
 
@@ -24,13 +27,20 @@ func NewProducer(brokers []string) (*Producer, error) {
 	config.Producer.Retry.Max = 5
 	config.Producer.Return.Successes = true
 
-	syncProducer, err := sarama.NewSyncProducer(brokers, config)
+	client, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "error with kafka client")
+	}
+
+	syncProducer, err := sarama.NewSyncProducerFromClient(client)
 	if err != nil {
+		client.Close()
 		return nil, errors.Wrap(err, "error with sync kafka-producer")
 	}
 
 	producer := &Producer{
 		brokers:      brokers,
+		client:       client,
 		syncProducer: syncProducer,
 	}
 
@@ -38,16 +48,40 @@ func NewProducer(brokers []string) (*Producer, error) {
 }
 
 func (k *Producer) SendSyncMessage(message *sarama.ProducerMessage) (partition int32, offset int64, err error) {
+	defer observeSend(message.Topic, time.Now(), &err)
 	return k.syncProducer.SendMessage(message)
 }
 
-func (k *Producer) SendSyncMessages(messages []*sarama.ProducerMessage) error {
-	err := k.syncProducer.SendMessages(messages)
-	if err != nil {
-		fmt.Println("kafka.Producer.SendMessages error", err)
+func (k *Producer) SendSyncMessages(messages []*sarama.ProducerMessage) (err error) {
+	if len(messages) == 0 {
+		return nil
+	}
+	defer observeSend(messages[0].Topic, time.Now(), &err)
+
+	return k.syncProducer.SendMessages(messages)
+}
+
+func observeSend(topic string, start time.Time, err *error) {
+	producerSendSeconds.WithLabelValues(topic).Observe(time.Since(start).Seconds())
+	if *err != nil {
+		producerErrors.WithLabelValues(topic).Inc()
 	}
+}
+
+// Ping checks that the brokers are reachable by refreshing the cluster
+// metadata.
+func (k *Producer) Ping(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- k.client.RefreshMetadata()
+	}()
 
-	return err
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func (k *Producer) Close() error {
@@ -56,5 +90,10 @@ func (k *Producer) Close() error {
 		return errors.Wrap(err, "kafka.Producer.Close syncProducer")
 	}
 
+	// A producer made from a client leaves closing the client to the caller.
+	if err := k.client.Close(); err != nil {
+		return errors.Wrap(err, "kafka.Producer.Close client")
+	}
+
 	return nil
 }
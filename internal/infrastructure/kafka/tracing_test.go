@@ -0,0 +1,56 @@
+//go:build unit
+// +build unit
+
+package kafka
+
+import (
+	"context"
+	mock_kafka "flash-card-manager/internal/infrastructure/kafka/mocks"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracePropagation(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	ctrl := gomock.NewController(t)
+	producer := mock_kafka.NewMockProducerInterface(ctrl)
+
+	var sent *sarama.ProducerMessage
+	producer.EXPECT().SendSyncMessage(gomock.Any()).DoAndReturn(func(msg *sarama.ProducerMessage) (int32, int64, error) {
+		sent = msg
+		return 0, 0, nil
+	})
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "CreateCard")
+	require.NoError(t, NewKafkaEventSender(producer).SendEvent(ctx, "CreateCard", "query"))
+	parent.End()
+
+	received := &sarama.ConsumerMessage{Topic: sent.Topic, Value: sent.Value.(sarama.ByteEncoder)}
+	for i := range sent.Headers {
+		received.Headers = append(received.Headers, &sent.Headers[i])
+	}
+	(&Consumer{}).handleMessage(context.Background(), received)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	publish, process := spans[0], spans[2]
+
+	assert.Equal(t, trace.SpanKindProducer, publish.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), publish.Parent().SpanID())
+
+	assert.Equal(t, trace.SpanKindConsumer, process.SpanKind())
+	assert.Equal(t, publish.SpanContext().TraceID(), process.SpanContext().TraceID())
+	assert.Equal(t, publish.SpanContext().SpanID(), process.Parent().SpanID())
+}
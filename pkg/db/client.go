@@ -2,18 +2,20 @@ package db
 
 import (
 	"context"
-	"fmt"
-	"flash-card-manager/pkg/logger"
-	"os"
+	"strings"
 
 	"github.com/jackc/pgx/v4/pgxpool"
-	"github.com/joho/godotenv"
 )
 
-func init() {
-	if err := godotenv.Load(); err != nil {
-		logger.GetLogger().Sugar().Info("Warning: Could not load .env file")
+// Open connects to the database the DSN points to. DSNs starting with
+// "sqlite:", e.g. "sqlite:///var/lib/flash-cards.db" or "sqlite::memory:",
+// open SQLite; anything else is passed to Postgres.
+func Open(ctx context.Context, dsn string) (DatabaseInterface, error) {
+	if path, ok := strings.CutPrefix(dsn, "sqlite:"); ok {
+		return NewSQLite(ctx, strings.TrimPrefix(path, "//"))
 	}
+
+	return NewDB(ctx, dsn)
 }
 
 func NewDB(ctx context.Context, dsn string) (*Database, error) {
@@ -24,13 +26,3 @@ func NewDB(ctx context.Context, dsn string) (*Database, error) {
 
 	return newDatabase(pool), nil
 }
-
-func GenerateDsn() string {
-	host := os.Getenv("DBHOST")
-	port := os.Getenv("DBPORT")
-	user := os.Getenv("DBUSER")
-	password := os.Getenv("DBPASSWORD")
-	dbname := os.Getenv("DBNAME")
-
-	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", host, port, user, password, dbname)
-}
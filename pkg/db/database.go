@@ -2,12 +2,22 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+
 	"github.com/georgysavva/scany/pgxscan"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/jackc/pgx/v4/stdlib"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// ErrNoRows is returned by Get and Row.Scan when a query finds nothing,
+// whatever the driver.
+var ErrNoRows = errors.New("no rows in result set")
+
+// Database is the Postgres implementation of PostgresInterface.
 type Database struct {
 	cluster *pgxpool.Pool
 }
@@ -16,22 +26,99 @@ func newDatabase(cluster *pgxpool.Pool) *Database {
 	return &Database{cluster: cluster}
 }
 
-func (db Database) GetPool(_ context.Context) *pgxpool.Pool {
+// querier is what Database runs statements on: the transaction from the
+// context when there is one, the pool otherwise.
+type querier interface {
+	pgxscan.Querier
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+func (db Database) querier(ctx context.Context) querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
 	return db.cluster
 }
 
-func (db Database) Get(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	return pgxscan.Get(ctx, db.cluster, dest, query, args...)
+func (db Database) Close() {
+	db.cluster.Close()
+}
+
+// Ping acquires a connection from the pool and checks that the server answers.
+func (db Database) Ping(ctx context.Context) error {
+	return db.cluster.Ping(ctx)
+}
+
+// Stat returns the connection pool statistics.
+func (db Database) Stat() *pgxpool.Stat {
+	return db.cluster.Stat()
+}
+
+// OpenSQL opens a database/sql pool with the same connection settings, for
+// tools like goose that do not speak pgx. The caller closes it.
+func (db Database) OpenSQL() *sql.DB {
+	return stdlib.OpenDB(*db.cluster.Config().ConnConfig)
+}
+
+func (db Database) Get(ctx context.Context, dest interface{}, query string, args ...interface{}) (err error) {
+	ctx, span := startSpan(ctx, "postgresql", "Get", query)
+	defer func() { endSpan(span, err) }()
+
+	return pgxNoRows(pgxscan.Get(ctx, db.querier(ctx), dest, query, args...))
+}
+
+func (db Database) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) (err error) {
+	ctx, span := startSpan(ctx, "postgresql", "Select", query)
+	defer func() { endSpan(span, err) }()
+
+	return pgxscan.Select(ctx, db.querier(ctx), dest, query, args...)
+}
+
+func (db Database) Exec(ctx context.Context, query string, args ...interface{}) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "postgresql", "Exec", query)
+	defer func() { endSpan(span, err) }()
+
+	tag, err := db.querier(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (db Database) ExecQueryRow(ctx context.Context, query string, args ...interface{}) Row {
+	ctx, span := startSpan(ctx, "postgresql", "QueryRow", query)
+	return tracedRow{row: pgxRow{db.querier(ctx).QueryRow(ctx, query, args...)}, span: span}
+}
+
+func (db Database) SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults {
+	ctx, span := startSpan(ctx, "postgresql", "SendBatch", "")
+	span.SetAttributes(attribute.Int("db.batch.size", batch.Len()))
+	return tracedBatch{BatchResults: db.querier(ctx).SendBatch(ctx, batch), span: span}
+}
+
+func (db Database) CopyFrom(ctx context.Context, table pgx.Identifier, columns []string, rows pgx.CopyFromSource) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "postgresql", "CopyFrom", "")
+	span.SetAttributes(attribute.String("db.sql.table", table.Sanitize()))
+	defer func() { endSpan(span, err) }()
+
+	return db.querier(ctx).CopyFrom(ctx, table, columns, rows)
 }
 
-func (db Database) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	return pgxscan.Select(ctx, db.cluster, dest, query, args...)
+type pgxRow struct {
+	row pgx.Row
 }
 
-func (db Database) Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
-	return db.cluster.Exec(ctx, query, args...)
+func (r pgxRow) Scan(dest ...interface{}) error {
+	return pgxNoRows(r.row.Scan(dest...))
 }
 
-func (db Database) ExecQueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
-	return db.cluster.QueryRow(ctx, query, args...)
+// pgxNoRows replaces pgx.ErrNoRows with ErrNoRows.
+func pgxNoRows(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNoRows
+	}
+	return err
 }
@@ -3,15 +3,42 @@ package db
 
 import (
 	"context"
-	"github.com/jackc/pgconn"
+
 	"github.com/jackc/pgx/v4"
-	"github.com/jackc/pgx/v4/pgxpool"
 )
 
+// TxManager runs units of work in a transaction. See Database.RunInTx.
+type TxManager interface {
+	RunInTx(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error
+}
+
+// Row is the result of ExecQueryRow. Scan fails with ErrNoRows when the
+// statement returned nothing.
+type Row interface {
+	Scan(dest ...interface{}) error
+}
+
+// DatabaseInterface runs statements in the transaction carried by the context,
+// if any, and directly on the connection pool otherwise. It is implemented
+// for every supported driver, so repositories written against it must stick
+// to SQL their database understands.
 type DatabaseInterface interface {
-	GetPool(ctx context.Context) *pgxpool.Pool
+	TxManager
+	// Get scans a single row into dest and fails with ErrNoRows if there is
+	// none.
 	Get(ctx context.Context, dest interface{}, query string, args ...interface{}) error
 	Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error
-	Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error)
-	ExecQueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row
+	// Exec returns the number of rows the statement affected.
+	Exec(ctx context.Context, query string, args ...interface{}) (int64, error)
+	ExecQueryRow(ctx context.Context, query string, args ...interface{}) Row
+	// Ping checks that the database is reachable.
+	Ping(ctx context.Context) error
+	Close()
+}
+
+// PostgresInterface adds the bulk operations of Postgres to DatabaseInterface.
+type PostgresInterface interface {
+	DatabaseInterface
+	SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults
+	CopyFrom(ctx context.Context, table pgx.Identifier, columns []string, rows pgx.CopyFromSource) (int64, error)
 }
@@ -6,14 +6,91 @@ package mock_db
 
 import (
 	context "context"
+	db "flash-card-manager/pkg/db"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
-	pgconn "github.com/jackc/pgconn"
 	pgx "github.com/jackc/pgx/v4"
-	pgxpool "github.com/jackc/pgx/v4/pgxpool"
 )
 
+// MockTxManager is a mock of TxManager interface.
+type MockTxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxManagerMockRecorder
+}
+
+// MockTxManagerMockRecorder is the mock recorder for MockTxManager.
+type MockTxManagerMockRecorder struct {
+	mock *MockTxManager
+}
+
+// NewMockTxManager creates a new mock instance.
+func NewMockTxManager(ctrl *gomock.Controller) *MockTxManager {
+	mock := &MockTxManager{ctrl: ctrl}
+	mock.recorder = &MockTxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxManager) EXPECT() *MockTxManagerMockRecorder {
+	return m.recorder
+}
+
+// RunInTx mocks base method.
+func (m *MockTxManager) RunInTx(ctx context.Context, opts db.TxOptions, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunInTx", ctx, opts, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunInTx indicates an expected call of RunInTx.
+func (mr *MockTxManagerMockRecorder) RunInTx(ctx, opts, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInTx", reflect.TypeOf((*MockTxManager)(nil).RunInTx), ctx, opts, fn)
+}
+
+// MockRow is a mock of Row interface.
+type MockRow struct {
+	ctrl     *gomock.Controller
+	recorder *MockRowMockRecorder
+}
+
+// MockRowMockRecorder is the mock recorder for MockRow.
+type MockRowMockRecorder struct {
+	mock *MockRow
+}
+
+// NewMockRow creates a new mock instance.
+func NewMockRow(ctrl *gomock.Controller) *MockRow {
+	mock := &MockRow{ctrl: ctrl}
+	mock.recorder = &MockRowMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRow) EXPECT() *MockRowMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockRow) Scan(dest ...interface{}) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockRowMockRecorder) Scan(dest ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockRow)(nil).Scan), dest...)
+}
+
 // MockDatabaseInterface is a mock of DatabaseInterface interface.
 type MockDatabaseInterface struct {
 	ctrl     *gomock.Controller
@@ -37,15 +114,27 @@ func (m *MockDatabaseInterface) EXPECT() *MockDatabaseInterfaceMockRecorder {
 	return m.recorder
 }
 
+// Close mocks base method.
+func (m *MockDatabaseInterface) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockDatabaseInterfaceMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDatabaseInterface)(nil).Close))
+}
+
 // Exec mocks base method.
-func (m *MockDatabaseInterface) Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
+func (m *MockDatabaseInterface) Exec(ctx context.Context, query string, args ...interface{}) (int64, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, query}
 	for _, a := range args {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "Exec", varargs...)
-	ret0, _ := ret[0].(pgconn.CommandTag)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -58,14 +147,14 @@ func (mr *MockDatabaseInterfaceMockRecorder) Exec(ctx, query interface{}, args .
 }
 
 // ExecQueryRow mocks base method.
-func (m *MockDatabaseInterface) ExecQueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
+func (m *MockDatabaseInterface) ExecQueryRow(ctx context.Context, query string, args ...interface{}) db.Row {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, query}
 	for _, a := range args {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "ExecQueryRow", varargs...)
-	ret0, _ := ret[0].(pgx.Row)
+	ret0, _ := ret[0].(db.Row)
 	return ret0
 }
 
@@ -95,18 +184,32 @@ func (mr *MockDatabaseInterfaceMockRecorder) Get(ctx, dest, query interface{}, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDatabaseInterface)(nil).Get), varargs...)
 }
 
-// GetPool mocks base method.
-func (m *MockDatabaseInterface) GetPool(ctx context.Context) *pgxpool.Pool {
+// Ping mocks base method.
+func (m *MockDatabaseInterface) Ping(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPool", ctx)
-	ret0, _ := ret[0].(*pgxpool.Pool)
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// GetPool indicates an expected call of GetPool.
-func (mr *MockDatabaseInterfaceMockRecorder) GetPool(ctx interface{}) *gomock.Call {
+// Ping indicates an expected call of Ping.
+func (mr *MockDatabaseInterfaceMockRecorder) Ping(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPool", reflect.TypeOf((*MockDatabaseInterface)(nil).GetPool), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockDatabaseInterface)(nil).Ping), ctx)
+}
+
+// RunInTx mocks base method.
+func (m *MockDatabaseInterface) RunInTx(ctx context.Context, opts db.TxOptions, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunInTx", ctx, opts, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunInTx indicates an expected call of RunInTx.
+func (mr *MockDatabaseInterfaceMockRecorder) RunInTx(ctx, opts, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInTx", reflect.TypeOf((*MockDatabaseInterface)(nil).RunInTx), ctx, opts, fn)
 }
 
 // Select mocks base method.
@@ -127,3 +230,172 @@ func (mr *MockDatabaseInterfaceMockRecorder) Select(ctx, dest, query interface{}
 	varargs := append([]interface{}{ctx, dest, query}, args...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Select", reflect.TypeOf((*MockDatabaseInterface)(nil).Select), varargs...)
 }
+
+// MockPostgresInterface is a mock of PostgresInterface interface.
+type MockPostgresInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostgresInterfaceMockRecorder
+}
+
+// MockPostgresInterfaceMockRecorder is the mock recorder for MockPostgresInterface.
+type MockPostgresInterfaceMockRecorder struct {
+	mock *MockPostgresInterface
+}
+
+// NewMockPostgresInterface creates a new mock instance.
+func NewMockPostgresInterface(ctrl *gomock.Controller) *MockPostgresInterface {
+	mock := &MockPostgresInterface{ctrl: ctrl}
+	mock.recorder = &MockPostgresInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostgresInterface) EXPECT() *MockPostgresInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockPostgresInterface) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockPostgresInterfaceMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPostgresInterface)(nil).Close))
+}
+
+// CopyFrom mocks base method.
+func (m *MockPostgresInterface) CopyFrom(ctx context.Context, table pgx.Identifier, columns []string, rows pgx.CopyFromSource) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyFrom", ctx, table, columns, rows)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyFrom indicates an expected call of CopyFrom.
+func (mr *MockPostgresInterfaceMockRecorder) CopyFrom(ctx, table, columns, rows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyFrom", reflect.TypeOf((*MockPostgresInterface)(nil).CopyFrom), ctx, table, columns, rows)
+}
+
+// Exec mocks base method.
+func (m *MockPostgresInterface) Exec(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exec", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exec indicates an expected call of Exec.
+func (mr *MockPostgresInterfaceMockRecorder) Exec(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockPostgresInterface)(nil).Exec), varargs...)
+}
+
+// ExecQueryRow mocks base method.
+func (m *MockPostgresInterface) ExecQueryRow(ctx context.Context, query string, args ...interface{}) db.Row {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExecQueryRow", varargs...)
+	ret0, _ := ret[0].(db.Row)
+	return ret0
+}
+
+// ExecQueryRow indicates an expected call of ExecQueryRow.
+func (mr *MockPostgresInterfaceMockRecorder) ExecQueryRow(ctx, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecQueryRow", reflect.TypeOf((*MockPostgresInterface)(nil).ExecQueryRow), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockPostgresInterface) Get(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, dest, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Get", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPostgresInterfaceMockRecorder) Get(ctx, dest, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, dest, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPostgresInterface)(nil).Get), varargs...)
+}
+
+// Ping mocks base method.
+func (m *MockPostgresInterface) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockPostgresInterfaceMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockPostgresInterface)(nil).Ping), ctx)
+}
+
+// RunInTx mocks base method.
+func (m *MockPostgresInterface) RunInTx(ctx context.Context, opts db.TxOptions, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunInTx", ctx, opts, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunInTx indicates an expected call of RunInTx.
+func (mr *MockPostgresInterfaceMockRecorder) RunInTx(ctx, opts, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInTx", reflect.TypeOf((*MockPostgresInterface)(nil).RunInTx), ctx, opts, fn)
+}
+
+// Select mocks base method.
+func (m *MockPostgresInterface) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, dest, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Select", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Select indicates an expected call of Select.
+func (mr *MockPostgresInterfaceMockRecorder) Select(ctx, dest, query interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, dest, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Select", reflect.TypeOf((*MockPostgresInterface)(nil).Select), varargs...)
+}
+
+// SendBatch mocks base method.
+func (m *MockPostgresInterface) SendBatch(ctx context.Context, batch *pgx.Batch) pgx.BatchResults {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBatch", ctx, batch)
+	ret0, _ := ret[0].(pgx.BatchResults)
+	return ret0
+}
+
+// SendBatch indicates an expected call of SendBatch.
+func (mr *MockPostgresInterfaceMockRecorder) SendBatch(ctx, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBatch", reflect.TypeOf((*MockPostgresInterface)(nil).SendBatch), ctx, batch)
+}
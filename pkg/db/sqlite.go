@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/georgysavva/scany/sqlscan"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteDatabase is the SQLite implementation of DatabaseInterface. It uses
+// a single connection, so statements and transactions never wait on a locked
+// database file; they queue in the pool instead.
+type SQLiteDatabase struct {
+	db *sql.DB
+}
+
+// NewSQLite opens the database file at path, or an in-memory database for
+// ":memory:", with foreign keys enforced.
+func NewSQLite(ctx context.Context, path string) (*SQLiteDatabase, error) {
+	dsn := "file:" + path + "?_foreign_keys=on&_busy_timeout=5000"
+	if strings.Contains(path, "?") {
+		dsn = "file:" + path + "&_foreign_keys=on&_busy_timeout=5000"
+	}
+
+	database, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+	database.SetMaxOpenConns(1)
+
+	if err := database.PingContext(ctx); err != nil {
+		database.Close()
+		return nil, err
+	}
+
+	return &SQLiteDatabase{db: database}, nil
+}
+
+// DB returns the underlying connection pool, e.g. for running migrations.
+func (db *SQLiteDatabase) DB() *sql.DB {
+	return db.db
+}
+
+type sqliteTx struct {
+	tx    *sql.Tx
+	depth int
+}
+
+type sqliteTxKey struct{}
+
+// sqliteQuerier is what SQLiteDatabase runs statements on: the transaction
+// from the context when there is one, the pool otherwise.
+type sqliteQuerier interface {
+	sqlscan.Querier
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func (db *SQLiteDatabase) querier(ctx context.Context) sqliteQuerier {
+	if tx, ok := ctx.Value(sqliteTxKey{}).(*sqliteTx); ok {
+		return tx.tx
+	}
+	return db.db
+}
+
+func (db *SQLiteDatabase) Close() {
+	_ = db.db.Close()
+}
+
+func (db *SQLiteDatabase) Ping(ctx context.Context) error {
+	return db.db.PingContext(ctx)
+}
+
+func (db *SQLiteDatabase) Get(ctx context.Context, dest interface{}, query string, args ...interface{}) (err error) {
+	ctx, span := startSpan(ctx, "sqlite", "Get", query)
+	defer func() { endSpan(span, err) }()
+
+	return sqlNoRows(sqlscan.Get(ctx, db.querier(ctx), dest, query, args...))
+}
+
+func (db *SQLiteDatabase) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) (err error) {
+	ctx, span := startSpan(ctx, "sqlite", "Select", query)
+	defer func() { endSpan(span, err) }()
+
+	return sqlscan.Select(ctx, db.querier(ctx), dest, query, args...)
+}
+
+func (db *SQLiteDatabase) Exec(ctx context.Context, query string, args ...interface{}) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "sqlite", "Exec", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := db.querier(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (db *SQLiteDatabase) ExecQueryRow(ctx context.Context, query string, args ...interface{}) Row {
+	ctx, span := startSpan(ctx, "sqlite", "QueryRow", query)
+	return tracedRow{row: sqlRow{db.querier(ctx).QueryRowContext(ctx, query, args...)}, span: span}
+}
+
+// RunInTx works like Database.RunInTx. SQLite transactions are serializable
+// whatever opts.IsoLevel says, and with a single connection they do not
+// conflict, so fn runs only once.
+func (db *SQLiteDatabase) RunInTx(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(sqliteTxKey{}).(*sqliteTx); ok {
+		return runInSQLiteSavepoint(ctx, tx, fn)
+	}
+
+	tx, err := db.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(context.WithValue(ctx, sqliteTxKey{}, &sqliteTx{tx: tx})); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func runInSQLiteSavepoint(ctx context.Context, tx *sqliteTx, fn func(ctx context.Context) error) error {
+	inner := &sqliteTx{tx: tx.tx, depth: tx.depth + 1}
+	name := fmt.Sprintf("sp_%d", inner.depth)
+	if _, err := tx.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, sqliteTxKey{}, inner)); err != nil {
+		if _, rollbackErr := tx.tx.ExecContext(ctx, "ROLLBACK TO "+name); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		_, _ = tx.tx.ExecContext(ctx, "RELEASE "+name)
+		return err
+	}
+
+	_, err := tx.tx.ExecContext(ctx, "RELEASE "+name)
+	return err
+}
+
+type sqlRow struct {
+	row *sql.Row
+}
+
+func (r sqlRow) Scan(dest ...interface{}) error {
+	return sqlNoRows(r.row.Scan(dest...))
+}
+
+// sqlNoRows replaces sql.ErrNoRows with ErrNoRows.
+func sqlNoRows(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNoRows
+	}
+	return err
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("flash-card-manager/pkg/db")
+
+// startSpan starts the client span of one statement. system is the
+// OpenTelemetry db.system value: "postgresql" or "sqlite".
+func startSpan(ctx context.Context, system, operation, statement string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{attribute.String("db.system", system)}
+	if statement != "" {
+		attrs = append(attrs, attribute.String("db.statement", statement))
+	}
+	return tracer.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// endSpan ends span, marking it failed unless err is nil or ErrNoRows, which
+// is a result rather than a failure.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracedRow ends the span of ExecQueryRow once the row is scanned, since that
+// is when the query actually runs.
+type tracedRow struct {
+	row  Row
+	span trace.Span
+}
+
+func (r tracedRow) Scan(dest ...interface{}) error {
+	err := r.row.Scan(dest...)
+	endSpan(r.span, err)
+	return err
+}
+
+// tracedBatch ends the span of SendBatch when the results are closed.
+type tracedBatch struct {
+	pgx.BatchResults
+	span trace.Span
+}
+
+func (b tracedBatch) Close() error {
+	err := b.BatchResults.Close()
+	endSpan(b.span, err)
+	return err
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// DefaultMaxAttempts is how many times RunInTx runs a transaction that keeps
+// failing with a serialization error when TxOptions.MaxAttempts is zero.
+const DefaultMaxAttempts = 3
+
+// IsoLevel is a transaction isolation level.
+type IsoLevel string
+
+const (
+	Serializable    IsoLevel = "serializable"
+	RepeatableRead  IsoLevel = "repeatable read"
+	ReadCommitted   IsoLevel = "read committed"
+	ReadUncommitted IsoLevel = "read uncommitted"
+)
+
+// TxOptions configures a transaction started by RunInTx.
+type TxOptions struct {
+	// IsoLevel defaults to the server default, read committed for Postgres.
+	// SQLite transactions are always serializable.
+	IsoLevel IsoLevel
+	ReadOnly bool
+	// MaxAttempts bounds how many times fn runs when the transaction fails
+	// with a serialization failure or a deadlock. Zero means
+	// DefaultMaxAttempts and one disables retries.
+	MaxAttempts int
+}
+
+type txKey struct{}
+
+// TxFromContext returns the transaction RunInTx put into ctx, if any.
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// RunInTx runs fn in a transaction and commits it if fn succeeds. Database
+// methods called with the context passed to fn run inside the transaction.
+// Called again from within fn, RunInTx opens a savepoint instead, so the
+// inner fn can fail without aborting the outer transaction; opts are then
+// ignored. Since fn may run more than once, it must not have side effects
+// outside the database.
+func (db Database) RunInTx(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error {
+	if tx, ok := TxFromContext(ctx); ok {
+		return runInSavepoint(ctx, tx, fn)
+	}
+
+	attempts := opts.MaxAttempts
+	if attempts <= 0 {
+		attempts = DefaultMaxAttempts
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		err = db.runInTx(ctx, opts, fn)
+		if !isRetryable(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (db Database) runInTx(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error {
+	accessMode := pgx.ReadWrite
+	if opts.ReadOnly {
+		accessMode = pgx.ReadOnly
+	}
+
+	tx, err := db.cluster.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.TxIsoLevel(opts.IsoLevel), AccessMode: accessMode})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func runInSavepoint(ctx context.Context, tx pgx.Tx, fn func(ctx context.Context) error) error {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = savepoint.Rollback(ctx) }()
+
+	if err := fn(context.WithValue(ctx, txKey{}, savepoint)); err != nil {
+		return err
+	}
+
+	return savepoint.Commit(ctx)
+}
+
+// isRetryable reports whether err means the transaction lost a race with a
+// concurrent one and may succeed if run again.
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}
@@ -0,0 +1,117 @@
+package diff
+
+import (
+	"strings"
+)
+
+// Field is a single named value compared by Fields.
+type Field struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// maxCells caps the size of the table Lines builds, about 8 MiB. Inputs
+// whose changed lines would need more are shown as replaced as a whole.
+const maxCells = 1 << 20
+
+// Lines returns a line-based diff of old and new. Every line of the result
+// starts with ' ' for an unchanged line, '-' for a removed one and '+' for an
+// added one. Lines that both start or both end the texts are kept as they
+// are; when what lies between them is too long to compare line by line, it
+// is all removed and then all added.
+func Lines(old, new string) []string {
+	a := strings.Split(old, "\n")
+	b := strings.Split(new, "\n")
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	out := make([]string, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		out = append(out, " "+line)
+	}
+	out = appendChanges(out, a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	for _, line := range a[len(a)-suffix:] {
+		out = append(out, " "+line)
+	}
+
+	return out
+}
+
+// appendChanges appends the diff of a and b, which differ in their first and
+// last lines, to out.
+func appendChanges(out, a, b []string) []string {
+	if (len(a)+1)*(len(b)+1) > maxCells {
+		for _, line := range a {
+			out = append(out, "-"+line)
+		}
+		for _, line := range b {
+			out = append(out, "+"+line)
+		}
+		return out
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, " "+a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, "-"+a[i])
+			i++
+		default:
+			out = append(out, "+"+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, "-"+a[i])
+	}
+	for ; j < len(b); j++ {
+		out = append(out, "+"+b[j])
+	}
+
+	return out
+}
+
+// Fields renders a diff of every changed field, one section per field with a
+// "--- name" / "+++ name" header. Unchanged fields are left out, so two equal
+// snapshots produce an empty string.
+func Fields(fields ...Field) string {
+	var sb strings.Builder
+	for _, f := range fields {
+		if f.Old == f.New {
+			continue
+		}
+		sb.WriteString("--- " + f.Name + "\n")
+		sb.WriteString("+++ " + f.Name + "\n")
+		for _, line := range Lines(f.Old, f.New) {
+			sb.WriteString(line + "\n")
+		}
+	}
+	return sb.String()
+}
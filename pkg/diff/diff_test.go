@@ -0,0 +1,74 @@
+//go:build unit
+// +build unit
+
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want []string
+	}{
+		{
+			name: "Equal",
+			old:  "a\nb",
+			new:  "a\nb",
+			want: []string{" a", " b"},
+		},
+		{
+			name: "Changed Line",
+			old:  "a\nb\nc",
+			new:  "a\nB\nc",
+			want: []string{" a", "-b", "+B", " c"},
+		},
+		{
+			name: "Appended Line",
+			old:  "a",
+			new:  "a\nb",
+			want: []string{" a", "+b"},
+		},
+		{
+			name: "Removed Line",
+			old:  "a\nb\nc",
+			new:  "a\nc",
+			want: []string{" a", "-b", " c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Lines(tt.old, tt.new))
+		})
+	}
+}
+
+func TestLinesTooLong(t *testing.T) {
+	// A card side of 10000 characters has at most 5001 lines. Compared line
+	// by line, "x" would be kept.
+	old := "first\nx\n" + strings.Repeat("a\n", 5000) + "last"
+	new := "first\n" + strings.Repeat("b\n", 5000) + "x\nlast"
+
+	got := Lines(old, new)
+
+	assert.Len(t, got, 10004)
+	assert.Equal(t, []string{" first", "-x", "-a"}, got[:3])
+	assert.Equal(t, []string{"+b", "+x", " last"}, got[10001:])
+}
+
+func TestFields(t *testing.T) {
+	got := Fields(
+		Field{Name: "front", Old: "cat", New: "dog"},
+		Field{Name: "back", Old: "кот", New: "кот"},
+	)
+
+	assert.Equal(t, "--- front\n+++ front\n-cat\n+dog\n", got)
+	assert.Empty(t, Fields(Field{Name: "front", Old: "cat", New: "cat"}))
+}
@@ -0,0 +1,156 @@
+// Package errs holds the errors repositories and handlers report for things
+// that are the caller's fault, such as a missing card or a stale version.
+// Callers tell them apart with errors.Is and errors.As rather than by their
+// text; the gRPC layer turns them into statuses.
+package errs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind says what went wrong, independent of the resource involved.
+type Kind int
+
+const (
+	NotFound Kind = iota + 1
+	AlreadyExists
+	FailedPrecondition
+	InvalidArgument
+	// Conflict means the resource changed since the caller last read it.
+	Conflict
+	// QuotaExceeded means the write would take the caller past a storage
+	// limit.
+	QuotaExceeded
+)
+
+func (k Kind) String() string {
+	switch k {
+	case NotFound:
+		return "not found"
+	case AlreadyExists:
+		return "already exists"
+	case FailedPrecondition:
+		return "failed precondition"
+	case InvalidArgument:
+		return "invalid argument"
+	case Conflict:
+		return "version mismatch"
+	case QuotaExceeded:
+		return "quota exceeded"
+	default:
+		return fmt.Sprintf("kind %d", int(k))
+	}
+}
+
+// Sentinels to match errors of a kind with errors.Is, whatever the resource.
+var (
+	ErrNotFound           = &Error{Kind: NotFound}
+	ErrAlreadyExists      = &Error{Kind: AlreadyExists}
+	ErrFailedPrecondition = &Error{Kind: FailedPrecondition}
+	ErrInvalidArgument    = &Error{Kind: InvalidArgument}
+	ErrConflict           = &Error{Kind: Conflict}
+	ErrQuotaExceeded      = &Error{Kind: QuotaExceeded}
+)
+
+// FieldViolation names a request field and what is wrong with it.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Error is a domain error. Resource and ID are empty when the error is not
+// about a particular stored object.
+type Error struct {
+	Kind     Kind
+	Resource string
+	ID       int64
+	// Message replaces the default text, e.g. "deck not found in trash".
+	Message    string
+	Violations []FieldViolation
+	// Err is the underlying cause, if any, such as a Postgres error.
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	switch {
+	case e.Kind == InvalidArgument && len(e.Violations) > 0:
+		parts := make([]string, 0, len(e.Violations))
+		for _, v := range e.Violations {
+			parts = append(parts, v.Field+": "+v.Description)
+		}
+		return strings.Join(parts, "; ")
+	case e.Kind == Conflict || e.Resource == "":
+		return e.Kind.String()
+	default:
+		return e.Resource + " " + e.Kind.String()
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an *Error of the same kind. A target without
+// a resource, like the sentinels, matches any resource.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Kind == e.Kind && (t.Resource == "" || t.Resource == e.Resource)
+}
+
+// In returns a copy of e blamed on a field of the request, e.g. "cards[3]".
+// The field is prepended to the message and to every field violation.
+func (e *Error) In(field string) *Error {
+	c := *e
+	c.Message = field + ": " + e.Error()
+	c.Violations = make([]FieldViolation, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		c.Violations = append(c.Violations, FieldViolation{Field: field + "." + v.Field, Description: v.Description})
+	}
+	return &c
+}
+
+// NotFoundError reports that there is no resource with the given id. Pass 0
+// when the id is not known.
+func NotFoundError(resource string, id int64) error {
+	return &Error{Kind: NotFound, Resource: resource, ID: id}
+}
+
+func AlreadyExistsError(resource string, id int64) error {
+	return &Error{Kind: AlreadyExists, Resource: resource, ID: id}
+}
+
+// FailedPreconditionError reports that the resource is in a state that does
+// not allow the operation; msg says which.
+func FailedPreconditionError(resource string, id int64, msg string) error {
+	return &Error{Kind: FailedPrecondition, Resource: resource, ID: id, Message: msg}
+}
+
+// ConflictError reports that the resource is no longer at the version the
+// caller expected.
+func ConflictError(resource string, id int64) error {
+	return &Error{Kind: Conflict, Resource: resource, ID: id}
+}
+
+// QuotaExceededError reports that the write would take the caller past the
+// limit on resource; msg says which.
+func QuotaExceededError(resource string, id int64, msg string) error {
+	return &Error{Kind: QuotaExceeded, Resource: resource, ID: id, Message: msg}
+}
+
+// InvalidArgumentError reports the request fields that failed validation.
+func InvalidArgumentError(violations ...FieldViolation) error {
+	return &Error{Kind: InvalidArgument, Violations: violations}
+}
+
+// Violation is shorthand for a single-field InvalidArgumentError.
+func Violation(field, description string) error {
+	return InvalidArgumentError(FieldViolation{Field: field, Description: description})
+}
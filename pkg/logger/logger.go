@@ -2,11 +2,15 @@ package logger
 
 import (
 	"context"
+	"fmt"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
-var defaultLogger *zap.Logger
+// defaultLogger discards everything until Init is called, so package init
+// functions and unit tests can log safely.
+var defaultLogger = zap.NewNop()
 
 type ctxKey struct{}
 
@@ -29,10 +33,22 @@ func Infof(ctx context.Context, format string, args ...interface{}) {
 	FromContext(ctx).Sugar().Infof(format, args...)
 }
 
+func Debug(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).Debug(msg, fields...)
+}
+
 func Info(ctx context.Context, msg string, fields ...zap.Field) {
 	FromContext(ctx).Info(msg, fields...)
 }
 
+func Warn(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).Warn(msg, fields...)
+}
+
+func Error(ctx context.Context, msg string, fields ...zap.Field) {
+	FromContext(ctx).Error(msg, fields...)
+}
+
 func Errorf(ctx context.Context, format string, args ...interface{}) {
 	FromContext(ctx).Sugar().Errorf(format, args...)
 }
@@ -45,6 +61,27 @@ func Init() {
 	}
 }
 
+// New returns a production logger that writes entries of level and above,
+// e.g. "debug" or "warn", encoded as "json" or "console".
+func New(level, encoding string) (*zap.Logger, error) {
+	lvl, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	if encoding != "json" && encoding != "console" {
+		return nil, fmt.Errorf("unknown log encoding %q", encoding)
+	}
+
+	config := zap.NewProductionConfig()
+	config.Level = zap.NewAtomicLevelAt(lvl)
+	config.Encoding = encoding
+	if encoding == "console" {
+		config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		config.EncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+	}
+	return config.Build()
+}
+
 func GetLogger() *zap.Logger {
 	return defaultLogger
 }
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Redacted replaces the values of redacted fields in logs.
+const Redacted = "[REDACTED]"
+
+// redactedFields are the names of the proto fields that hold card content,
+// which users may not want to end up in logs.
+var redactedFields = map[protoreflect.Name]bool{
+	"front":          true,
+	"back":           true,
+	"rendered_front": true,
+	"rendered_back":  true,
+	"diff":           true,
+}
+
+// Proto logs msg as JSON with the card content replaced by Redacted.
+func Proto(key string, msg proto.Message) zap.Field {
+	if msg == nil {
+		return zap.Skip()
+	}
+
+	redacted := proto.Clone(msg)
+	redact(redacted.ProtoReflect())
+
+	b, err := protojson.Marshal(redacted)
+	if err != nil {
+		return zap.Error(err)
+	}
+	return zap.Any(key, jsonValue(b))
+}
+
+func redact(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case redactedFields[fd.Name()] && fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap():
+			if v.String() != "" {
+				m.Set(fd, protoreflect.ValueOfString(Redacted))
+			}
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				redact(list.Get(i).Message())
+			}
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsMap():
+			redact(v.Message())
+		}
+		return true
+	})
+}
+
+// jsonValue is logged as embedded JSON by the JSON encoder rather than as a
+// quoted string.
+type jsonValue []byte
+
+func (j jsonValue) MarshalJSON() ([]byte, error) {
+	return j, nil
+}
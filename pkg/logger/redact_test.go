@@ -0,0 +1,33 @@
+//go:build unit
+// +build unit
+
+package logger
+
+import (
+	"encoding/json"
+	pb "flash-card-manager/internal/app/grpc"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestProto(t *testing.T) {
+	req := &pb.BatchCreateCardsRequest{Cards: []*pb.CreateCardRequest{
+		{Front: "capital of France", Back: "Paris", DeckId: 1, Author: "alice"},
+		{Front: "capital of Italy", DeckId: 1},
+	}}
+
+	enc := zapcore.NewMapObjectEncoder()
+	Proto("request", req).AddTo(enc)
+
+	b, err := json.Marshal(enc.Fields["request"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"cards": [
+		{"front": "[REDACTED]", "back": "[REDACTED]", "deckId": "1", "author": "alice"},
+		{"front": "[REDACTED]", "deckId": "1"}
+	]}`, string(b))
+
+	assert.Equal(t, "Paris", req.Cards[0].Back, "the request itself is left alone")
+}
@@ -0,0 +1,142 @@
+package render
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"regexp"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+type Format string
+
+const (
+	FormatPlain    Format = "plain"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+const DefaultCacheSize = 1024
+
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case "", FormatPlain:
+		return FormatPlain, nil
+	case FormatMarkdown, FormatHTML:
+		return Format(s), nil
+	default:
+		return "", fmt.Errorf("unknown card format: %q", s)
+	}
+}
+
+// Renderer turns card content into sanitized HTML. Rendered output is kept in
+// an LRU cache keyed by the content hash, so repeated reads of the same card
+// do not parse and sanitize it again.
+type Renderer struct {
+	markdown goldmark.Markdown
+	policy   *bluemonday.Policy
+
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key  string
+	html string
+}
+
+func NewRenderer(cacheSize int) *Renderer {
+	if cacheSize <= 0 {
+		cacheSize = DefaultCacheSize
+	}
+
+	return &Renderer{
+		markdown: goldmark.New(
+			goldmark.WithExtensions(extension.GFM),
+			goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+		),
+		policy:   newPolicy(),
+		capacity: cacheSize,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// newPolicy builds the allowlist applied to every rendered card. It is the
+// UGC policy plus the class names goldmark puts on fenced code blocks.
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	p.AllowAttrs("align").Matching(regexp.MustCompile(`^(left|center|right)$`)).OnElements("th", "td")
+	return p
+}
+
+func (r *Renderer) Render(format Format, source string) (string, error) {
+	key := cacheKey(format, source)
+	if cached, ok := r.get(key); ok {
+		return cached, nil
+	}
+
+	var out string
+	switch format {
+	case FormatPlain:
+		out = html.EscapeString(source)
+	case FormatMarkdown:
+		var buf bytes.Buffer
+		if err := r.markdown.Convert([]byte(source), &buf); err != nil {
+			return "", fmt.Errorf("render markdown: %w", err)
+		}
+		out = r.policy.Sanitize(buf.String())
+	case FormatHTML:
+		out = r.policy.Sanitize(source)
+	default:
+		return "", fmt.Errorf("unknown card format: %q", format)
+	}
+
+	r.put(key, out)
+	return out, nil
+}
+
+func (r *Renderer) get(key string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	elem, ok := r.entries[key]
+	if !ok {
+		return "", false
+	}
+	r.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).html, true
+}
+
+func (r *Renderer) put(key, value string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if elem, ok := r.entries[key]; ok {
+		r.order.MoveToFront(elem)
+		return
+	}
+
+	r.entries[key] = r.order.PushFront(&cacheEntry{key: key, html: value})
+	if r.order.Len() > r.capacity {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func cacheKey(format Format, source string) string {
+	sum := sha256.Sum256([]byte(string(format) + "\x00" + source))
+	return hex.EncodeToString(sum[:])
+}
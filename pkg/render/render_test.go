@@ -0,0 +1,109 @@
+//go:build unit
+// +build unit
+
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{input: "", want: FormatPlain},
+		{input: "plain", want: FormatPlain},
+		{input: "markdown", want: FormatMarkdown},
+		{input: "html", want: FormatHTML},
+		{input: "rtf", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFormat(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRenderer_Render(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      Format
+		source      string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "Plain Text Is Escaped",
+			format:   FormatPlain,
+			source:   "1 < 2 & <b>bold</b>",
+			contains: []string{"1 &lt; 2 &amp; &lt;b&gt;bold&lt;/b&gt;"},
+		},
+		{
+			name:     "Markdown Fenced Code",
+			format:   FormatMarkdown,
+			source:   "```go\nfmt.Println(\"hi\")\n```",
+			contains: []string{`<pre><code class="language-go">`, "fmt.Println"},
+		},
+		{
+			name:     "Markdown Table",
+			format:   FormatMarkdown,
+			source:   "| word | translation |\n|---|---|\n| cat | кот |",
+			contains: []string{"<table>", "<th>word</th>", "<td>кот</td>"},
+		},
+		{
+			name:        "Markdown Script Is Stripped",
+			format:      FormatMarkdown,
+			source:      "hello <script>alert(1)</script>",
+			contains:    []string{"hello"},
+			notContains: []string{"<script", "alert(1)"},
+		},
+		{
+			name:        "HTML Event Handlers Are Stripped",
+			format:      FormatHTML,
+			source:      `<p onclick="steal()">text</p><a href="javascript:alert(1)">link</a>`,
+			contains:    []string{"<p>text</p>", "link"},
+			notContains: []string{"onclick", "javascript:"},
+		},
+	}
+
+	r := NewRenderer(0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := r.Render(tt.format, tt.source)
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, out, s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, out, s)
+			}
+		})
+	}
+}
+
+func TestRenderer_CacheEviction(t *testing.T) {
+	r := NewRenderer(2)
+
+	for _, src := range []string{"one", "two", "three"} {
+		_, err := r.Render(FormatMarkdown, src)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, r.order.Len())
+	_, ok := r.get(cacheKey(FormatMarkdown, "one"))
+	assert.False(t, ok)
+	_, ok = r.get(cacheKey(FormatMarkdown, "three"))
+	assert.True(t, ok)
+}
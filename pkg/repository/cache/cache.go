@@ -0,0 +1,220 @@
+// Package cache decorates the deck and card repositories with a read-through
+// cache of GetByID and GetWithCardsByID. Writes made through the decorators
+// evict the entries they make stale and publish the evicted keys, so that the
+// other replicas can evict them from their own caches.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/logger"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// InvalidateEvent is the type of the events that carry evicted keys, separated
+// by spaces.
+const InvalidateEvent = "InvalidateCache"
+
+// generationSlots is the number of eviction counters keys are spread over.
+const generationSlots = 256
+
+// ErrMiss is returned by Backend.Get for keys that are not cached.
+var ErrMiss = errors.New("cache miss")
+
+// Backend stores the cached entries.
+type Backend interface {
+	// Get fails with ErrMiss when key is not cached or has expired.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// EventSender publishes evicted keys to the other replicas.
+// kafka.EventSender implements it.
+type EventSender interface {
+	SendEvent(ctx context.Context, eventType, query string) error
+}
+
+// Cache is shared by the repository decorators. Reads inside a transaction
+// started through TxManager skip the cache, and the keys written there are
+// evicted once the transaction is over.
+type Cache struct {
+	backend Backend
+	ttl     time.Duration
+	events  EventSender
+
+	// generations count the evictions of the keys that hash to each slot. A
+	// load caches what it fetched only if no key of its slot was evicted
+	// meanwhile, so that a write evicting the key between the fetch and the
+	// Set does not leave the old value cached. Writes on other replicas
+	// count once their InvalidateEvent arrives, which evicts the key again.
+	generations [generationSlots]atomic.Uint64
+}
+
+// New returns a cache whose entries expire after ttl. events may be nil for a
+// single replica.
+func New(backend Backend, ttl time.Duration, events EventSender) *Cache {
+	return &Cache{backend: backend, ttl: ttl, events: events}
+}
+
+// HandleEvent evicts the keys of an InvalidateEvent. Other events are
+// ignored.
+func (c *Cache) HandleEvent(ctx context.Context, eventType, query string) {
+	if eventType == InvalidateEvent {
+		c.evict(ctx, strings.Fields(query))
+	}
+}
+
+type bypassKey struct{}
+
+// WithBypass returns a context whose reads go to the repository and leave the
+// cache as it is.
+func WithBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+// Bypassed reports whether ctx came from WithBypass.
+func Bypassed(ctx context.Context) bool {
+	return ctx.Value(bypassKey{}) != nil
+}
+
+// pending collects the keys written inside a transaction.
+type pending struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+type pendingKey struct{}
+
+// TxManager wraps tx so that the cache knows which reads and writes happen in
+// a transaction.
+func (c *Cache) TxManager(tx db.TxManager) db.TxManager {
+	return txManager{tx: tx, cache: c}
+}
+
+type txManager struct {
+	tx    db.TxManager
+	cache *Cache
+}
+
+func (m txManager) RunInTx(ctx context.Context, opts db.TxOptions, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(pendingKey{}).(*pending); ok {
+		return m.tx.RunInTx(ctx, opts, fn)
+	}
+
+	p := &pending{}
+	err := m.tx.RunInTx(context.WithValue(ctx, pendingKey{}, p), opts, fn)
+	// Evicted after a rollback too: the keys may have been filled in the
+	// meantime from what the transaction was about to change.
+	m.cache.invalidate(ctx, p.keys)
+	return err
+}
+
+// load returns the entry of key, or fetches and caches it on a miss. Errors
+// of the backend are logged and the repository is used instead.
+func load[T any](ctx context.Context, c *Cache, kind, key string, fetch func(ctx context.Context) (*T, error)) (*T, error) {
+	if Bypassed(ctx) || ctx.Value(pendingKey{}) != nil {
+		requests.WithLabelValues(kind, "bypass").Inc()
+		return fetch(ctx)
+	}
+
+	data, err := c.backend.Get(ctx, key)
+	if err == nil {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			requests.WithLabelValues(kind, "hit").Inc()
+			return &value, nil
+		}
+	} else if !errors.Is(err, ErrMiss) {
+		logger.Error(ctx, "Failed to read from cache", zap.String("key", key), zap.Error(err))
+	}
+	requests.WithLabelValues(kind, "miss").Inc()
+
+	generation := c.generation(key)
+	before := generation.Load()
+	value, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if generation.Load() != before {
+		return value, nil
+	}
+	if data, err := json.Marshal(value); err == nil {
+		if err := c.backend.Set(ctx, key, data, c.ttl); err != nil {
+			logger.Error(ctx, "Failed to write to cache", zap.String("key", key), zap.Error(err))
+		}
+		// An eviction that came in during the Set may have run before it.
+		if generation.Load() != before {
+			c.evict(ctx, []string{key})
+		}
+	}
+	return value, nil
+}
+
+func (c *Cache) generation(key string) *atomic.Uint64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return &c.generations[h.Sum32()%generationSlots]
+}
+
+// invalidate evicts keys and publishes them, or leaves that to the end of the
+// transaction in ctx.
+func (c *Cache) invalidate(ctx context.Context, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	if p, ok := ctx.Value(pendingKey{}).(*pending); ok {
+		p.mu.Lock()
+		p.keys = append(p.keys, keys...)
+		p.mu.Unlock()
+		return
+	}
+
+	keys = unique(keys)
+	c.evict(ctx, keys)
+	if c.events == nil {
+		return
+	}
+	if err := c.events.SendEvent(ctx, InvalidateEvent, strings.Join(keys, " ")); err != nil {
+		logger.Error(ctx, "Failed to publish cache invalidation", zap.Error(err))
+	}
+}
+
+func (c *Cache) evict(ctx context.Context, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	for _, key := range keys {
+		c.generation(key).Add(1)
+	}
+	if err := c.backend.Delete(ctx, keys...); err != nil {
+		logger.Error(ctx, "Failed to evict from cache", zap.Strings("keys", keys), zap.Error(err))
+		return
+	}
+	evictions.Add(float64(len(keys)))
+}
+
+func unique(keys []string) []string {
+	seen := make(map[string]bool, len(keys))
+	result := keys[:0]
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+func deckKey(id int64) string      { return fmt.Sprintf("deck:%d", id) }
+func deckCardsKey(id int64) string { return fmt.Sprintf("deck-cards:%d", id) }
+func cardKey(id int64) string      { return fmt.Sprintf("card:%d", id) }
@@ -0,0 +1,249 @@
+//go:build unit
+// +build unit
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/memory"
+	"flash-card-manager/pkg/repository/structs"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// replica is one server: its own cache in front of the shared store.
+type replica struct {
+	cache *Cache
+	decks *Deck
+	cards *Card
+	tx    db.TxManager
+}
+
+// cluster is the replicas of a test and the store they share. The events one
+// publishes reach all of them, as they would through Kafka.
+type cluster struct {
+	store    *memory.Store
+	decks    interfaces.DeckRepository
+	cards    interfaces.CardRepository
+	replicas []*replica
+}
+
+func newCluster(t *testing.T, replicas int) *cluster {
+	store := memory.NewStore()
+	c := &cluster{store: store, decks: memory.NewDeck(store), cards: memory.NewCard(store)}
+	for i := 0; i < replicas; i++ {
+		cache := New(NewLRU(100), time.Minute, c)
+		c.replicas = append(c.replicas, &replica{
+			cache: cache,
+			decks: NewDeck(c.decks, cache),
+			cards: NewCard(c.cards, cache),
+			tx:    cache.TxManager(store),
+		})
+	}
+	return c
+}
+
+func (c *cluster) SendEvent(ctx context.Context, eventType, query string) error {
+	for _, r := range c.replicas {
+		r.cache.HandleEvent(ctx, eventType, query)
+	}
+	return nil
+}
+
+func (c *cluster) addDeck(t *testing.T, title string) int64 {
+	id, err := c.decks.Add(context.Background(), structs.Deck{Title: title, Author: "Author"})
+	require.NoError(t, err)
+	return id
+}
+
+func (c *cluster) addCard(t *testing.T, deckID int64, front string) int64 {
+	id, err := c.cards.Add(context.Background(), structs.Card{Front: front, Back: "Back", DeckID: deckID, Author: "Author", Format: "plain"})
+	require.NoError(t, err)
+	return id
+}
+
+func fronts(t *testing.T, decks interfaces.DeckRepository, deckID int64) []string {
+	deck, err := decks.GetWithCardsByID(context.Background(), deckID)
+	require.NoError(t, err)
+	var fronts []string
+	for _, card := range deck.Cards {
+		fronts = append(fronts, card.Front)
+	}
+	return fronts
+}
+
+func TestReadThrough(t *testing.T) {
+	ctx := context.Background()
+	c := newCluster(t, 1)
+	r := c.replicas[0]
+	deckID := c.addDeck(t, "Title")
+
+	hits := testutil.ToFloat64(requests.WithLabelValues("deck", "hit"))
+	misses := testutil.ToFloat64(requests.WithLabelValues("deck", "miss"))
+
+	deck, err := r.decks.GetByID(ctx, deckID)
+	require.NoError(t, err)
+	assert.Equal(t, "Title", deck.Title)
+
+	// Changed behind the cache's back, the deck is served from the cache.
+	_, err = c.decks.Update(ctx, structs.Deck{ID: deckID, Title: "Changed", Author: "Author"}, "editor")
+	require.NoError(t, err)
+	deck, err = r.decks.GetByID(ctx, deckID)
+	require.NoError(t, err)
+	assert.Equal(t, "Title", deck.Title)
+
+	assert.Equal(t, hits+1, testutil.ToFloat64(requests.WithLabelValues("deck", "hit")))
+	assert.Equal(t, misses+1, testutil.ToFloat64(requests.WithLabelValues("deck", "miss")))
+
+	// Unless the request bypasses it.
+	deck, err = r.decks.GetByID(WithBypass(ctx), deckID)
+	require.NoError(t, err)
+	assert.Equal(t, "Changed", deck.Title)
+
+	// Missing decks are not cached.
+	_, err = r.decks.GetByID(ctx, deckID+1)
+	assert.Error(t, err)
+	newID := c.addDeck(t, "New")
+	require.Equal(t, deckID+1, newID)
+	deck, err = r.decks.GetByID(ctx, newID)
+	require.NoError(t, err)
+	assert.Equal(t, "New", deck.Title)
+}
+
+// racingDecks runs write after fetching a deck and before load caches it.
+type racingDecks struct {
+	interfaces.DeckRepository
+	write func()
+}
+
+func (d *racingDecks) GetByID(ctx context.Context, id int64) (*structs.Deck, error) {
+	deck, err := d.DeckRepository.GetByID(ctx, id)
+	if d.write != nil {
+		d.write()
+		d.write = nil
+	}
+	return deck, err
+}
+
+func TestWriteDuringLoad(t *testing.T) {
+	ctx := context.Background()
+	c := newCluster(t, 1)
+	r := c.replicas[0]
+	deckID := c.addDeck(t, "Title")
+
+	racing := &racingDecks{DeckRepository: c.decks}
+	decks := NewDeck(racing, r.cache)
+	racing.write = func() {
+		_, err := decks.Update(ctx, structs.Deck{ID: deckID, Title: "Changed", Author: "Author"}, "editor")
+		require.NoError(t, err)
+	}
+
+	deck, err := decks.GetByID(ctx, deckID)
+	require.NoError(t, err)
+	assert.Equal(t, "Title", deck.Title)
+
+	// The deck fetched before the write is not cached.
+	deck, err = decks.GetByID(ctx, deckID)
+	require.NoError(t, err)
+	assert.Equal(t, "Changed", deck.Title)
+}
+
+func TestWritesEvict(t *testing.T) {
+	ctx := context.Background()
+	c := newCluster(t, 2)
+	writer, reader := c.replicas[0], c.replicas[1]
+
+	first, second := c.addDeck(t, "First"), c.addDeck(t, "Second")
+	cardID := c.addCard(t, first, "Front")
+
+	// Both replicas cache both decks and the card.
+	for _, r := range c.replicas {
+		assert.Equal(t, []string{"Front"}, fronts(t, r.decks, first))
+		assert.Empty(t, fronts(t, r.decks, second))
+		_, err := r.cards.GetByID(ctx, cardID)
+		require.NoError(t, err)
+	}
+
+	_, err := writer.cards.Move(ctx, []int64{cardID}, second, "editor")
+	require.NoError(t, err)
+
+	// The event of the move reaches the other replica.
+	for _, r := range []*replica{writer, reader} {
+		assert.Empty(t, fronts(t, r.decks, first))
+		assert.Equal(t, []string{"Front"}, fronts(t, r.decks, second))
+		card, err := r.cards.GetByID(ctx, cardID)
+		require.NoError(t, err)
+		assert.Equal(t, second, card.DeckID)
+	}
+
+	_, err = writer.decks.Patch(ctx, structs.Deck{ID: second, Title: "Renamed"}, []string{"title"}, "editor")
+	require.NoError(t, err)
+	deck, err := reader.decks.GetByID(ctx, second)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", deck.Title)
+
+	// Deleting the deck takes its cards along.
+	require.NoError(t, writer.decks.Delete(ctx, second))
+	_, err = reader.cards.GetByID(ctx, cardID)
+	assert.Error(t, err)
+}
+
+func TestTransaction(t *testing.T) {
+	ctx := context.Background()
+	c := newCluster(t, 1)
+	r := c.replicas[0]
+	deckID := c.addDeck(t, "Title")
+	cardID := c.addCard(t, deckID, "Front")
+
+	_, err := r.cards.GetByID(ctx, cardID)
+	require.NoError(t, err)
+
+	errRollback := errors.New("rollback")
+	err = r.tx.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		_, err := r.cards.Patch(ctx, structs.Card{ID: cardID, Front: "Changed"}, []string{"front"}, "editor")
+		require.NoError(t, err)
+
+		// Reads in the transaction see its writes and are not cached.
+		card, err := r.cards.GetByID(ctx, cardID)
+		require.NoError(t, err)
+		assert.Equal(t, "Changed", card.Front)
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+
+	card, err := r.cards.GetByID(ctx, cardID)
+	require.NoError(t, err)
+	assert.Equal(t, "Front", card.Front)
+
+	err = r.tx.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		_, err := r.cards.Patch(ctx, structs.Card{ID: cardID, Front: "Changed"}, []string{"front"}, "editor")
+		return err
+	})
+	require.NoError(t, err)
+
+	card, err = r.cards.GetByID(ctx, cardID)
+	require.NoError(t, err)
+	assert.Equal(t, "Changed", card.Front)
+}
+
+func TestRestoreCard(t *testing.T) {
+	ctx := context.Background()
+	c := newCluster(t, 1)
+	r := c.replicas[0]
+	trash := NewTrash(memory.NewTrash(c.store), c.cards, r.cache)
+	deckID := c.addDeck(t, "Title")
+	cardID := c.addCard(t, deckID, "Front")
+
+	require.NoError(t, r.cards.Delete(ctx, cardID))
+	assert.Empty(t, fronts(t, r.decks, deckID))
+
+	require.NoError(t, trash.RestoreCard(ctx, cardID))
+	assert.Equal(t, []string{"Front"}, fronts(t, r.decks, deckID))
+}
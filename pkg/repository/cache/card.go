@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"context"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+)
+
+// Card caches GetByID of a CardRepository. Writes also evict the decks the
+// cards leave and join; to find the decks they leave, the cards are read from
+// the repository before they are changed.
+type Card struct {
+	interfaces.CardRepository
+	cache *Cache
+}
+
+func NewCard(repo interfaces.CardRepository, cache *Cache) *Card {
+	return &Card{CardRepository: repo, cache: cache}
+}
+
+func (c *Card) GetByID(ctx context.Context, id int64) (*structs.Card, error) {
+	return load(ctx, c.cache, "card", cardKey(id), func(ctx context.Context) (*structs.Card, error) {
+		return c.CardRepository.GetByID(ctx, id)
+	})
+}
+
+func (c *Card) Add(ctx context.Context, card structs.Card) (int64, error) {
+	id, err := c.CardRepository.Add(ctx, card)
+	if err == nil {
+		c.cache.invalidate(ctx, []string{deckCardsKey(card.DeckID)})
+	}
+	return id, err
+}
+
+func (c *Card) BatchAdd(ctx context.Context, cards []structs.Card) ([]int64, error) {
+	ids, err := c.CardRepository.BatchAdd(ctx, cards)
+	if err == nil {
+		keys := make([]string, 0, len(cards))
+		for _, card := range cards {
+			keys = append(keys, deckCardsKey(card.DeckID))
+		}
+		c.cache.invalidate(ctx, keys)
+	}
+	return ids, err
+}
+
+func (c *Card) Update(ctx context.Context, card structs.Card, editor string) (int64, error) {
+	keys := append(c.storedKeys(ctx, card.ID), deckCardsKey(card.DeckID))
+	version, err := c.CardRepository.Update(ctx, card, editor)
+	if err == nil {
+		c.cache.invalidate(ctx, keys)
+	}
+	return version, err
+}
+
+func (c *Card) Patch(ctx context.Context, card structs.Card, fields []string, editor string) (int64, error) {
+	keys := c.storedKeys(ctx, card.ID)
+	for _, field := range fields {
+		if field == "deck_id" {
+			keys = append(keys, deckCardsKey(card.DeckID))
+		}
+	}
+	version, err := c.CardRepository.Patch(ctx, card, fields, editor)
+	if err == nil {
+		c.cache.invalidate(ctx, keys)
+	}
+	return version, err
+}
+
+func (c *Card) BatchUpdate(ctx context.Context, cards []structs.Card, editor string) ([]int64, error) {
+	ids := make([]int64, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+	keys := c.storedKeys(ctx, ids...)
+	for _, card := range cards {
+		keys = append(keys, deckCardsKey(card.DeckID))
+	}
+
+	versions, err := c.CardRepository.BatchUpdate(ctx, cards, editor)
+	if err == nil {
+		c.cache.invalidate(ctx, keys)
+	}
+	return versions, err
+}
+
+func (c *Card) Delete(ctx context.Context, id int64) error {
+	keys := c.storedKeys(ctx, id)
+	if err := c.CardRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	c.cache.invalidate(ctx, keys)
+	return nil
+}
+
+func (c *Card) BatchDelete(ctx context.Context, ids []int64) error {
+	keys := c.storedKeys(ctx, ids...)
+	if err := c.CardRepository.BatchDelete(ctx, ids); err != nil {
+		return err
+	}
+	c.cache.invalidate(ctx, keys)
+	return nil
+}
+
+func (c *Card) Move(ctx context.Context, ids []int64, deckID int64, editor string) ([]int64, error) {
+	keys := append(c.storedKeys(ctx, ids...), deckCardsKey(deckID))
+	versions, err := c.CardRepository.Move(ctx, ids, deckID, editor)
+	if err == nil {
+		c.cache.invalidate(ctx, keys)
+	}
+	return versions, err
+}
+
+// storedKeys returns the keys of the cards and of the decks they are in now.
+// Cards that cannot be read are left for the write to report.
+func (c *Card) storedKeys(ctx context.Context, ids ...int64) []string {
+	keys := make([]string, 0, 2*len(ids))
+	for _, id := range ids {
+		keys = append(keys, cardKey(id))
+		if card, err := c.CardRepository.GetByID(ctx, id); err == nil {
+			keys = append(keys, deckCardsKey(card.DeckID))
+		}
+	}
+	return keys
+}
@@ -0,0 +1,28 @@
+//go:build unit
+// +build unit
+
+package cache
+
+import (
+	"flash-card-manager/pkg/repository/memory"
+	"flash-card-manager/tests/conformance"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// TestConformance checks that reads through the cache see every write.
+func TestConformance(t *testing.T) {
+	suite.Run(t, &conformance.Suite{New: func(t *testing.T) conformance.Repositories {
+		store := memory.NewStore()
+		cache := New(NewLRU(100), time.Minute, nil)
+		return conformance.Repositories{
+			Cards: NewCard(memory.NewCard(store), cache),
+			Decks: NewDeck(memory.NewDeck(store), cache),
+			Trash: NewTrash(memory.NewTrash(store), memory.NewCard(store), cache),
+			Usage: memory.NewUsage(store),
+			Tx:    cache.TxManager(store),
+		}
+	}})
+}
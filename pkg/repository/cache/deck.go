@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+)
+
+// Deck caches GetByID and GetWithCardsByID of a DeckRepository. Revisions are
+// read from the repository every time.
+type Deck struct {
+	interfaces.DeckRepository
+	cache *Cache
+}
+
+func NewDeck(repo interfaces.DeckRepository, cache *Cache) *Deck {
+	return &Deck{DeckRepository: repo, cache: cache}
+}
+
+func (d *Deck) GetByID(ctx context.Context, id int64) (*structs.Deck, error) {
+	return load(ctx, d.cache, "deck", deckKey(id), func(ctx context.Context) (*structs.Deck, error) {
+		return d.DeckRepository.GetByID(ctx, id)
+	})
+}
+
+func (d *Deck) GetWithCardsByID(ctx context.Context, id int64) (*structs.DeckWithCards, error) {
+	return load(ctx, d.cache, "deck_cards", deckCardsKey(id), func(ctx context.Context) (*structs.DeckWithCards, error) {
+		return d.DeckRepository.GetWithCardsByID(ctx, id)
+	})
+}
+
+func (d *Deck) Update(ctx context.Context, deck structs.Deck, editor string) (int64, error) {
+	version, err := d.DeckRepository.Update(ctx, deck, editor)
+	if err == nil {
+		d.cache.invalidate(ctx, []string{deckKey(deck.ID), deckCardsKey(deck.ID)})
+	}
+	return version, err
+}
+
+func (d *Deck) Patch(ctx context.Context, deck structs.Deck, fields []string, editor string) (int64, error) {
+	version, err := d.DeckRepository.Patch(ctx, deck, fields, editor)
+	if err == nil {
+		d.cache.invalidate(ctx, []string{deckKey(deck.ID), deckCardsKey(deck.ID)})
+	}
+	return version, err
+}
+
+// Delete also evicts the cards of the deck, which go to the trash with it.
+func (d *Deck) Delete(ctx context.Context, id int64) error {
+	keys := []string{deckKey(id), deckCardsKey(id)}
+	if deck, err := d.DeckRepository.GetWithCardsByID(ctx, id); err == nil {
+		for _, card := range deck.Cards {
+			keys = append(keys, cardKey(card.ID))
+		}
+	}
+
+	if err := d.DeckRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	d.cache.invalidate(ctx, keys)
+	return nil
+}
@@ -0,0 +1,50 @@
+//go:build unit
+// +build unit
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	lru := NewLRU(2)
+	lru.now = func() time.Time { return now }
+
+	get := func(key string) string {
+		value, err := lru.Get(ctx, key)
+		if err != nil {
+			require.ErrorIs(t, err, ErrMiss)
+			return ""
+		}
+		return string(value)
+	}
+
+	require.NoError(t, lru.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, lru.Set(ctx, "b", []byte("2"), time.Minute))
+	assert.Equal(t, "1", get("a"))
+
+	// b is the least recently used.
+	require.NoError(t, lru.Set(ctx, "c", []byte("3"), time.Minute))
+	assert.Equal(t, 2, lru.Len())
+	assert.Equal(t, "", get("b"))
+	assert.Equal(t, "1", get("a"))
+	assert.Equal(t, "3", get("c"))
+
+	require.NoError(t, lru.Set(ctx, "a", []byte("4"), time.Second))
+	assert.Equal(t, "4", get("a"))
+	now = now.Add(time.Second)
+	assert.Equal(t, "", get("a"))
+	assert.Equal(t, "3", get("c"))
+
+	require.NoError(t, lru.Delete(ctx, "c", "missing"))
+	assert.Equal(t, "", get("c"))
+	assert.Equal(t, 0, lru.Len())
+}
@@ -0,0 +1,21 @@
+package cache
+
+import (
+	"flash-card-manager/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	requests = promauto.With(metrics.Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "repository_cache_requests_total",
+		Help:      "Reads through the repository cache by kind of entry and result: hit, miss or bypass.",
+	}, []string{"kind", "result"})
+	evictions = promauto.With(metrics.Registry).NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Name:      "repository_cache_evictions_total",
+		Help:      "Keys evicted from the repository cache by writes and invalidation events.",
+	})
+)
@@ -0,0 +1,171 @@
+//go:build unit
+// +build unit
+
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis serves the commands Redis sends from a map, ignoring expiry.
+type fakeRedis struct {
+	password string
+
+	mu       sync.Mutex
+	values   map[string]string
+	commands []string
+}
+
+func serveFakeRedis(t *testing.T, password string) (*fakeRedis, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	f := &fakeRedis{password: password, values: map[string]string{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f, listener.Addr().String()
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authed := f.password == ""
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		f.commands = append(f.commands, strings.Join(args, " "))
+		var reply string
+		switch {
+		case args[0] == "AUTH":
+			authed = args[len(args)-1] == f.password
+			reply = "+OK\r\n"
+			if !authed {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authed:
+			reply = "-NOAUTH Authentication required\r\n"
+		case args[0] == "PING":
+			reply = "+PONG\r\n"
+		case args[0] == "SELECT":
+			reply = "+OK\r\n"
+		case args[0] == "GET":
+			if value, ok := f.values[args[1]]; ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			} else {
+				reply = "$-1\r\n"
+			}
+		case args[0] == "SET":
+			f.values[args[1]] = args[2]
+			reply = "+OK\r\n"
+		case args[0] == "DEL":
+			for _, key := range args[1:] {
+				delete(f.values, key)
+			}
+			reply = fmt.Sprintf(":%d\r\n", len(args)-1)
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		f.mu.Unlock()
+
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+	return args, nil
+}
+
+func TestRedis(t *testing.T) {
+	ctx := context.Background()
+	server, addr := serveFakeRedis(t, "secret")
+
+	redis, err := NewRedis("redis://:secret@" + addr + "/2")
+	require.NoError(t, err)
+	t.Cleanup(func() { redis.Close() })
+
+	require.NoError(t, redis.Ping(ctx))
+
+	_, err = redis.Get(ctx, "deck:1")
+	assert.ErrorIs(t, err, ErrMiss)
+
+	value := []byte("{\"Title\":\"line\\r\\nbreak\"}")
+	require.NoError(t, redis.Set(ctx, "deck:1", value, 5*time.Second))
+	got, err := redis.Get(ctx, "deck:1")
+	require.NoError(t, err)
+	assert.Equal(t, value, got)
+
+	require.NoError(t, redis.Delete(ctx, "deck:1", "deck-cards:1"))
+	_, err = redis.Get(ctx, "deck:1")
+	assert.ErrorIs(t, err, ErrMiss)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	// One connection, set up once and reused.
+	assert.Equal(t, []string{"AUTH secret", "SELECT 2", "PING"}, server.commands[:3])
+	assert.Contains(t, server.commands, "SET deck:1 "+string(value)+" PX 5000")
+	assert.Equal(t, 1, strings.Count(strings.Join(server.commands, "\n"), "AUTH"))
+}
+
+func TestRedisErrors(t *testing.T) {
+	ctx := context.Background()
+	_, addr := serveFakeRedis(t, "secret")
+
+	redis, err := NewRedis("redis://:wrong@" + addr)
+	require.NoError(t, err)
+	assert.ErrorContains(t, redis.Ping(ctx), "WRONGPASS")
+
+	_, err = NewRedis("http://" + addr)
+	assert.Error(t, err)
+	_, err = NewRedis("redis://" + addr + "/first")
+	assert.Error(t, err)
+}
@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"context"
+	"flash-card-manager/pkg/repository/interfaces"
+)
+
+// Trash evicts what restoring from a TrashRepository brings back. cards is the
+// card repository without the cache, used to find the deck of a restored
+// card.
+type Trash struct {
+	interfaces.TrashRepository
+	cards interfaces.CardRepository
+	cache *Cache
+}
+
+func NewTrash(repo interfaces.TrashRepository, cards interfaces.CardRepository, cache *Cache) *Trash {
+	return &Trash{TrashRepository: repo, cards: cards, cache: cache}
+}
+
+func (t *Trash) RestoreDeck(ctx context.Context, id int64) (int64, error) {
+	restored, err := t.TrashRepository.RestoreDeck(ctx, id)
+	if err == nil {
+		t.cache.invalidate(ctx, []string{deckKey(id), deckCardsKey(id)})
+	}
+	return restored, err
+}
+
+func (t *Trash) RestoreCard(ctx context.Context, id int64) error {
+	if err := t.TrashRepository.RestoreCard(ctx, id); err != nil {
+		return err
+	}
+
+	keys := []string{cardKey(id)}
+	if card, err := t.cards.GetByID(ctx, id); err == nil {
+		keys = append(keys, deckCardsKey(card.DeckID))
+	}
+	t.cache.invalidate(ctx, keys)
+	return nil
+}
@@ -3,11 +3,32 @@ package repository
 import (
 	"flash-card-manager/pkg/db"
 	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/memory"
 	"flash-card-manager/pkg/repository/postgresql"
+	"flash-card-manager/pkg/repository/sqlite"
+	"fmt"
 )
 
-func InitRepositories(database db.DatabaseInterface) (interfaces.CardRepository, interfaces.DeckRepository) {
-	cardRepo := postgresql.NewCard(database)
-	deckRepo := postgresql.NewDeck(database)
-	return cardRepo, deckRepo
+// InitRepositories returns the repositories for the driver behind database.
+func InitRepositories(database db.DatabaseInterface) (interfaces.CardRepository, interfaces.DeckRepository, interfaces.TrashRepository, interfaces.IdempotencyRepository, interfaces.UsageRepository, error) {
+	switch database := database.(type) {
+	case *db.SQLiteDatabase:
+		return sqlite.NewCard(database), sqlite.NewDeck(database), sqlite.NewTrash(database), sqlite.NewIdempotency(database), sqlite.NewUsage(database), nil
+	case db.PostgresInterface:
+		return postgresql.NewCard(database), postgresql.NewDeck(database), postgresql.NewTrash(database), postgresql.NewIdempotency(database), postgresql.NewUsage(database), nil
+	default:
+		return nil, nil, nil, nil, nil, fmt.Errorf("no repositories for database %T", database)
+	}
+}
+
+// InitMemoryRepositories returns repositories that keep everything in memory,
+// along with the TxManager for their transactions. Nothing survives a restart.
+func InitMemoryRepositories() (interfaces.CardRepository, interfaces.DeckRepository, interfaces.TrashRepository, interfaces.IdempotencyRepository, interfaces.UsageRepository, db.TxManager) {
+	store := memory.NewStore()
+	cardRepo := memory.NewCard(store)
+	deckRepo := memory.NewDeck(store)
+	trashRepo := memory.NewTrash(store)
+	idempotencyRepo := memory.NewIdempotency()
+	usageRepo := memory.NewUsage(store)
+	return cardRepo, deckRepo, trashRepo, idempotencyRepo, usageRepo, store
 }
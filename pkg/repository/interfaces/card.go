@@ -10,5 +10,19 @@ type CardRepository interface {
 	Add(ctx context.Context, card structs.Card) (int64, error)
 	Delete(ctx context.Context, id int64) error
 	GetByID(ctx context.Context, id int64) (*structs.Card, error)
-	Update(ctx context.Context, card structs.Card) (int64, error)
+	// Update returns the card's new version, or 0 when the card does not exist.
+	// A non-zero card.Version makes the update conditional on that version.
+	Update(ctx context.Context, card structs.Card, editor string) (int64, error)
+	// Patch is Update restricted to the given columns; the other fields of
+	// card are ignored.
+	Patch(ctx context.Context, card structs.Card, fields []string, editor string) (int64, error)
+	// BatchAdd, BatchUpdate, BatchDelete and Move are all-or-nothing. When a
+	// single item is to blame they fail with *structs.BatchError naming it.
+	BatchAdd(ctx context.Context, cards []structs.Card) ([]int64, error)
+	BatchUpdate(ctx context.Context, cards []structs.Card, editor string) ([]int64, error)
+	BatchDelete(ctx context.Context, ids []int64) error
+	Move(ctx context.Context, ids []int64, deckID int64, editor string) ([]int64, error)
+	// ListRevisions returns a page of the card's revisions, newest first.
+	ListRevisions(ctx context.Context, cardID int64, page structs.Page) ([]structs.CardRevision, error)
+	GetRevision(ctx context.Context, cardID, revisionID int64) (*structs.CardRevision, error)
 }
@@ -10,6 +10,13 @@ type DeckRepository interface {
 	Add(ctx context.Context, deck structs.Deck) (int64, error)
 	Delete(ctx context.Context, id int64) error
 	GetByID(ctx context.Context, id int64) (*structs.Deck, error)
-	Update(ctx context.Context, deck structs.Deck) (int64, error)
+	// Update returns the deck's new version, or 0 when the deck does not exist.
+	// A non-zero deck.Version makes the update conditional on that version.
+	Update(ctx context.Context, deck structs.Deck, editor string) (int64, error)
+	// Patch is Update restricted to the given columns; the other fields of
+	// deck are ignored.
+	Patch(ctx context.Context, deck structs.Deck, fields []string, editor string) (int64, error)
 	GetWithCardsByID(ctx context.Context, id int64) (*structs.DeckWithCards, error)
+	ListRevisions(ctx context.Context, deckID int64) ([]structs.DeckRevision, error)
+	GetRevision(ctx context.Context, deckID, revisionID int64) (*structs.DeckRevision, error)
 }
@@ -0,0 +1,20 @@
+//go:generate mockgen -source ./idempotency.go -destination=./mocks/mock_idempotency.go -package=mock_idempotency
+package interfaces
+
+import (
+	"context"
+	"flash-card-manager/pkg/repository/structs"
+)
+
+type IdempotencyRepository interface {
+	// Claim stores record unless its key is already held by a record that has
+	// not expired. It returns nil when the key was claimed and the holding
+	// record otherwise.
+	Claim(ctx context.Context, record structs.IdempotencyRecord) (*structs.IdempotencyRecord, error)
+	// Complete saves the response and header of record for the request that
+	// claimed its key with its request hash, and keeps them until its
+	// ExpiresAt. It does nothing if the claim was lost, e.g. after it expired.
+	Complete(ctx context.Context, record structs.IdempotencyRecord) error
+	// Release frees key, e.g. after the request failed, so it can be retried.
+	Release(ctx context.Context, key string) error
+}
@@ -50,6 +50,50 @@ func (mr *MockCardRepositoryMockRecorder) Add(ctx, card interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCardRepository)(nil).Add), ctx, card)
 }
 
+// BatchAdd mocks base method.
+func (m *MockCardRepository) BatchAdd(ctx context.Context, cards []structs.Card) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchAdd", ctx, cards)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchAdd indicates an expected call of BatchAdd.
+func (mr *MockCardRepositoryMockRecorder) BatchAdd(ctx, cards interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchAdd", reflect.TypeOf((*MockCardRepository)(nil).BatchAdd), ctx, cards)
+}
+
+// BatchDelete mocks base method.
+func (m *MockCardRepository) BatchDelete(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDelete", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchDelete indicates an expected call of BatchDelete.
+func (mr *MockCardRepositoryMockRecorder) BatchDelete(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDelete", reflect.TypeOf((*MockCardRepository)(nil).BatchDelete), ctx, ids)
+}
+
+// BatchUpdate mocks base method.
+func (m *MockCardRepository) BatchUpdate(ctx context.Context, cards []structs.Card, editor string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchUpdate", ctx, cards, editor)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchUpdate indicates an expected call of BatchUpdate.
+func (mr *MockCardRepositoryMockRecorder) BatchUpdate(ctx, cards, editor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchUpdate", reflect.TypeOf((*MockCardRepository)(nil).BatchUpdate), ctx, cards, editor)
+}
+
 // Delete mocks base method.
 func (m *MockCardRepository) Delete(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -79,17 +123,77 @@ func (mr *MockCardRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCardRepository)(nil).GetByID), ctx, id)
 }
 
+// GetRevision mocks base method.
+func (m *MockCardRepository) GetRevision(ctx context.Context, cardID, revisionID int64) (*structs.CardRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, cardID, revisionID)
+	ret0, _ := ret[0].(*structs.CardRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockCardRepositoryMockRecorder) GetRevision(ctx, cardID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockCardRepository)(nil).GetRevision), ctx, cardID, revisionID)
+}
+
+// ListRevisions mocks base method.
+func (m *MockCardRepository) ListRevisions(ctx context.Context, cardID int64, page structs.Page) ([]structs.CardRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, cardID, page)
+	ret0, _ := ret[0].([]structs.CardRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockCardRepositoryMockRecorder) ListRevisions(ctx, cardID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockCardRepository)(nil).ListRevisions), ctx, cardID, page)
+}
+
+// Move mocks base method.
+func (m *MockCardRepository) Move(ctx context.Context, ids []int64, deckID int64, editor string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, ids, deckID, editor)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockCardRepositoryMockRecorder) Move(ctx, ids, deckID, editor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockCardRepository)(nil).Move), ctx, ids, deckID, editor)
+}
+
+// Patch mocks base method.
+func (m *MockCardRepository) Patch(ctx context.Context, card structs.Card, fields []string, editor string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, card, fields, editor)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockCardRepositoryMockRecorder) Patch(ctx, card, fields, editor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockCardRepository)(nil).Patch), ctx, card, fields, editor)
+}
+
 // Update mocks base method.
-func (m *MockCardRepository) Update(ctx context.Context, card structs.Card) (int64, error) {
+func (m *MockCardRepository) Update(ctx context.Context, card structs.Card, editor string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, card)
+	ret := m.ctrl.Call(m, "Update", ctx, card, editor)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockCardRepositoryMockRecorder) Update(ctx, card interface{}) *gomock.Call {
+func (mr *MockCardRepositoryMockRecorder) Update(ctx, card, editor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCardRepository)(nil).Update), ctx, card)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCardRepository)(nil).Update), ctx, card, editor)
 }
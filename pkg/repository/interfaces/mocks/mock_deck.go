@@ -79,6 +79,21 @@ func (mr *MockDeckRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDeckRepository)(nil).GetByID), ctx, id)
 }
 
+// GetRevision mocks base method.
+func (m *MockDeckRepository) GetRevision(ctx context.Context, deckID, revisionID int64) (*structs.DeckRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, deckID, revisionID)
+	ret0, _ := ret[0].(*structs.DeckRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockDeckRepositoryMockRecorder) GetRevision(ctx, deckID, revisionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockDeckRepository)(nil).GetRevision), ctx, deckID, revisionID)
+}
+
 // GetWithCardsByID mocks base method.
 func (m *MockDeckRepository) GetWithCardsByID(ctx context.Context, id int64) (*structs.DeckWithCards, error) {
 	m.ctrl.T.Helper()
@@ -94,17 +109,47 @@ func (mr *MockDeckRepositoryMockRecorder) GetWithCardsByID(ctx, id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithCardsByID", reflect.TypeOf((*MockDeckRepository)(nil).GetWithCardsByID), ctx, id)
 }
 
+// ListRevisions mocks base method.
+func (m *MockDeckRepository) ListRevisions(ctx context.Context, deckID int64) ([]structs.DeckRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, deckID)
+	ret0, _ := ret[0].([]structs.DeckRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockDeckRepositoryMockRecorder) ListRevisions(ctx, deckID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockDeckRepository)(nil).ListRevisions), ctx, deckID)
+}
+
+// Patch mocks base method.
+func (m *MockDeckRepository) Patch(ctx context.Context, deck structs.Deck, fields []string, editor string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, deck, fields, editor)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockDeckRepositoryMockRecorder) Patch(ctx, deck, fields, editor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockDeckRepository)(nil).Patch), ctx, deck, fields, editor)
+}
+
 // Update mocks base method.
-func (m *MockDeckRepository) Update(ctx context.Context, deck structs.Deck) (int64, error) {
+func (m *MockDeckRepository) Update(ctx context.Context, deck structs.Deck, editor string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, deck)
+	ret := m.ctrl.Call(m, "Update", ctx, deck, editor)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockDeckRepositoryMockRecorder) Update(ctx, deck interface{}) *gomock.Call {
+func (mr *MockDeckRepositoryMockRecorder) Update(ctx, deck, editor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeckRepository)(nil).Update), ctx, deck)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDeckRepository)(nil).Update), ctx, deck, editor)
 }
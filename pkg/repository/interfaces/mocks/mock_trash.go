@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./trash.go
+
+// Package mock_trash is a generated GoMock package.
+package mock_units
+
+import (
+	context "context"
+	structs "flash-card-manager/pkg/repository/structs"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTrashRepository is a mock of TrashRepository interface.
+type MockTrashRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashRepositoryMockRecorder
+}
+
+// MockTrashRepositoryMockRecorder is the mock recorder for MockTrashRepository.
+type MockTrashRepositoryMockRecorder struct {
+	mock *MockTrashRepository
+}
+
+// NewMockTrashRepository creates a new mock instance.
+func NewMockTrashRepository(ctrl *gomock.Controller) *MockTrashRepository {
+	mock := &MockTrashRepository{ctrl: ctrl}
+	mock.recorder = &MockTrashRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashRepository) EXPECT() *MockTrashRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListCards mocks base method.
+func (m *MockTrashRepository) ListCards(ctx context.Context, owner string) ([]structs.Card, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCards", ctx, owner)
+	ret0, _ := ret[0].([]structs.Card)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCards indicates an expected call of ListCards.
+func (mr *MockTrashRepositoryMockRecorder) ListCards(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCards", reflect.TypeOf((*MockTrashRepository)(nil).ListCards), ctx, owner)
+}
+
+// ListDecks mocks base method.
+func (m *MockTrashRepository) ListDecks(ctx context.Context, owner string) ([]structs.TrashedDeck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDecks", ctx, owner)
+	ret0, _ := ret[0].([]structs.TrashedDeck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDecks indicates an expected call of ListDecks.
+func (mr *MockTrashRepositoryMockRecorder) ListDecks(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDecks", reflect.TypeOf((*MockTrashRepository)(nil).ListDecks), ctx, owner)
+}
+
+// Purge mocks base method.
+func (m *MockTrashRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTrashRepositoryMockRecorder) Purge(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTrashRepository)(nil).Purge), ctx, deletedBefore)
+}
+
+// PurgeOwner mocks base method.
+func (m *MockTrashRepository) PurgeOwner(ctx context.Context, owner string, deletedBefore time.Time) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeOwner", ctx, owner, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PurgeOwner indicates an expected call of PurgeOwner.
+func (mr *MockTrashRepositoryMockRecorder) PurgeOwner(ctx, owner, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeOwner", reflect.TypeOf((*MockTrashRepository)(nil).PurgeOwner), ctx, owner, deletedBefore)
+}
+
+// RestoreCard mocks base method.
+func (m *MockTrashRepository) RestoreCard(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCard", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreCard indicates an expected call of RestoreCard.
+func (mr *MockTrashRepositoryMockRecorder) RestoreCard(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCard", reflect.TypeOf((*MockTrashRepository)(nil).RestoreCard), ctx, id)
+}
+
+// RestoreDeck mocks base method.
+func (m *MockTrashRepository) RestoreDeck(ctx context.Context, id int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreDeck", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreDeck indicates an expected call of RestoreDeck.
+func (mr *MockTrashRepositoryMockRecorder) RestoreDeck(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDeck", reflect.TypeOf((*MockTrashRepository)(nil).RestoreDeck), ctx, id)
+}
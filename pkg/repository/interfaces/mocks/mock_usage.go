@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./usage.go
+
+// Package mock_usage is a generated GoMock package.
+package mock_units
+
+import (
+	context "context"
+	structs "flash-card-manager/pkg/repository/structs"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUsageRepository is a mock of UsageRepository interface.
+type MockUsageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsageRepositoryMockRecorder
+}
+
+// MockUsageRepositoryMockRecorder is the mock recorder for MockUsageRepository.
+type MockUsageRepositoryMockRecorder struct {
+	mock *MockUsageRepository
+}
+
+// NewMockUsageRepository creates a new mock instance.
+func NewMockUsageRepository(ctrl *gomock.Controller) *MockUsageRepository {
+	mock := &MockUsageRepository{ctrl: ctrl}
+	mock.recorder = &MockUsageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsageRepository) EXPECT() *MockUsageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeckUsage mocks base method.
+func (m *MockUsageRepository) DeckUsage(ctx context.Context, deckID int64) (structs.DeckUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeckUsage", ctx, deckID)
+	ret0, _ := ret[0].(structs.DeckUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeckUsage indicates an expected call of DeckUsage.
+func (mr *MockUsageRepositoryMockRecorder) DeckUsage(ctx, deckID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeckUsage", reflect.TypeOf((*MockUsageRepository)(nil).DeckUsage), ctx, deckID)
+}
+
+// Usage mocks base method.
+func (m *MockUsageRepository) Usage(ctx context.Context, owner string) (structs.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage", ctx, owner)
+	ret0, _ := ret[0].(structs.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MockUsageRepositoryMockRecorder) Usage(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockUsageRepository)(nil).Usage), ctx, owner)
+}
@@ -0,0 +1,21 @@
+//go:generate mockgen -source ./trash.go -destination=./mocks/mock_trash.go -package=mock_trash
+package interfaces
+
+import (
+	"context"
+	"flash-card-manager/pkg/repository/structs"
+	"time"
+)
+
+// TrashRepository manages deleted decks and cards. Methods taking an owner
+// only see the trash of the decks owner owns; cards belong to the owner of
+// their deck.
+type TrashRepository interface {
+	ListDecks(ctx context.Context, owner string) ([]structs.TrashedDeck, error)
+	ListCards(ctx context.Context, owner string) ([]structs.Card, error)
+	RestoreDeck(ctx context.Context, id int64) (restoredCards int64, err error)
+	RestoreCard(ctx context.Context, id int64) error
+	// Purge empties the trash of everyone, for the retention job.
+	Purge(ctx context.Context, deletedBefore time.Time) (purgedDecks, purgedCards int64, err error)
+	PurgeOwner(ctx context.Context, owner string, deletedBefore time.Time) (purgedDecks, purgedCards int64, err error)
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source ./usage.go -destination=./mocks/mock_usage.go -package=mock_usage
+package interfaces
+
+import (
+	"context"
+	"flash-card-manager/pkg/repository/structs"
+)
+
+// UsageRepository reports what the quotas are checked against. Decks and
+// cards in the trash do not count.
+type UsageRepository interface {
+	Usage(ctx context.Context, owner string) (structs.Usage, error)
+	// DeckUsage fails with a NotFound error when the deck does not exist or
+	// is in the trash.
+	DeckUsage(ctx context.Context, deckID int64) (structs.DeckUsage, error)
+}
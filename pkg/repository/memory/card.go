@@ -0,0 +1,279 @@
+package memory
+
+import (
+	"context"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+	"sort"
+)
+
+type CardRepo struct {
+	store *Store
+}
+
+func NewCard(store *Store) interfaces.CardRepository {
+	return &CardRepo{store: store}
+}
+
+// Add inserts the card unless its deck is missing or sits in the trash.
+func (r *CardRepo) Add(ctx context.Context, card structs.Card) (int64, error) {
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	if _, ok := d.liveDeck(card.DeckID); !ok {
+		return 0, errs.NotFoundError("deck", card.DeckID)
+	}
+
+	return r.insert(card), nil
+}
+
+func (r *CardRepo) insert(card structs.Card) int64 {
+	d := r.store.data
+	d.lastCardID++
+	card.ID = d.lastCardID
+	card.Version = 1
+	card.CreatedAt = r.store.now()
+	card.DeletedAt = nil
+	d.cards[card.ID] = card
+	return card.ID
+}
+
+// Delete moves the card to the trash.
+func (r *CardRepo) Delete(ctx context.Context, id int64) error {
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	if card, ok := d.liveCard(id); ok {
+		now := r.store.now()
+		card.DeletedAt = &now
+		d.cards[id] = card
+	}
+	return nil
+}
+
+func (r *CardRepo) GetByID(ctx context.Context, id int64) (*structs.Card, error) {
+	defer r.store.lock(ctx)()
+
+	card, ok := r.store.data.liveCard(id)
+	if !ok {
+		return nil, errs.NotFoundError("card", id)
+	}
+	return &card, nil
+}
+
+var cardPatchFields = []string{"front", "back", "deck_id", "author", "format"}
+
+func (r *CardRepo) Update(ctx context.Context, card structs.Card, editor string) (int64, error) {
+	return r.Patch(ctx, card, cardPatchFields, editor)
+}
+
+// Patch follows the Postgres implementation: it returns 0 for a missing
+// card, a Conflict error for a version mismatch and records a revision.
+func (r *CardRepo) Patch(ctx context.Context, card structs.Card, fields []string, editor string) (int64, error) {
+	defer r.store.lock(ctx)()
+	return r.patch(card, fields, editor)
+}
+
+func (r *CardRepo) patch(card structs.Card, fields []string, editor string) (int64, error) {
+	d := r.store.data
+
+	for _, field := range fields {
+		switch field {
+		case "front", "back", "deck_id", "author", "format":
+		default:
+			return 0, errs.Violation(field, "cannot be updated")
+		}
+	}
+
+	old, ok := d.liveCard(card.ID)
+	if !ok {
+		return 0, nil
+	}
+	if card.Version != 0 && old.Version != card.Version {
+		return 0, errs.ConflictError("card", card.ID)
+	}
+
+	updated := old
+	for _, field := range fields {
+		switch field {
+		case "front":
+			updated.Front = card.Front
+		case "back":
+			updated.Back = card.Back
+		case "deck_id":
+			// Like Add, this requires a deck that is not in the trash.
+			if _, ok := d.liveDeck(card.DeckID); !ok {
+				return 0, errs.NotFoundError("deck", card.DeckID)
+			}
+			updated.DeckID = card.DeckID
+		case "author":
+			updated.Author = card.Author
+		case "format":
+			updated.Format = card.Format
+		}
+	}
+	updated.Version++
+	d.cards[updated.ID] = updated
+
+	d.lastCardRevisionID++
+	d.cardRevisions[d.lastCardRevisionID] = structs.CardRevision{
+		ID:        d.lastCardRevisionID,
+		CardID:    old.ID,
+		Editor:    editor,
+		OldFront:  old.Front,
+		OldBack:   old.Back,
+		OldDeckID: old.DeckID,
+		OldAuthor: old.Author,
+		OldFormat: old.Format,
+		NewFront:  updated.Front,
+		NewBack:   updated.Back,
+		NewDeckID: updated.DeckID,
+		NewAuthor: updated.Author,
+		NewFormat: updated.Format,
+		CreatedAt: r.store.now(),
+	}
+
+	return updated.Version, nil
+}
+
+// BatchAdd inserts all cards, or none of them if any deck is missing or in
+// the trash.
+func (r *CardRepo) BatchAdd(ctx context.Context, cards []structs.Card) ([]int64, error) {
+	if len(cards) == 0 {
+		return nil, nil
+	}
+
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	for i, card := range cards {
+		if _, ok := d.liveDeck(card.DeckID); !ok {
+			return nil, &structs.BatchError{Index: i, Err: errs.NotFoundError("deck", card.DeckID)}
+		}
+	}
+
+	ids := make([]int64, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, r.insert(card))
+	}
+	return ids, nil
+}
+
+// BatchUpdate applies Update to every card in one transaction and returns the
+// new versions in input order.
+func (r *CardRepo) BatchUpdate(ctx context.Context, cards []structs.Card, editor string) ([]int64, error) {
+	if len(cards) == 0 {
+		return nil, nil
+	}
+
+	var versions []int64
+	err := r.store.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		versions = make([]int64, 0, len(cards))
+		for i, card := range cards {
+			version, err := r.patchItem(card, cardPatchFields, editor)
+			if err != nil {
+				return &structs.BatchError{Index: i, Err: err}
+			}
+			versions = append(versions, version)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// BatchDelete moves all the cards to the trash, or none of them if any is
+// missing or already deleted.
+func (r *CardRepo) BatchDelete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	for i, id := range ids {
+		if _, ok := d.liveCard(id); !ok {
+			return &structs.BatchError{Index: i, Err: errs.NotFoundError("card", id)}
+		}
+	}
+
+	now := r.store.now()
+	for _, id := range ids {
+		card := d.cards[id]
+		card.DeletedAt = &now
+		d.cards[id] = card
+	}
+	return nil
+}
+
+// Move puts all the cards into the deck and records a revision for each. It
+// returns the new versions in input order.
+func (r *CardRepo) Move(ctx context.Context, ids []int64, deckID int64, editor string) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var versions []int64
+	err := r.store.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		if _, ok := r.store.data.liveDeck(deckID); !ok {
+			return errs.NotFoundError("deck", deckID)
+		}
+
+		versions = make([]int64, 0, len(ids))
+		for i, id := range ids {
+			version, err := r.patchItem(structs.Card{ID: id, DeckID: deckID}, []string{"deck_id"}, editor)
+			if err != nil {
+				return &structs.BatchError{Index: i, Err: err}
+			}
+			versions = append(versions, version)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// patchItem is patch for a batch item, where a missing card is an error.
+func (r *CardRepo) patchItem(card structs.Card, fields []string, editor string) (int64, error) {
+	version, err := r.patch(card, fields, editor)
+	if err == nil && version == 0 {
+		return 0, errs.NotFoundError("card", card.ID)
+	}
+	return version, err
+}
+
+func (r *CardRepo) ListRevisions(ctx context.Context, cardID int64, page structs.Page) ([]structs.CardRevision, error) {
+	defer r.store.lock(ctx)()
+
+	var revisions []structs.CardRevision
+	for _, revision := range r.store.data.cardRevisions {
+		if revision.CardID == cardID && (page.After == 0 || revision.ID < page.After) {
+			revisions = append(revisions, revision)
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].ID > revisions[j].ID })
+	if len(revisions) > page.Size {
+		revisions = revisions[:page.Size]
+	}
+
+	return revisions, nil
+}
+
+func (r *CardRepo) GetRevision(ctx context.Context, cardID, revisionID int64) (*structs.CardRevision, error) {
+	defer r.store.lock(ctx)()
+
+	revision, ok := r.store.data.cardRevisions[revisionID]
+	if !ok || revision.CardID != cardID {
+		return nil, errs.NotFoundError("revision", revisionID)
+	}
+	return &revision, nil
+}
@@ -0,0 +1,18 @@
+//go:build unit
+// +build unit
+
+package memory
+
+import (
+	"flash-card-manager/tests/conformance"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestConformance(t *testing.T) {
+	suite.Run(t, &conformance.Suite{New: func(t *testing.T) conformance.Repositories {
+		store := NewStore()
+		return conformance.Repositories{Cards: NewCard(store), Decks: NewDeck(store), Trash: NewTrash(store), Usage: NewUsage(store), Tx: store}
+	}})
+}
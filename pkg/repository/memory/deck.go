@@ -0,0 +1,169 @@
+package memory
+
+import (
+	"context"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+	"sort"
+)
+
+type DeckRepo struct {
+	store *Store
+}
+
+func NewDeck(store *Store) interfaces.DeckRepository {
+	return &DeckRepo{store: store}
+}
+
+func (r *DeckRepo) Add(ctx context.Context, deck structs.Deck) (int64, error) {
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	d.lastDeckID++
+	deck.ID = d.lastDeckID
+	deck.Version = 1
+	deck.CreatedAt = r.store.now()
+	deck.DeletedAt = nil
+	d.decks[deck.ID] = deck
+	return deck.ID, nil
+}
+
+// Delete moves the deck and all of its cards to the trash. The cards share the
+// deck's deleted_at, which is how RestoreDeck finds them again.
+func (r *DeckRepo) Delete(ctx context.Context, id int64) error {
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	deck, ok := d.liveDeck(id)
+	if !ok {
+		return nil
+	}
+
+	now := r.store.now()
+	deck.DeletedAt = &now
+	d.decks[id] = deck
+	for cardID, card := range d.cards {
+		if card.DeckID == id && card.DeletedAt == nil {
+			card.DeletedAt = &now
+			d.cards[cardID] = card
+		}
+	}
+	return nil
+}
+
+func (r *DeckRepo) GetByID(ctx context.Context, id int64) (*structs.Deck, error) {
+	defer r.store.lock(ctx)()
+
+	deck, ok := r.store.data.liveDeck(id)
+	if !ok {
+		return nil, errs.NotFoundError("deck", id)
+	}
+	return &deck, nil
+}
+
+var deckPatchFields = []string{"title", "description", "author"}
+
+func (r *DeckRepo) Update(ctx context.Context, deck structs.Deck, editor string) (int64, error) {
+	return r.Patch(ctx, deck, deckPatchFields, editor)
+}
+
+// Patch follows the Postgres implementation: it returns 0 for a missing
+// deck, a Conflict error for a version mismatch and records a revision.
+func (r *DeckRepo) Patch(ctx context.Context, deck structs.Deck, fields []string, editor string) (int64, error) {
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	for _, field := range fields {
+		switch field {
+		case "title", "description", "author":
+		default:
+			return 0, errs.Violation(field, "cannot be updated")
+		}
+	}
+
+	old, ok := d.liveDeck(deck.ID)
+	if !ok {
+		return 0, nil
+	}
+	if deck.Version != 0 && old.Version != deck.Version {
+		return 0, errs.ConflictError("deck", deck.ID)
+	}
+
+	updated := old
+	for _, field := range fields {
+		switch field {
+		case "title":
+			updated.Title = deck.Title
+		case "description":
+			updated.Description = deck.Description
+		case "author":
+			updated.Author = deck.Author
+		}
+	}
+	updated.Version++
+	d.decks[updated.ID] = updated
+
+	d.lastDeckRevisionID++
+	d.deckRevisions[d.lastDeckRevisionID] = structs.DeckRevision{
+		ID:             d.lastDeckRevisionID,
+		DeckID:         old.ID,
+		Editor:         editor,
+		OldTitle:       old.Title,
+		OldDescription: old.Description,
+		OldAuthor:      old.Author,
+		NewTitle:       updated.Title,
+		NewDescription: updated.Description,
+		NewAuthor:      updated.Author,
+		CreatedAt:      r.store.now(),
+	}
+
+	return updated.Version, nil
+}
+
+func (r *DeckRepo) GetWithCardsByID(ctx context.Context, id int64) (*structs.DeckWithCards, error) {
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	deck, ok := d.liveDeck(id)
+	if !ok {
+		return nil, errs.NotFoundError("deck", id)
+	}
+
+	deckWithCards := &structs.DeckWithCards{
+		Deck:  deck,
+		Cards: []structs.Card{},
+	}
+	for _, card := range d.cards {
+		if card.DeckID == id && card.DeletedAt == nil {
+			deckWithCards.Cards = append(deckWithCards.Cards, card)
+		}
+	}
+	sort.Slice(deckWithCards.Cards, func(i, j int) bool { return deckWithCards.Cards[i].ID < deckWithCards.Cards[j].ID })
+
+	return deckWithCards, nil
+}
+
+func (r *DeckRepo) ListRevisions(ctx context.Context, deckID int64) ([]structs.DeckRevision, error) {
+	defer r.store.lock(ctx)()
+
+	var revisions []structs.DeckRevision
+	for _, revision := range r.store.data.deckRevisions {
+		if revision.DeckID == deckID {
+			revisions = append(revisions, revision)
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].ID > revisions[j].ID })
+
+	return revisions, nil
+}
+
+func (r *DeckRepo) GetRevision(ctx context.Context, deckID, revisionID int64) (*structs.DeckRevision, error) {
+	defer r.store.lock(ctx)()
+
+	revision, ok := r.store.data.deckRevisions[revisionID]
+	if !ok || revision.DeckID != deckID {
+		return nil, errs.NotFoundError("revision", revisionID)
+	}
+	return &revision, nil
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+	"sync"
+	"time"
+)
+
+type IdempotencyRepo struct {
+	mu      sync.Mutex
+	records map[string]structs.IdempotencyRecord
+	now     func() time.Time
+}
+
+func NewIdempotency() interfaces.IdempotencyRepository {
+	return &IdempotencyRepo{records: make(map[string]structs.IdempotencyRecord), now: time.Now}
+}
+
+// Claim behaves like the Postgres implementation, including dropping the
+// expired records it comes across.
+func (r *IdempotencyRepo) Claim(ctx context.Context, record structs.IdempotencyRecord) (*structs.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for key, held := range r.records {
+		if !held.ExpiresAt.After(now) {
+			delete(r.records, key)
+		}
+	}
+
+	if held, ok := r.records[record.Key]; ok {
+		return &held, nil
+	}
+
+	record.Response, record.Header = nil, nil
+	r.records[record.Key] = record
+	return nil, nil
+}
+
+func (r *IdempotencyRepo) Complete(ctx context.Context, record structs.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	held, ok := r.records[record.Key]
+	if ok && held.Response == nil && bytes.Equal(held.RequestHash, record.RequestHash) && held.ExpiresAt.After(r.now()) {
+		r.records[record.Key] = record
+	}
+	return nil
+}
+
+func (r *IdempotencyRepo) Release(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if record, ok := r.records[key]; ok && record.Response == nil {
+		delete(r.records, key)
+	}
+	return nil
+}
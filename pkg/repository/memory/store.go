@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/repository/structs"
+	"sync"
+	"time"
+)
+
+// Store holds the decks, cards and their revisions of the in-memory
+// repositories. Repositories built on the same Store see each other's data,
+// just like the Postgres ones sharing a database.
+type Store struct {
+	mu   sync.Mutex
+	data *data
+	now  func() time.Time
+}
+
+type data struct {
+	decks         map[int64]structs.Deck
+	cards         map[int64]structs.Card
+	deckRevisions map[int64]structs.DeckRevision
+	cardRevisions map[int64]structs.CardRevision
+
+	lastDeckID, lastCardID, lastDeckRevisionID, lastCardRevisionID int64
+}
+
+func NewStore() *Store {
+	return &Store{
+		data: &data{
+			decks:         make(map[int64]structs.Deck),
+			cards:         make(map[int64]structs.Card),
+			deckRevisions: make(map[int64]structs.DeckRevision),
+			cardRevisions: make(map[int64]structs.CardRevision),
+		},
+		now: time.Now,
+	}
+}
+
+type txKey struct{}
+
+// RunInTx implements db.TxManager. Transactions hold the store for their
+// whole duration, so they are serializable whatever opts ask for. Changes
+// made by a failing fn are rolled back, and a nested call behaves like a
+// savepoint.
+func (s *Store) RunInTx(ctx context.Context, opts db.TxOptions, fn func(ctx context.Context) error) error {
+	if !s.inTx(ctx) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		ctx = context.WithValue(ctx, txKey{}, s)
+	}
+
+	saved := s.data.clone()
+	if err := fn(ctx); err != nil {
+		s.data = saved
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) inTx(ctx context.Context) bool {
+	store, _ := ctx.Value(txKey{}).(*Store)
+	return store == s
+}
+
+// lock takes the store for a single repository call, unless the call is part
+// of a transaction that already holds it, and returns the matching unlock.
+func (s *Store) lock(ctx context.Context) func() {
+	if s.inTx(ctx) {
+		return func() {}
+	}
+
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+func (d *data) clone() *data {
+	c := *d
+	c.decks = cloneMap(d.decks)
+	c.cards = cloneMap(d.cards)
+	c.deckRevisions = cloneMap(d.deckRevisions)
+	c.cardRevisions = cloneMap(d.cardRevisions)
+	return &c
+}
+
+func cloneMap[V any](m map[int64]V) map[int64]V {
+	c := make(map[int64]V, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// liveDeck returns the deck unless it is missing or in the trash.
+func (d *data) liveDeck(id int64) (structs.Deck, bool) {
+	deck, ok := d.decks[id]
+	return deck, ok && deck.DeletedAt == nil
+}
+
+// liveCard returns the card unless it is missing or in the trash.
+func (d *data) liveCard(id int64) (structs.Card, bool) {
+	card, ok := d.cards[id]
+	return card, ok && card.DeletedAt == nil
+}
@@ -0,0 +1,61 @@
+//go:build unit
+// +build unit
+
+package memory
+
+import (
+	"context"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/repository/structs"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_Concurrent(t *testing.T) {
+	store := NewStore()
+	decks := NewDeck(store)
+	cards := NewCard(store)
+	ctx := context.Background()
+
+	deckID, err := decks.Add(ctx, structs.Deck{Title: "T", Author: "A"})
+	require.NoError(t, err)
+
+	const workers = 20
+	ids := make(chan int64, workers*2)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			id, err := cards.Add(ctx, structs.Card{Front: "F", Back: "B", DeckID: deckID, Author: "A"})
+			assert.NoError(t, err)
+			ids <- id
+
+			err = store.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+				id, err := cards.Add(ctx, structs.Card{Front: "F", Back: "B", DeckID: deckID, Author: "A"})
+				assert.NoError(t, err)
+				ids <- id
+				_, err = decks.Update(ctx, structs.Deck{ID: deckID, Title: "T", Author: "A"}, "A")
+				return err
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[int64]bool)
+	for id := range ids {
+		assert.False(t, seen[id], "duplicate id %d", id)
+		seen[id] = true
+	}
+
+	deck, err := decks.GetWithCardsByID(ctx, deckID)
+	require.NoError(t, err)
+	assert.Len(t, deck.Cards, workers*2)
+	assert.Equal(t, int64(workers+1), deck.Deck.Version)
+}
@@ -0,0 +1,157 @@
+package memory
+
+import (
+	"context"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+	"sort"
+	"time"
+)
+
+type TrashRepo struct {
+	store *Store
+}
+
+func NewTrash(store *Store) interfaces.TrashRepository {
+	return &TrashRepo{store: store}
+}
+
+func (r *TrashRepo) ListDecks(ctx context.Context, owner string) ([]structs.TrashedDeck, error) {
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	var decks []structs.TrashedDeck
+	for _, deck := range d.decks {
+		if deck.Owner != owner || deck.DeletedAt == nil {
+			continue
+		}
+
+		trashed := structs.TrashedDeck{Deck: deck}
+		for _, card := range d.cards {
+			if card.DeckID == deck.ID && card.DeletedAt != nil && card.DeletedAt.Equal(*deck.DeletedAt) {
+				trashed.CardCount++
+			}
+		}
+		decks = append(decks, trashed)
+	}
+	sort.Slice(decks, func(i, j int) bool { return decks[i].DeletedAt.After(*decks[j].DeletedAt) })
+
+	return decks, nil
+}
+
+// ListCards returns cards that were deleted on their own. Cards deleted
+// together with their deck are listed under the deck instead.
+func (r *TrashRepo) ListCards(ctx context.Context, owner string) ([]structs.Card, error) {
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	var cards []structs.Card
+	for _, card := range d.cards {
+		if deck, ok := d.liveDeck(card.DeckID); ok && deck.Owner == owner && card.DeletedAt != nil {
+			cards = append(cards, card)
+		}
+	}
+	sort.Slice(cards, func(i, j int) bool { return cards[i].DeletedAt.After(*cards[j].DeletedAt) })
+
+	return cards, nil
+}
+
+// RestoreDeck takes the deck out of the trash along with the cards that were
+// deleted in the same DeleteDeck call.
+func (r *TrashRepo) RestoreDeck(ctx context.Context, id int64) (int64, error) {
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	deck, ok := d.decks[id]
+	if !ok || deck.DeletedAt == nil {
+		return 0, &errs.Error{Kind: errs.NotFound, Resource: "deck", ID: id, Message: "deck not found in trash"}
+	}
+
+	var restored int64
+	for cardID, card := range d.cards {
+		if card.DeckID == id && card.DeletedAt != nil && card.DeletedAt.Equal(*deck.DeletedAt) {
+			card.DeletedAt = nil
+			d.cards[cardID] = card
+			restored++
+		}
+	}
+	deck.DeletedAt = nil
+	d.decks[id] = deck
+
+	return restored, nil
+}
+
+// RestoreCard takes a single card out of the trash. A card whose deck is
+// still in the trash cannot be restored on its own.
+func (r *TrashRepo) RestoreCard(ctx context.Context, id int64) error {
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	card, ok := d.cards[id]
+	if !ok || card.DeletedAt == nil {
+		return &errs.Error{Kind: errs.NotFound, Resource: "card", ID: id, Message: "card not found in trash"}
+	}
+	if _, ok := d.liveDeck(card.DeckID); !ok {
+		return errs.FailedPreconditionError("card", id, "deck is in trash")
+	}
+
+	card.DeletedAt = nil
+	d.cards[id] = card
+	return nil
+}
+
+// Purge permanently removes everything that was put into the trash before
+// deletedBefore, along with the cards of purged decks and the revisions of
+// everything purged.
+func (r *TrashRepo) Purge(ctx context.Context, deletedBefore time.Time) (int64, int64, error) {
+	return r.purge(ctx, nil, deletedBefore)
+}
+
+// PurgeOwner is Purge limited to the decks of owner and their cards.
+func (r *TrashRepo) PurgeOwner(ctx context.Context, owner string, deletedBefore time.Time) (int64, int64, error) {
+	return r.purge(ctx, &owner, deletedBefore)
+}
+
+// purge purges the trash of owner, or of everyone when owner is nil.
+func (r *TrashRepo) purge(ctx context.Context, owner *string, deletedBefore time.Time) (int64, int64, error) {
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	var decks, cards int64
+	purged := map[int64]bool{}
+	for id, deck := range d.decks {
+		if owner != nil && deck.Owner != *owner {
+			continue
+		}
+		if deck.DeletedAt != nil && deck.DeletedAt.Before(deletedBefore) {
+			delete(d.decks, id)
+			purged[id] = true
+			decks++
+		}
+	}
+	for id, card := range d.cards {
+		if purged[card.DeckID] {
+			delete(d.cards, id)
+			cards++
+			continue
+		}
+		deck, ok := d.decks[card.DeckID]
+		if ok && (owner == nil || deck.Owner == *owner) && card.DeletedAt != nil && card.DeletedAt.Before(deletedBefore) {
+			delete(d.cards, id)
+			cards++
+		}
+	}
+	for id, revision := range d.deckRevisions {
+		if _, ok := d.decks[revision.DeckID]; !ok {
+			delete(d.deckRevisions, id)
+		}
+	}
+	for id, revision := range d.cardRevisions {
+		if _, ok := d.cards[revision.CardID]; !ok {
+			delete(d.cardRevisions, id)
+		}
+	}
+
+	return decks, cards, nil
+}
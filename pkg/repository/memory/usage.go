@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+)
+
+type UsageRepo struct {
+	store *Store
+}
+
+func NewUsage(store *Store) interfaces.UsageRepository {
+	return &UsageRepo{store: store}
+}
+
+func (r *UsageRepo) Usage(ctx context.Context, owner string) (structs.Usage, error) {
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	var usage structs.Usage
+	for _, deck := range d.decks {
+		if deck.Owner == owner && deck.DeletedAt == nil {
+			usage.Decks++
+		}
+	}
+	for _, card := range d.cards {
+		if deck, ok := d.liveDeck(card.DeckID); ok && deck.Owner == owner && card.DeletedAt == nil {
+			usage.MediaBytes += int64(len(card.Front) + len(card.Back))
+		}
+	}
+	return usage, nil
+}
+
+func (r *UsageRepo) DeckUsage(ctx context.Context, deckID int64) (structs.DeckUsage, error) {
+	defer r.store.lock(ctx)()
+	d := r.store.data
+
+	deck, ok := d.liveDeck(deckID)
+	if !ok {
+		return structs.DeckUsage{}, errs.NotFoundError("deck", deckID)
+	}
+
+	usage := structs.DeckUsage{DeckID: deckID, Owner: deck.Owner}
+	for _, card := range d.cards {
+		if card.DeckID == deckID && card.DeletedAt == nil {
+			usage.Cards++
+		}
+	}
+	return usage, nil
+}
@@ -2,42 +2,53 @@ package postgresql
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/errs"
 	"flash-card-manager/pkg/repository/interfaces"
 	"flash-card-manager/pkg/repository/structs"
+	"fmt"
+	"strings"
 )
 
 type CardRepo struct {
-	db db.DatabaseInterface
+	db db.PostgresInterface
 }
 
-func NewCard(database db.DatabaseInterface) interfaces.CardRepository {
+func NewCard(database db.PostgresInterface) interfaces.CardRepository {
 	return &CardRepo{db: database}
 }
 
+// Add inserts the card unless its deck is missing or sits in the trash.
 func (r *CardRepo) Add(ctx context.Context, card structs.Card) (int64, error) {
+	query := `
+	INSERT INTO cards(front, back, deck_id, author, format)
+	SELECT $1, $2, id, $4, $5 FROM decks WHERE id=$3 AND deleted_at IS NULL
+	RETURNING id;
+	`
+
 	var id int64
-	err := r.db.ExecQueryRow(ctx, `INSERT INTO cards(front, back, deck_id, author) VALUES($1,$2,$3,$4) RETURNING id;`, card.Front, card.Back, card.DeckID, card.Author).Scan(&id)
+	err := r.db.ExecQueryRow(ctx, query, card.Front, card.Back, card.DeckID, card.Author, card.Format).Scan(&id)
+	if errors.Is(err, db.ErrNoRows) {
+		return 0, errs.NotFoundError("deck", card.DeckID)
+	}
 
-	return id, err
+	return id, translateError(err)
 }
 
+// Delete moves the card to the trash. It is removed for good by PurgeTrash.
 func (r *CardRepo) Delete(ctx context.Context, id int64) error {
-	_, err := r.db.Exec(ctx, "DELETE FROM cards WHERE id=$1", id)
+	_, err := r.db.Exec(ctx, "UPDATE cards SET deleted_at=now() WHERE id=$1 AND deleted_at IS NULL", id)
 	return err
 }
 
 func (r *CardRepo) GetByID(ctx context.Context, id int64) (*structs.Card, error) {
 	var card structs.Card
-	err := r.db.Get(ctx, &card, "SELECT id, front, back, deck_id, author, created_at FROM cards WHERE id=$1", id)
+	err := r.db.Get(ctx, &card, "SELECT id, front, back, deck_id, author, format, version, created_at FROM cards WHERE id=$1 AND deleted_at IS NULL", id)
 
 	if err != nil {
-
-		if errors.Is(err, sql.ErrNoRows) {
-
-			return nil, errors.New("card not found")
+		if errors.Is(err, db.ErrNoRows) {
+			return nil, errs.NotFoundError("card", id)
 		}
 
 		return nil, err
@@ -46,11 +57,118 @@ func (r *CardRepo) GetByID(ctx context.Context, id int64) (*structs.Card, error)
 	return &card, nil
 }
 
-func (r *CardRepo) Update(ctx context.Context, card structs.Card) (int64, error) {
-	result, err := r.db.Exec(ctx, `UPDATE cards SET front=$1, back=$2, deck_id=$3, author=$4 WHERE id=$5;`, card.Front, card.Back, card.DeckID, card.Author, card.ID)
+// cardPatchColumns lists the columns Patch may change, in the order Update
+// writes them.
+var cardPatchColumns = []string{"front", "back", "deck_id", "author", "format"}
+
+// Update overwrites every editable column of the card. See Patch.
+func (r *CardRepo) Update(ctx context.Context, card structs.Card, editor string) (int64, error) {
+	return r.Patch(ctx, card, cardPatchColumns, editor)
+}
+
+// Patch changes only the given columns of the card and records its previous
+// and new contents in card_revisions within the same statement. When
+// card.Version is set, the card is only updated if it is still at that
+// version. Patch returns the card's new version, or 0 when there is no such
+// card.
+func (r *CardRepo) Patch(ctx context.Context, card structs.Card, fields []string, editor string) (int64, error) {
+	query, args, err := cardPatchQuery(card, fields, editor)
 	if err != nil {
 		return 0, err
 	}
 
-	return result.RowsAffected(), nil
+	var found, version, decks int64
+	err = r.db.ExecQueryRow(ctx, query, args...).Scan(&found, &version, &decks)
+	if err != nil {
+		return 0, translateError(err)
+	}
+
+	if found > 0 && decks == 0 {
+		return 0, errs.NotFoundError("deck", card.DeckID)
+	}
+	if found > 0 && version == 0 {
+		return 0, errs.ConflictError("card", card.ID)
+	}
+
+	return version, nil
+}
+
+// cardPatchQuery builds the statement behind Patch. It yields one row with
+// the number of live cards matching card.ID, the new version, which is 0 when
+// nothing was updated, and the number of live decks the card may end up in.
+// Like Add, a card can only be moved into a deck that is not in the trash; the
+// deck is share-locked so that it stays that way until the update commits.
+func cardPatchQuery(card structs.Card, fields []string, editor string) (string, []interface{}, error) {
+	args := []interface{}{card.ID, editor, card.Version}
+	set := make([]string, 0, len(fields)+1)
+	deck := "SELECT 1"
+	for _, field := range fields {
+		var value interface{}
+		switch field {
+		case "front":
+			value = card.Front
+		case "back":
+			value = card.Back
+		case "deck_id":
+			value = card.DeckID
+		case "author":
+			value = card.Author
+		case "format":
+			value = card.Format
+		default:
+			return "", nil, errs.Violation(field, "cannot be updated")
+		}
+		args = append(args, value)
+		set = append(set, fmt.Sprintf("%s=$%d", field, len(args)))
+		if field == "deck_id" {
+			deck = fmt.Sprintf("SELECT id FROM decks WHERE id=$%d AND deleted_at IS NULL FOR SHARE", len(args))
+		}
+	}
+	set = append(set, "version=old.version+1")
+
+	query := `
+	WITH old AS (
+		SELECT id, front, back, deck_id, author, format, version FROM cards WHERE id=$1 AND deleted_at IS NULL FOR UPDATE
+	), deck AS (
+		` + deck + `
+	), updated AS (
+		UPDATE cards SET ` + strings.Join(set, ", ") + `
+		FROM old WHERE cards.id=old.id AND ($3::bigint = 0 OR old.version = $3::bigint) AND EXISTS (SELECT 1 FROM deck)
+		RETURNING cards.id, cards.front, cards.back, cards.deck_id, cards.author, cards.format, cards.version
+	), revision AS (
+		INSERT INTO card_revisions(card_id, editor, old_front, old_back, old_deck_id, old_author, old_format, new_front, new_back, new_deck_id, new_author, new_format)
+		SELECT old.id, $2, old.front, old.back, old.deck_id, old.author, old.format, updated.front, updated.back, updated.deck_id, updated.author, updated.format
+		FROM old JOIN updated ON updated.id = old.id
+	)
+	SELECT (SELECT count(*) FROM old), COALESCE((SELECT version FROM updated), 0), (SELECT count(*) FROM deck);
+	`
+
+	return query, args, nil
+}
+
+const cardRevisionColumns = `id, card_id, editor, old_front, old_back, old_deck_id, old_author, old_format, new_front, new_back, new_deck_id, new_author, new_format, created_at`
+
+func (r *CardRepo) ListRevisions(ctx context.Context, cardID int64, page structs.Page) ([]structs.CardRevision, error) {
+	var revisions []structs.CardRevision
+	err := r.db.Select(ctx, &revisions, "SELECT "+cardRevisionColumns+" FROM card_revisions WHERE card_id=$1 AND ($2::bigint = 0 OR id < $2::bigint) ORDER BY id DESC LIMIT $3",
+		cardID, page.After, page.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (r *CardRepo) GetRevision(ctx context.Context, cardID, revisionID int64) (*structs.CardRevision, error) {
+	var revision structs.CardRevision
+	err := r.db.Get(ctx, &revision, "SELECT "+cardRevisionColumns+" FROM card_revisions WHERE card_id=$1 AND id=$2", cardID, revisionID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRows) {
+			return nil, errs.NotFoundError("revision", revisionID)
+		}
+
+		return nil, err
+	}
+
+	return &revision, nil
 }
@@ -0,0 +1,196 @@
+package postgresql
+
+import (
+	"context"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/structs"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// BatchAdd inserts all cards with a single COPY, or none of them if any deck
+// is missing or in the trash. IDs are reserved from the cards sequence up
+// front, since COPY cannot return them, and come back in input order.
+func (r *CardRepo) BatchAdd(ctx context.Context, cards []structs.Card) ([]int64, error) {
+	if len(cards) == 0 {
+		return nil, nil
+	}
+
+	deckIDs := make([]int64, 0, len(cards))
+	for _, card := range cards {
+		deckIDs = append(deckIDs, card.DeckID)
+	}
+
+	var ids []int64
+	err := r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		live, err := lockLiveDecks(ctx, r.db, deckIDs)
+		if err != nil {
+			return err
+		}
+		for i, card := range cards {
+			if !live[card.DeckID] {
+				return &structs.BatchError{Index: i, Err: errs.NotFoundError("deck", card.DeckID)}
+			}
+		}
+
+		ids = nil
+		err = r.db.Select(ctx, &ids, `SELECT nextval(pg_get_serial_sequence('cards', 'id')) FROM generate_series(1, $1)`, len(cards))
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.CopyFrom(ctx, pgx.Identifier{"cards"}, []string{"id", "front", "back", "deck_id", "author", "format"},
+			pgx.CopyFromSlice(len(cards), func(i int) ([]interface{}, error) {
+				card := cards[i]
+				return []interface{}{ids[i], card.Front, card.Back, card.DeckID, card.Author, card.Format}, nil
+			}))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// BatchUpdate applies Update to every card in one transaction and returns the
+// new versions in input order. A missing card, a version mismatch or a deck
+// that is missing or in the trash rolls back the whole batch.
+func (r *CardRepo) BatchUpdate(ctx context.Context, cards []structs.Card, editor string) ([]int64, error) {
+	if len(cards) == 0 {
+		return nil, nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, card := range cards {
+		query, args, err := cardPatchQuery(card, cardPatchColumns, editor)
+		if err != nil {
+			return nil, err
+		}
+		batch.Queue(query, args...)
+	}
+
+	var versions []int64
+	err := r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		var err error
+		versions, err = sendCardPatchBatch(ctx, r.db, batch, cards)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// BatchDelete moves all the cards to the trash, or none of them if any is
+// missing or already deleted.
+func (r *CardRepo) BatchDelete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		var deleted []int64
+		err := r.db.Select(ctx, &deleted, "UPDATE cards SET deleted_at=now() WHERE id = ANY($1) AND deleted_at IS NULL RETURNING id", ids)
+		if err != nil {
+			return err
+		}
+
+		found := make(map[int64]bool, len(deleted))
+		for _, id := range deleted {
+			found[id] = true
+		}
+		for i, id := range ids {
+			if !found[id] {
+				return &structs.BatchError{Index: i, Err: errs.NotFoundError("card", id)}
+			}
+		}
+
+		return nil
+	})
+}
+
+// Move puts all the cards into the deck and records a revision for each. It
+// returns the new versions in input order.
+func (r *CardRepo) Move(ctx context.Context, ids []int64, deckID int64, editor string) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	batch := &pgx.Batch{}
+	cards := make([]structs.Card, 0, len(ids))
+	for _, id := range ids {
+		card := structs.Card{ID: id, DeckID: deckID}
+		query, args, err := cardPatchQuery(card, []string{"deck_id"}, editor)
+		if err != nil {
+			return nil, err
+		}
+		batch.Queue(query, args...)
+		cards = append(cards, card)
+	}
+
+	var versions []int64
+	err := r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		live, err := lockLiveDecks(ctx, r.db, []int64{deckID})
+		if err != nil {
+			return err
+		}
+		if !live[deckID] {
+			return errs.NotFoundError("deck", deckID)
+		}
+
+		versions, err = sendCardPatchBatch(ctx, r.db, batch, cards)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// lockLiveDecks share-locks the decks that exist and are not in the trash, so
+// they cannot be deleted before the transaction ends, and reports which of
+// the given IDs they are.
+func lockLiveDecks(ctx context.Context, database db.PostgresInterface, ids []int64) (map[int64]bool, error) {
+	var live []int64
+	err := database.Select(ctx, &live, "SELECT id FROM decks WHERE id = ANY($1) AND deleted_at IS NULL FOR SHARE", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64]bool, len(live))
+	for _, id := range live {
+		result[id] = true
+	}
+	return result, nil
+}
+
+// sendCardPatchBatch runs queries built by cardPatchQuery for the given cards
+// and returns the new versions, failing on the first card that is missing, at
+// another version or moved into a deck that is missing or in the trash.
+func sendCardPatchBatch(ctx context.Context, database db.PostgresInterface, batch *pgx.Batch, cards []structs.Card) ([]int64, error) {
+	results := database.SendBatch(ctx, batch)
+	defer results.Close()
+
+	versions := make([]int64, batch.Len())
+	for i := range versions {
+		var found, decks int64
+		if err := results.QueryRow().Scan(&found, &versions[i], &decks); err != nil {
+			return nil, &structs.BatchError{Index: i, Err: translateError(err)}
+		}
+		if found == 0 {
+			return nil, &structs.BatchError{Index: i, Err: errs.NotFoundError("card", cards[i].ID)}
+		}
+		if decks == 0 {
+			return nil, &structs.BatchError{Index: i, Err: errs.NotFoundError("deck", cards[i].DeckID)}
+		}
+		if versions[i] == 0 {
+			return nil, &structs.BatchError{Index: i, Err: errs.ConflictError("card", cards[i].ID)}
+		}
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,97 @@
+//go:build unit
+// +build unit
+
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/db/mocks"
+	"flash-card-manager/pkg/repository/structs"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func expectTx(mockDB *mock_db.MockPostgresInterface) {
+	mockDB.EXPECT().RunInTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ db.TxOptions, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+func TestCardRepo_BatchAddMissingDeck(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewCard(mockDB)
+
+	expectTx(mockDB)
+	mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), gomock.Any(), []int64{1, 2}).SetArg(1, []int64{1}).Return(nil)
+
+	_, err := repo.BatchAdd(context.TODO(), []structs.Card{{DeckID: 1}, {DeckID: 2}})
+
+	var batchErr *structs.BatchError
+	if !errors.As(err, &batchErr) || batchErr.Index != 1 {
+		t.Errorf("Expected the second card to be reported, got %v", err)
+	}
+}
+
+func TestCardRepo_BatchAdd(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewCard(mockDB)
+
+	expectTx(mockDB)
+	mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), gomock.Any(), []int64{1, 1}).SetArg(1, []int64{1}).Return(nil)
+	mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), gomock.Any(), 2).SetArg(1, []int64{7, 8}).Return(nil)
+	mockDB.EXPECT().CopyFrom(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(2), nil)
+
+	ids, err := repo.BatchAdd(context.TODO(), []structs.Card{{DeckID: 1}, {DeckID: 1}})
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 7 || ids[1] != 8 {
+		t.Errorf("Unexpected IDs: %v", ids)
+	}
+}
+
+func TestCardRepo_BatchDeleteMissingCard(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewCard(mockDB)
+
+	expectTx(mockDB)
+	mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), gomock.Any(), []int64{1, 2, 3}).SetArg(1, []int64{1, 3}).Return(nil)
+
+	err := repo.BatchDelete(context.TODO(), []int64{1, 2, 3})
+
+	var batchErr *structs.BatchError
+	if !errors.As(err, &batchErr) || batchErr.Index != 1 || batchErr.Err.Error() != "card not found" {
+		t.Errorf("Expected the second card to be reported, got %v", err)
+	}
+}
+
+func TestCardRepo_MoveToMissingDeck(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewCard(mockDB)
+
+	expectTx(mockDB)
+	mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), gomock.Any(), []int64{5}).Return(nil)
+
+	_, err := repo.Move(context.TODO(), []int64{1, 2}, 5, "editor")
+
+	if err == nil || err.Error() != "deck not found" {
+		t.Errorf("Expected deck not found, got %v", err)
+	}
+}
@@ -7,10 +7,14 @@ import (
 	"context"
 	"errors"
 	"github.com/golang/mock/gomock"
-	"github.com/jackc/pgconn"
-	"homework-3/pkg/db/mocks"
-	"homework-3/pkg/repository/structs"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/db/mocks"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/structs"
+	"strings"
 	"testing"
+
+	"github.com/jackc/pgconn"
 )
 
 type mockRow struct {
@@ -35,10 +39,10 @@ func TestCardRepo_Add(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	mockDB := mock_db.NewMockDatabaseInterface(mockCtrl)
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
 	repo := NewCard(mockDB)
 
-	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&mockRow{value: 1})
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&mockRow{value: 1})
 
 	id, err := repo.Add(context.TODO(), structs.Card{
 		Front:  "testFront",
@@ -59,10 +63,10 @@ func TestCardRepo_Delete(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	mockDB := mock_db.NewMockDatabaseInterface(mockCtrl)
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
 	repo := NewCard(mockDB)
 
-	mockDB.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockDB.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil)
 
 	err := repo.Delete(context.TODO(), 1)
 
@@ -75,7 +79,7 @@ func TestCardRepo_GetByID(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	mockDB := mock_db.NewMockDatabaseInterface(mockCtrl)
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
 	repo := NewCard(mockDB)
 
 	expectedCard := structs.Card{
@@ -102,7 +106,7 @@ func TestCardRepo_Update(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	mockDB := mock_db.NewMockDatabaseInterface(mockCtrl)
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
 	repo := NewCard(mockDB)
 
 	updateCard := structs.Card{
@@ -113,15 +117,177 @@ func TestCardRepo_Update(t *testing.T) {
 		Author: "updatedAuthor",
 	}
 
-	mockCommandTagValue := pgconn.CommandTag("UPDATE 1")
-	mockDB.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockCommandTagValue, nil)
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), int64(1), "editor", int64(0), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&countsRow{values: []int64{1, 2, 1}})
+
+	version, err := repo.Update(context.TODO(), updateCard, "editor")
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if version != 2 {
+		t.Errorf("Expected new version to be 2, received %d", version)
+	}
+}
+
+func TestCardRepo_UpdateVersionMismatch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewCard(mockDB)
+
+	updateCard := structs.Card{
+		ID:      1,
+		Front:   "updatedFront",
+		Back:    "updatedBack",
+		DeckID:  1,
+		Author:  "updatedAuthor",
+		Version: 1,
+	}
+
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), int64(1), "editor", int64(1), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&countsRow{values: []int64{1, 0, 1}})
+
+	_, err := repo.Update(context.TODO(), updateCard, "editor")
+
+	if !errors.Is(err, errs.ErrConflict) || err.Error() != "version mismatch" {
+		t.Errorf("Expected version mismatch, got %v", err)
+	}
+}
+
+func TestCardRepo_UpdateMissingDeck(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewCard(mockDB)
+
+	pgErr := &pgconn.PgError{Code: "23503", ConstraintName: "fk_deck_id"}
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), int64(1), "editor", int64(0), gomock.Any(), gomock.Any(), int64(42), gomock.Any(), gomock.Any()).Return(&countsRow{err: pgErr})
+
+	_, err := repo.Update(context.TODO(), structs.Card{ID: 1, Front: "f", Back: "b", DeckID: 42}, "editor")
+
+	if !errors.Is(err, &errs.Error{Kind: errs.NotFound, Resource: "deck"}) || !errors.Is(err, pgErr) {
+		t.Errorf("Expected deck not found wrapping the pg error, got %v", err)
+	}
+}
+
+func TestCardRepo_GetByIDNotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewCard(mockDB)
+
+	mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).Return(db.ErrNoRows)
+
+	_, err := repo.GetByID(context.TODO(), 1)
+
+	var domainErr *errs.Error
+	if !errors.As(err, &domainErr) || domainErr.Kind != errs.NotFound || domainErr.Resource != "card" || domainErr.ID != 1 {
+		t.Errorf("Expected card 1 not found, got %v", err)
+	}
+}
+
+func TestCardRepo_Patch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewCard(mockDB)
+
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), int64(1), "editor", int64(0), int64(2), "newAuthor").DoAndReturn(
+		func(_ context.Context, query string, _ ...interface{}) db.Row {
+			if !strings.Contains(query, "SET deck_id=$4, author=$5, version=old.version+1") {
+				t.Errorf("Unexpected SET clause in query: %s", query)
+			}
+			if !strings.Contains(query, "FROM decks WHERE id=$4 AND deleted_at IS NULL") {
+				t.Errorf("Expected the target deck to be checked in query: %s", query)
+			}
+			return &countsRow{values: []int64{1, 3, 1}}
+		})
+
+	version, err := repo.Patch(context.TODO(), structs.Card{ID: 1, DeckID: 2, Author: "newAuthor", Front: "ignored"}, []string{"deck_id", "author"}, "editor")
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if version != 3 {
+		t.Errorf("Expected new version to be 3, received %d", version)
+	}
+}
+
+func TestCardRepo_PatchDeletedDeck(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewCard(mockDB)
+
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), int64(1), "editor", int64(0), int64(42)).Return(&countsRow{values: []int64{1, 0, 0}})
+
+	_, err := repo.Patch(context.TODO(), structs.Card{ID: 1, DeckID: 42}, []string{"deck_id"}, "editor")
+
+	var domainErr *errs.Error
+	if !errors.As(err, &domainErr) || domainErr.Kind != errs.NotFound || domainErr.Resource != "deck" || domainErr.ID != 42 {
+		t.Errorf("Expected deck 42 not found, got %v", err)
+	}
+}
+
+func TestCardRepo_PatchUnknownField(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewCard(mockDB)
+
+	_, err := repo.Patch(context.TODO(), structs.Card{ID: 1}, []string{"created_at"}, "editor")
+
+	if err == nil {
+		t.Errorf("Expected an error for an unknown field")
+	}
+}
+
+func TestCardRepo_ListRevisions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewCard(mockDB)
+
+	expectedRevisions := []structs.CardRevision{
+		{ID: 2, CardID: 1, Editor: "editor", OldFront: "testFront", NewFront: "updatedFront"},
+		{ID: 1, CardID: 1, Editor: "editor", OldFront: "firstFront", NewFront: "testFront"},
+	}
+
+	mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), int64(3), 2).SetArg(1, expectedRevisions).Return(nil)
+
+	revisions, err := repo.ListRevisions(context.TODO(), 1, structs.Page{Size: 2, After: 3})
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(revisions) != 2 || revisions[0].ID != 2 || revisions[0].NewFront != "updatedFront" {
+		t.Errorf("Unexpected revisions: %v", revisions)
+	}
+}
+
+func TestCardRepo_GetRevision(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewCard(mockDB)
+
+	expectedRevision := structs.CardRevision{ID: 2, CardID: 1, Editor: "editor", OldFront: "testFront", NewFront: "updatedFront"}
+
+	mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), int64(2)).SetArg(1, expectedRevision).Return(nil)
 
-	rowsAffected, err := repo.Update(context.TODO(), updateCard)
+	revision, err := repo.GetRevision(context.TODO(), 1, 2)
 
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
-	if rowsAffected != 1 {
-		t.Errorf("Expected that the number of modified rows would be 1, received %d", rowsAffected)
+	if revision.ID != 2 || revision.OldFront != "testFront" {
+		t.Errorf("Unexpected revision details: %v", revision)
 	}
 }
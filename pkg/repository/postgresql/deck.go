@@ -2,40 +2,51 @@ package postgresql
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/errs"
 	"flash-card-manager/pkg/repository/interfaces"
 	"flash-card-manager/pkg/repository/structs"
+	"fmt"
+	"strings"
 )
 
 type DeckRepo struct {
-	db db.DatabaseInterface
+	db db.PostgresInterface
 }
 
-func NewDeck(database db.DatabaseInterface) interfaces.DeckRepository {
+func NewDeck(database db.PostgresInterface) interfaces.DeckRepository {
 	return &DeckRepo{db: database}
 }
 
 func (r *DeckRepo) Add(ctx context.Context, deck structs.Deck) (int64, error) {
 	var id int64
-	err := r.db.ExecQueryRow(ctx, `INSERT INTO decks(title, description, author) VALUES($1,$2,$3) RETURNING id;`, deck.Title, deck.Description, deck.Author).Scan(&id)
+	err := r.db.ExecQueryRow(ctx, `INSERT INTO decks(title, description, author, owner) VALUES($1,$2,$3,$4) RETURNING id;`, deck.Title, deck.Description, deck.Author, deck.Owner).Scan(&id)
 
-	return id, err
+	return id, translateError(err)
 }
 
+// Delete moves the deck and all of its cards to the trash. The cards share the
+// deck's deleted_at, which is how RestoreDeck finds them again.
 func (r *DeckRepo) Delete(ctx context.Context, id int64) error {
-	_, err := r.db.Exec(ctx, "DELETE FROM decks WHERE id=$1", id)
+	query := `
+	WITH deck AS (
+		UPDATE decks SET deleted_at=now() WHERE id=$1 AND deleted_at IS NULL RETURNING id, deleted_at
+	)
+	UPDATE cards SET deleted_at=deck.deleted_at FROM deck WHERE cards.deck_id=deck.id AND cards.deleted_at IS NULL;
+	`
+
+	_, err := r.db.Exec(ctx, query, id)
 	return err
 }
 
 func (r *DeckRepo) GetByID(ctx context.Context, id int64) (*structs.Deck, error) {
 	var deck structs.Deck
-	err := r.db.Get(ctx, &deck, "SELECT id, title, description, author, created_at FROM decks WHERE id=$1", id)
+	err := r.db.Get(ctx, &deck, "SELECT id, title, description, author, owner, version, created_at FROM decks WHERE id=$1 AND deleted_at IS NULL", id)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("deck not found")
+		if errors.Is(err, db.ErrNoRows) {
+			return nil, errs.NotFoundError("deck", id)
 		}
 
 		return nil, err
@@ -44,13 +55,66 @@ func (r *DeckRepo) GetByID(ctx context.Context, id int64) (*structs.Deck, error)
 	return &deck, nil
 }
 
-func (r *DeckRepo) Update(ctx context.Context, deck structs.Deck) (int64, error) {
-	result, err := r.db.Exec(ctx, `UPDATE decks SET title=$1, description=$2, author=$3 WHERE id=$4;`, deck.Title, deck.Description, deck.Author, deck.ID)
+// deckPatchColumns lists the columns Patch may change, in the order Update
+// writes them.
+var deckPatchColumns = []string{"title", "description", "author"}
+
+// Update overwrites all of the deck metadata. See Patch.
+func (r *DeckRepo) Update(ctx context.Context, deck structs.Deck, editor string) (int64, error) {
+	return r.Patch(ctx, deck, deckPatchColumns, editor)
+}
+
+// Patch changes only the given columns of the deck and records its previous
+// and new values in deck_revisions within the same statement. When
+// deck.Version is set, the deck is only updated if it is still at that
+// version. Patch returns the deck's new version, or 0 when there is no such
+// deck.
+func (r *DeckRepo) Patch(ctx context.Context, deck structs.Deck, fields []string, editor string) (int64, error) {
+	args := []interface{}{deck.ID, editor, deck.Version}
+	set := make([]string, 0, len(fields)+1)
+	for _, field := range fields {
+		var value interface{}
+		switch field {
+		case "title":
+			value = deck.Title
+		case "description":
+			value = deck.Description
+		case "author":
+			value = deck.Author
+		default:
+			return 0, errs.Violation(field, "cannot be updated")
+		}
+		args = append(args, value)
+		set = append(set, fmt.Sprintf("%s=$%d", field, len(args)))
+	}
+	set = append(set, "version=old.version+1")
+
+	query := `
+	WITH old AS (
+		SELECT id, title, description, author, version FROM decks WHERE id=$1 AND deleted_at IS NULL FOR UPDATE
+	), updated AS (
+		UPDATE decks SET ` + strings.Join(set, ", ") + `
+		FROM old WHERE decks.id=old.id AND ($3::bigint = 0 OR old.version = $3::bigint)
+		RETURNING decks.id, decks.title, decks.description, decks.author, decks.version
+	), revision AS (
+		INSERT INTO deck_revisions(deck_id, editor, old_title, old_description, old_author, new_title, new_description, new_author)
+		SELECT old.id, $2, old.title, COALESCE(old.description, ''), old.author, updated.title, COALESCE(updated.description, ''), updated.author
+		FROM old JOIN updated ON updated.id = old.id
+	)
+	SELECT (SELECT count(*) FROM old), COALESCE((SELECT version FROM updated), 0);
+	`
+
+	var found, version int64
+	err := r.db.ExecQueryRow(ctx, query, args...).Scan(&found, &version)
 	if err != nil {
-		return 0, err
+		return 0, translateError(err)
+	}
+
+	if found > 0 && version == 0 {
+		return 0, errs.ConflictError("deck", deck.ID)
 	}
 
-	return result.RowsAffected(), nil
+	return version, nil
 }
 
 func (r *DeckRepo) GetWithCardsByID(ctx context.Context, id int64) (*structs.DeckWithCards, error) {
@@ -60,16 +124,19 @@ func (r *DeckRepo) GetWithCardsByID(ctx context.Context, id int64) (*structs.Dec
 		d.title, 
 		d.description, 
 		d.author, 
+		d.version,
 		d.created_at,
 		c.id as "cards.id",
 		c.front as "cards.front",
 		c.back as "cards.back",
 		c.deck_id as "cards.deck_id",
 		c.author as "cards.author",
+		c.format as "cards.format",
+		c.version as "cards.version",
 		c.created_at as "cards.created_at"
 	FROM decks d
-	LEFT JOIN cards c ON d.id = c.deck_id
-	WHERE d.id = $1;
+	LEFT JOIN cards c ON d.id = c.deck_id AND c.deleted_at IS NULL
+	WHERE d.id = $1 AND d.deleted_at IS NULL;
     `
 
 	var rows []struct {
@@ -83,7 +150,7 @@ func (r *DeckRepo) GetWithCardsByID(ctx context.Context, id int64) (*structs.Dec
 	}
 
 	if len(rows) == 0 {
-		return nil, errors.New("no deck found with the provided ID")
+		return nil, errs.NotFoundError("deck", id)
 	}
 
 	deckWithCards := &structs.DeckWithCards{
@@ -99,3 +166,29 @@ func (r *DeckRepo) GetWithCardsByID(ctx context.Context, id int64) (*structs.Dec
 
 	return deckWithCards, nil
 }
+
+const deckRevisionColumns = `id, deck_id, editor, old_title, old_description, old_author, new_title, new_description, new_author, created_at`
+
+func (r *DeckRepo) ListRevisions(ctx context.Context, deckID int64) ([]structs.DeckRevision, error) {
+	var revisions []structs.DeckRevision
+	err := r.db.Select(ctx, &revisions, "SELECT "+deckRevisionColumns+" FROM deck_revisions WHERE deck_id=$1 ORDER BY id DESC", deckID)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (r *DeckRepo) GetRevision(ctx context.Context, deckID, revisionID int64) (*structs.DeckRevision, error) {
+	var revision structs.DeckRevision
+	err := r.db.Get(ctx, &revision, "SELECT "+deckRevisionColumns+" FROM deck_revisions WHERE deck_id=$1 AND id=$2", deckID, revisionID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRows) {
+			return nil, errs.NotFoundError("revision", revisionID)
+		}
+
+		return nil, err
+	}
+
+	return &revision, nil
+}
@@ -5,27 +5,31 @@ package postgresql
 
 import (
 	"context"
+	"errors"
+	"flash-card-manager/pkg/errs"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
-	"github.com/jackc/pgconn"
-	"homework-3/pkg/db/mocks"
-	"homework-3/pkg/repository/structs"
+	"github.com/jackc/pgx/v4"
+	"flash-card-manager/pkg/db/mocks"
+	"flash-card-manager/pkg/repository/structs"
 )
 
 func TestDeckRepo_Add(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	mockDB := mock_db.NewMockDatabaseInterface(mockCtrl)
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
 	repo := NewDeck(mockDB)
 
-	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&mockRow{value: 1})
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), "testTitle", "testDescription", "testAuthor", "alice").Return(&mockRow{value: 1})
 
 	id, err := repo.Add(context.TODO(), structs.Deck{
 		Title:       "testTitle",
 		Description: "testDescription",
 		Author:      "testAuthor",
+		Owner:       "alice",
 	})
 
 	if err != nil {
@@ -40,10 +44,10 @@ func TestDeckRepo_Delete(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	mockDB := mock_db.NewMockDatabaseInterface(mockCtrl)
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
 	repo := NewDeck(mockDB)
 
-	mockDB.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockDB.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil)
 
 	err := repo.Delete(context.TODO(), 1)
 
@@ -56,7 +60,7 @@ func TestDeckRepo_GetByID(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	mockDB := mock_db.NewMockDatabaseInterface(mockCtrl)
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
 	repo := NewDeck(mockDB)
 
 	expectedDeck := structs.Deck{
@@ -82,7 +86,7 @@ func TestDeckRepo_Update(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	mockDB := mock_db.NewMockDatabaseInterface(mockCtrl)
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
 	repo := NewDeck(mockDB)
 
 	updateDeck := structs.Deck{
@@ -92,16 +96,59 @@ func TestDeckRepo_Update(t *testing.T) {
 		Author:      "updatedAuthor",
 	}
 
-	mockCommandTagValue := pgconn.CommandTag("UPDATE 1")
-	mockDB.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockCommandTagValue, nil)
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), int64(1), "editor", int64(0), gomock.Any(), gomock.Any(), gomock.Any()).Return(&countsRow{values: []int64{1, 2}})
 
-	rowsAffected, err := repo.Update(context.TODO(), updateDeck)
+	version, err := repo.Update(context.TODO(), updateDeck, "editor")
 
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
-	if rowsAffected != 1 {
-		t.Errorf("Expected rows affected to be 1, got %d", rowsAffected)
+	if version != 2 {
+		t.Errorf("Expected new version to be 2, got %d", version)
+	}
+}
+
+func TestDeckRepo_UpdateNotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewDeck(mockDB)
+
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), int64(1), "editor", int64(3), gomock.Any(), gomock.Any(), gomock.Any()).Return(&countsRow{values: []int64{0, 0}})
+
+	version, err := repo.Update(context.TODO(), structs.Deck{ID: 1, Version: 3}, "editor")
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if version != 0 {
+		t.Errorf("Expected version to be 0 for a missing deck, got %d", version)
+	}
+}
+
+func TestDeckRepo_Patch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewDeck(mockDB)
+
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), int64(1), "editor", int64(2), "newTitle").DoAndReturn(
+		func(_ context.Context, query string, _ ...interface{}) pgx.Row {
+			if !strings.Contains(query, "SET title=$4, version=old.version+1") {
+				t.Errorf("Unexpected SET clause in query: %s", query)
+			}
+			return &countsRow{values: []int64{1, 3}}
+		})
+
+	version, err := repo.Patch(context.TODO(), structs.Deck{ID: 1, Title: "newTitle", Version: 2}, []string{"title"}, "editor")
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if version != 3 {
+		t.Errorf("Expected new version to be 3, got %d", version)
 	}
 }
 
@@ -109,7 +156,7 @@ func TestDeckRepo_GetWithCardsByID(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	mockDB := mock_db.NewMockDatabaseInterface(mockCtrl)
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
 	repo := NewDeck(mockDB)
 
 	expectedDeckWithCards := structs.DeckWithCards{
@@ -154,3 +201,63 @@ func TestDeckRepo_GetWithCardsByID(t *testing.T) {
 		t.Errorf("Unexpected cards in deck: %v", deckWithCards.Cards)
 	}
 }
+
+func TestDeckRepo_GetWithCardsByIDNotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewDeck(mockDB)
+
+	mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).Return(nil)
+
+	_, err := repo.GetWithCardsByID(context.TODO(), 1)
+
+	if !errors.Is(err, errs.ErrNotFound) || err.Error() != "deck not found" {
+		t.Errorf("Expected deck not found, got %v", err)
+	}
+}
+
+func TestDeckRepo_ListRevisions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewDeck(mockDB)
+
+	expectedRevisions := []structs.DeckRevision{
+		{ID: 1, DeckID: 1, Editor: "editor", OldTitle: "testTitle", NewTitle: "updatedTitle"},
+	}
+
+	mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).SetArg(1, expectedRevisions).Return(nil)
+
+	revisions, err := repo.ListRevisions(context.TODO(), 1)
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(revisions) != 1 || revisions[0].NewTitle != "updatedTitle" {
+		t.Errorf("Unexpected revisions: %v", revisions)
+	}
+}
+
+func TestDeckRepo_GetRevision(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewDeck(mockDB)
+
+	expectedRevision := structs.DeckRevision{ID: 1, DeckID: 1, Editor: "editor", OldTitle: "testTitle", NewTitle: "updatedTitle"}
+
+	mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), int64(1), int64(1)).SetArg(1, expectedRevision).Return(nil)
+
+	revision, err := repo.GetRevision(context.TODO(), 1, 1)
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if revision.OldTitle != "testTitle" {
+		t.Errorf("Unexpected revision details: %v", revision)
+	}
+}
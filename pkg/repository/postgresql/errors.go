@@ -0,0 +1,46 @@
+package postgresql
+
+import (
+	"errors"
+	"flash-card-manager/pkg/errs"
+
+	"github.com/jackc/pgconn"
+)
+
+// fkResources maps foreign key constraints to the resource they point at.
+var fkResources = map[string]string{
+	"fk_deck_id":                  "deck",
+	"card_revisions_card_id_fkey": "card",
+	"deck_revisions_deck_id_fkey": "deck",
+}
+
+// tableResources maps tables to the resource their rows are.
+var tableResources = map[string]string{
+	"cards": "card",
+	"decks": "deck",
+}
+
+// translateError turns the constraint violations Postgres reports into
+// domain errors, e.g. a card pointing at a deck that does not exist becomes
+// a deck NotFound. Other errors are returned unchanged.
+func translateError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case "23503": // foreign_key_violation
+		return &errs.Error{Kind: errs.NotFound, Resource: fkResources[pgErr.ConstraintName], Err: err}
+	case "23505": // unique_violation
+		return &errs.Error{Kind: errs.AlreadyExists, Resource: tableResources[pgErr.TableName], Err: err}
+	case "23502": // not_null_violation
+		return &errs.Error{
+			Kind:       errs.InvalidArgument,
+			Violations: []errs.FieldViolation{{Field: pgErr.ColumnName, Description: "must not be null"}},
+			Err:        err,
+		}
+	default:
+		return err
+	}
+}
@@ -0,0 +1,72 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+)
+
+// claimAttempts bounds how often Claim retries when the record holding a key
+// expires or is released between its two statements.
+const claimAttempts = 3
+
+type IdempotencyRepo struct {
+	db db.PostgresInterface
+}
+
+func NewIdempotency(database db.PostgresInterface) interfaces.IdempotencyRepository {
+	return &IdempotencyRepo{db: database}
+}
+
+// Claim inserts the record, taking over the key if the record holding it has
+// expired. Other expired records are deleted along the way, so the table does
+// not need a separate cleanup job.
+func (r *IdempotencyRepo) Claim(ctx context.Context, record structs.IdempotencyRecord) (*structs.IdempotencyRecord, error) {
+	query := `
+	WITH expired AS (
+		DELETE FROM idempotency_keys WHERE expires_at <= now() AND key <> $1
+	)
+	INSERT INTO idempotency_keys(key, request_hash, expires_at) VALUES($1, $2, $3)
+	ON CONFLICT (key) DO UPDATE SET request_hash=EXCLUDED.request_hash, response=NULL, response_header=NULL, expires_at=EXCLUDED.expires_at
+	WHERE idempotency_keys.expires_at <= now()
+	RETURNING key;
+	`
+
+	for attempt := 0; attempt < claimAttempts; attempt++ {
+		var key string
+		err := r.db.ExecQueryRow(ctx, query, record.Key, record.RequestHash, record.ExpiresAt).Scan(&key)
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, db.ErrNoRows) {
+			return nil, err
+		}
+
+		var holder structs.IdempotencyRecord
+		err = r.db.Get(ctx, &holder, "SELECT key, request_hash, response, response_header, expires_at FROM idempotency_keys WHERE key=$1 AND expires_at > now()", record.Key)
+		if err == nil {
+			return &holder, nil
+		}
+		if !errors.Is(err, db.ErrNoRows) {
+			return nil, err
+		}
+	}
+
+	return nil, errors.New("idempotency key is contended")
+}
+
+func (r *IdempotencyRepo) Complete(ctx context.Context, record structs.IdempotencyRecord) error {
+	query := `
+	UPDATE idempotency_keys SET response=$3, response_header=$4, expires_at=$5
+	WHERE key=$1 AND request_hash=$2 AND response IS NULL AND expires_at > now();
+	`
+	_, err := r.db.Exec(ctx, query, record.Key, record.RequestHash, record.Response, record.Header, record.ExpiresAt)
+	return err
+}
+
+func (r *IdempotencyRepo) Release(ctx context.Context, key string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM idempotency_keys WHERE key=$1 AND response IS NULL", key)
+	return err
+}
@@ -0,0 +1,50 @@
+//go:build unit
+// +build unit
+
+package postgresql
+
+import (
+	"context"
+	"flash-card-manager/pkg/db"
+	mock_db "flash-card-manager/pkg/db/mocks"
+	"flash-card-manager/pkg/repository/structs"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestIdempotencyRepo_ClaimHeldKey(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewIdempotency(mockDB)
+
+	held := structs.IdempotencyRecord{Key: "k", RequestHash: []byte{1}, Response: []byte{2}}
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), "k", gomock.Any(), gomock.Any()).Return(&countsRow{err: db.ErrNoRows})
+	mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), "k").SetArg(1, held).Return(nil)
+
+	record, err := repo.Claim(context.TODO(), structs.IdempotencyRecord{Key: "k", RequestHash: []byte{3}})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if record == nil || string(record.Response) != string(held.Response) {
+		t.Errorf("Expected the held record, got %v", record)
+	}
+}
+
+func TestIdempotencyRepo_ClaimContended(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewIdempotency(mockDB)
+
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), "k", gomock.Any(), gomock.Any()).Return(&countsRow{err: db.ErrNoRows}).Times(claimAttempts)
+	mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), "k").Return(db.ErrNoRows).Times(claimAttempts)
+
+	_, err := repo.Claim(context.TODO(), structs.IdempotencyRecord{Key: "k"})
+	if err == nil {
+		t.Error("Expected an error when the key keeps changing hands")
+	}
+}
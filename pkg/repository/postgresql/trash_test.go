@@ -0,0 +1,120 @@
+//go:build unit
+// +build unit
+
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/db"
+	mock_db "flash-card-manager/pkg/db/mocks"
+	"flash-card-manager/pkg/repository/structs"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+type countsRow struct {
+	values []int64
+	err    error
+}
+
+func (m *countsRow) Scan(dest ...interface{}) error {
+	if m.err != nil {
+		return m.err
+	}
+	for i := range dest {
+		valPtr, ok := dest[i].(*int64)
+		if !ok {
+			return errors.New("unsupported type for Scan")
+		}
+		*valPtr = m.values[i]
+	}
+	return nil
+}
+
+func TestTrashRepo_ListDecks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewTrash(mockDB)
+
+	deletedAt := time.Now()
+	expectedDecks := []structs.TrashedDeck{
+		{Deck: structs.Deck{ID: 1, Title: "testTitle", DeletedAt: &deletedAt}, CardCount: 2},
+	}
+
+	mockDB.EXPECT().Select(gomock.Any(), gomock.Any(), gomock.Any(), "alice").SetArg(1, expectedDecks).Return(nil)
+
+	decks, err := repo.ListDecks(context.TODO(), "alice")
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(decks) != 1 || decks[0].CardCount != 2 {
+		t.Errorf("Unexpected decks: %v", decks)
+	}
+}
+
+func TestTrashRepo_RestoreDeck(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewTrash(mockDB)
+
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), int64(1)).Return(&countsRow{values: []int64{1, 3}})
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), int64(2)).Return(&countsRow{values: []int64{0, 0}})
+
+	restored, err := repo.RestoreDeck(context.TODO(), 1)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if restored != 3 {
+		t.Errorf("Expected 3 restored cards, got %d", restored)
+	}
+
+	_, err = repo.RestoreDeck(context.TODO(), 2)
+	if err == nil || err.Error() != "deck not found in trash" {
+		t.Errorf("Expected deck not found in trash error, got %v", err)
+	}
+}
+
+func TestTrashRepo_RestoreCardNotInTrash(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewTrash(mockDB)
+
+	expectTx(mockDB)
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), int64(1)).Return(&countsRow{err: db.ErrNoRows})
+
+	err := repo.RestoreCard(context.TODO(), 1)
+	if err == nil || err.Error() != "card not found in trash" {
+		t.Errorf("Expected card not found in trash error, got %v", err)
+	}
+}
+
+func TestTrashRepo_Purge(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewTrash(mockDB)
+
+	deletedBefore := time.Now()
+	owner := "alice"
+	mockDB.EXPECT().ExecQueryRow(gomock.Any(), gomock.Any(), &owner, deletedBefore).Return(&countsRow{values: []int64{1, 5}})
+
+	decks, cards, err := repo.PurgeOwner(context.TODO(), owner, deletedBefore)
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if decks != 1 || cards != 5 {
+		t.Errorf("Unexpected purge counts: %d decks, %d cards", decks, cards)
+	}
+}
@@ -0,0 +1,47 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+)
+
+type UsageRepo struct {
+	db db.PostgresInterface
+}
+
+func NewUsage(database db.PostgresInterface) interfaces.UsageRepository {
+	return &UsageRepo{db: database}
+}
+
+func (r *UsageRepo) Usage(ctx context.Context, owner string) (structs.Usage, error) {
+	query := `
+	SELECT
+		(SELECT count(*) FROM decks WHERE owner=$1 AND deleted_at IS NULL) AS decks,
+		(SELECT COALESCE(sum(octet_length(cards.front) + octet_length(cards.back)), 0)
+		 FROM cards JOIN decks ON decks.id = cards.deck_id
+		 WHERE decks.owner=$1 AND decks.deleted_at IS NULL AND cards.deleted_at IS NULL) AS media_bytes;
+	`
+
+	var usage structs.Usage
+	err := r.db.Get(ctx, &usage, query, owner)
+	return usage, err
+}
+
+func (r *UsageRepo) DeckUsage(ctx context.Context, deckID int64) (structs.DeckUsage, error) {
+	query := `
+	SELECT decks.id AS deck_id, decks.owner,
+		(SELECT count(*) FROM cards WHERE cards.deck_id = decks.id AND cards.deleted_at IS NULL) AS cards
+	FROM decks WHERE decks.id=$1 AND decks.deleted_at IS NULL;
+	`
+
+	var usage structs.DeckUsage
+	err := r.db.Get(ctx, &usage, query, deckID)
+	if errors.Is(err, db.ErrNoRows) {
+		return structs.DeckUsage{}, errs.NotFoundError("deck", deckID)
+	}
+	return usage, err
+}
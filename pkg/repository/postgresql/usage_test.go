@@ -0,0 +1,47 @@
+//go:build unit
+// +build unit
+
+package postgresql
+
+import (
+	"context"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/db/mocks"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/structs"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageRepo_Usage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewUsage(mockDB)
+
+	expected := structs.Usage{Decks: 2, MediaBytes: 1024}
+	mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), "alice").SetArg(1, expected).Return(nil)
+
+	usage, err := repo.Usage(context.Background(), "alice")
+	require.NoError(t, err)
+	assert.Equal(t, expected, usage)
+}
+
+func TestUsageRepo_DeckUsage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockDB := mock_db.NewMockPostgresInterface(mockCtrl)
+	repo := NewUsage(mockDB)
+
+	expected := structs.DeckUsage{DeckID: 1, Owner: "alice", Cards: 3}
+	mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), int64(1)).SetArg(1, expected).Return(nil)
+	mockDB.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), int64(2)).Return(db.ErrNoRows)
+
+	usage, err := repo.DeckUsage(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, expected, usage)
+
+	_, err = repo.DeckUsage(context.Background(), 2)
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+}
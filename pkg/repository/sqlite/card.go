@@ -0,0 +1,185 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+)
+
+type CardRepo struct {
+	db db.DatabaseInterface
+}
+
+func NewCard(database db.DatabaseInterface) interfaces.CardRepository {
+	return &CardRepo{db: database}
+}
+
+// Add inserts the card unless its deck is missing or sits in the trash.
+func (r *CardRepo) Add(ctx context.Context, card structs.Card) (int64, error) {
+	query := `
+	INSERT INTO cards(front, back, deck_id, author, format, created_at)
+	SELECT ?, ?, id, ?, ?, ? FROM decks WHERE id=? AND deleted_at IS NULL
+	RETURNING id;
+	`
+
+	var id int64
+	err := r.db.ExecQueryRow(ctx, query, card.Front, card.Back, card.Author, card.Format, now(), card.DeckID).Scan(&id)
+	if errors.Is(err, db.ErrNoRows) {
+		return 0, errs.NotFoundError("deck", card.DeckID)
+	}
+
+	return id, translateError(err)
+}
+
+// Delete moves the card to the trash. It is removed for good by PurgeTrash.
+func (r *CardRepo) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.Exec(ctx, "UPDATE cards SET deleted_at=? WHERE id=? AND deleted_at IS NULL", now(), id)
+	return err
+}
+
+func (r *CardRepo) GetByID(ctx context.Context, id int64) (*structs.Card, error) {
+	var card structs.Card
+	err := r.db.Get(ctx, &card, "SELECT id, front, back, deck_id, author, format, version, created_at FROM cards WHERE id=? AND deleted_at IS NULL", id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRows) {
+			return nil, errs.NotFoundError("card", id)
+		}
+
+		return nil, err
+	}
+
+	return &card, nil
+}
+
+var cardPatchColumns = []string{"front", "back", "deck_id", "author", "format"}
+
+// Update overwrites every editable column of the card. See Patch.
+func (r *CardRepo) Update(ctx context.Context, card structs.Card, editor string) (int64, error) {
+	return r.Patch(ctx, card, cardPatchColumns, editor)
+}
+
+// Patch changes only the given columns of the card and records its previous
+// and new contents in card_revisions within one transaction. When
+// card.Version is set, the card is only updated if it is still at that
+// version. Patch returns the card's new version, or 0 when there is no such
+// card.
+func (r *CardRepo) Patch(ctx context.Context, card structs.Card, fields []string, editor string) (int64, error) {
+	var version int64
+	err := r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		var err error
+		version, err = r.patch(ctx, card, fields, editor)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// patch is Patch within the caller's transaction.
+func (r *CardRepo) patch(ctx context.Context, card structs.Card, fields []string, editor string) (int64, error) {
+	for _, field := range fields {
+		switch field {
+		case "front", "back", "deck_id", "author", "format":
+		default:
+			return 0, errs.Violation(field, "cannot be updated")
+		}
+	}
+
+	var old structs.Card
+	err := r.db.Get(ctx, &old, "SELECT id, front, back, deck_id, author, format, version FROM cards WHERE id=? AND deleted_at IS NULL", card.ID)
+	if errors.Is(err, db.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if card.Version != 0 && old.Version != card.Version {
+		return 0, errs.ConflictError("card", card.ID)
+	}
+
+	updated := old
+	for _, field := range fields {
+		switch field {
+		case "front":
+			updated.Front = card.Front
+		case "back":
+			updated.Back = card.Back
+		case "deck_id":
+			// Like Add, this requires a deck that is not in the trash.
+			if err := r.requireLiveDeck(ctx, card.DeckID); err != nil {
+				return 0, err
+			}
+			updated.DeckID = card.DeckID
+		case "author":
+			updated.Author = card.Author
+		case "format":
+			updated.Format = card.Format
+		}
+	}
+
+	var version int64
+	err = r.db.ExecQueryRow(ctx, `
+	UPDATE cards SET front=?, back=?, deck_id=?, author=?, format=?, version=version+1
+	WHERE id=?
+	RETURNING version;
+	`, updated.Front, updated.Back, updated.DeckID, updated.Author, updated.Format, card.ID).Scan(&version)
+	if err != nil {
+		return 0, translateError(err)
+	}
+
+	_, err = r.db.Exec(ctx, `
+	INSERT INTO card_revisions(card_id, editor, old_front, old_back, old_deck_id, old_author, old_format, new_front, new_back, new_deck_id, new_author, new_format, created_at)
+	VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`, old.ID, editor, old.Front, old.Back, old.DeckID, old.Author, old.Format,
+		updated.Front, updated.Back, updated.DeckID, updated.Author, updated.Format, now())
+	if err != nil {
+		return 0, translateError(err)
+	}
+
+	return version, nil
+}
+
+const cardRevisionColumns = `id, card_id, editor, old_front, old_back, old_deck_id, old_author, old_format, new_front, new_back, new_deck_id, new_author, new_format, created_at`
+
+func (r *CardRepo) ListRevisions(ctx context.Context, cardID int64, page structs.Page) ([]structs.CardRevision, error) {
+	var revisions []structs.CardRevision
+	err := r.db.Select(ctx, &revisions, "SELECT "+cardRevisionColumns+" FROM card_revisions WHERE card_id=?1 AND (?2 = 0 OR id < ?2) ORDER BY id DESC LIMIT ?3",
+		cardID, page.After, page.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (r *CardRepo) GetRevision(ctx context.Context, cardID, revisionID int64) (*structs.CardRevision, error) {
+	var revision structs.CardRevision
+	err := r.db.Get(ctx, &revision, "SELECT "+cardRevisionColumns+" FROM card_revisions WHERE card_id=? AND id=?", cardID, revisionID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRows) {
+			return nil, errs.NotFoundError("revision", revisionID)
+		}
+
+		return nil, err
+	}
+
+	return &revision, nil
+}
+
+// requireLiveDeck fails with NotFound unless the deck exists and is not in
+// the trash.
+func (r *CardRepo) requireLiveDeck(ctx context.Context, id int64) error {
+	var live bool
+	if err := r.db.ExecQueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM decks WHERE id=? AND deleted_at IS NULL)", id).Scan(&live); err != nil {
+		return err
+	}
+	if !live {
+		return errs.NotFoundError("deck", id)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/structs"
+)
+
+// BatchAdd inserts all cards in one transaction, or none of them if any deck
+// is missing or in the trash. IDs come back in input order.
+func (r *CardRepo) BatchAdd(ctx context.Context, cards []structs.Card) ([]int64, error) {
+	if len(cards) == 0 {
+		return nil, nil
+	}
+
+	var ids []int64
+	err := r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		ids = make([]int64, 0, len(cards))
+		for i, card := range cards {
+			id, err := r.Add(ctx, card)
+			if err != nil {
+				return &structs.BatchError{Index: i, Err: err}
+			}
+			ids = append(ids, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// BatchUpdate applies Update to every card in one transaction and returns the
+// new versions in input order. A missing card or a version mismatch rolls
+// back the whole batch.
+func (r *CardRepo) BatchUpdate(ctx context.Context, cards []structs.Card, editor string) ([]int64, error) {
+	if len(cards) == 0 {
+		return nil, nil
+	}
+
+	var versions []int64
+	err := r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		versions = make([]int64, 0, len(cards))
+		for i, card := range cards {
+			version, err := r.patchItem(ctx, card, cardPatchColumns, editor)
+			if err != nil {
+				return &structs.BatchError{Index: i, Err: err}
+			}
+			versions = append(versions, version)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// BatchDelete moves all the cards to the trash, or none of them if any is
+// missing or already deleted.
+func (r *CardRepo) BatchDelete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	deletedAt := now()
+	return r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		for i, id := range ids {
+			deleted, err := r.db.Exec(ctx, "UPDATE cards SET deleted_at=? WHERE id=? AND deleted_at IS NULL", deletedAt, id)
+			if err != nil {
+				return err
+			}
+			if deleted == 0 {
+				return &structs.BatchError{Index: i, Err: errs.NotFoundError("card", id)}
+			}
+		}
+		return nil
+	})
+}
+
+// Move puts all the cards into the deck and records a revision for each. It
+// returns the new versions in input order.
+func (r *CardRepo) Move(ctx context.Context, ids []int64, deckID int64, editor string) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var versions []int64
+	err := r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		if err := r.requireLiveDeck(ctx, deckID); err != nil {
+			return err
+		}
+
+		versions = make([]int64, 0, len(ids))
+		for i, id := range ids {
+			version, err := r.patchItem(ctx, structs.Card{ID: id, DeckID: deckID}, []string{"deck_id"}, editor)
+			if err != nil {
+				return &structs.BatchError{Index: i, Err: err}
+			}
+			versions = append(versions, version)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// patchItem is patch for a batch item, where a missing card is an error.
+func (r *CardRepo) patchItem(ctx context.Context, card structs.Card, fields []string, editor string) (int64, error) {
+	version, err := r.patch(ctx, card, fields, editor)
+	if err == nil && version == 0 {
+		return 0, errs.NotFoundError("card", card.ID)
+	}
+	return version, err
+}
@@ -0,0 +1,38 @@
+//go:build unit
+// +build unit
+
+package sqlite
+
+import (
+	"context"
+	"flash-card-manager/internal/app/migrate"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/tests/conformance"
+	"path/filepath"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// newDatabase opens a fresh SQLite database with all migrations applied.
+func newDatabase(t *testing.T) *db.SQLiteDatabase {
+	database, err := db.NewSQLite(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(database.Close)
+
+	goose.SetLogger(goose.NopLogger())
+	migrator, err := migrate.New(database)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up(context.Background()))
+
+	return database
+}
+
+func TestConformance(t *testing.T) {
+	suite.Run(t, &conformance.Suite{New: func(t *testing.T) conformance.Repositories {
+		database := newDatabase(t)
+		return conformance.Repositories{Cards: NewCard(database), Decks: NewDeck(database), Trash: NewTrash(database), Usage: NewUsage(database), Tx: database}
+	}})
+}
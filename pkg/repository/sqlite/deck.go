@@ -0,0 +1,171 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+)
+
+type DeckRepo struct {
+	db db.DatabaseInterface
+}
+
+func NewDeck(database db.DatabaseInterface) interfaces.DeckRepository {
+	return &DeckRepo{db: database}
+}
+
+func (r *DeckRepo) Add(ctx context.Context, deck structs.Deck) (int64, error) {
+	var id int64
+	err := r.db.ExecQueryRow(ctx, `INSERT INTO decks(title, description, author, owner, created_at) VALUES(?, ?, ?, ?, ?) RETURNING id;`, deck.Title, deck.Description, deck.Author, deck.Owner, now()).Scan(&id)
+
+	return id, translateError(err)
+}
+
+// Delete moves the deck and all of its cards to the trash. The cards share the
+// deck's deleted_at, which is how RestoreDeck finds them again.
+func (r *DeckRepo) Delete(ctx context.Context, id int64) error {
+	deletedAt := now()
+	return r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		deleted, err := r.db.Exec(ctx, "UPDATE decks SET deleted_at=? WHERE id=? AND deleted_at IS NULL", deletedAt, id)
+		if err != nil || deleted == 0 {
+			return err
+		}
+
+		_, err = r.db.Exec(ctx, "UPDATE cards SET deleted_at=? WHERE deck_id=? AND deleted_at IS NULL", deletedAt, id)
+		return err
+	})
+}
+
+func (r *DeckRepo) GetByID(ctx context.Context, id int64) (*structs.Deck, error) {
+	var deck structs.Deck
+	err := r.db.Get(ctx, &deck, "SELECT id, title, description, author, owner, version, created_at FROM decks WHERE id=? AND deleted_at IS NULL", id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRows) {
+			return nil, errs.NotFoundError("deck", id)
+		}
+
+		return nil, err
+	}
+
+	return &deck, nil
+}
+
+var deckPatchColumns = []string{"title", "description", "author"}
+
+// Update overwrites all of the deck metadata. See Patch.
+func (r *DeckRepo) Update(ctx context.Context, deck structs.Deck, editor string) (int64, error) {
+	return r.Patch(ctx, deck, deckPatchColumns, editor)
+}
+
+// Patch changes only the given columns of the deck and records its previous
+// and new values in deck_revisions within one transaction. When deck.Version
+// is set, the deck is only updated if it is still at that version. Patch
+// returns the deck's new version, or 0 when there is no such deck.
+func (r *DeckRepo) Patch(ctx context.Context, deck structs.Deck, fields []string, editor string) (int64, error) {
+	for _, field := range fields {
+		switch field {
+		case "title", "description", "author":
+		default:
+			return 0, errs.Violation(field, "cannot be updated")
+		}
+	}
+
+	var version int64
+	err := r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		var old structs.Deck
+		err := r.db.Get(ctx, &old, "SELECT id, title, description, author, version FROM decks WHERE id=? AND deleted_at IS NULL", deck.ID)
+		if errors.Is(err, db.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if deck.Version != 0 && old.Version != deck.Version {
+			return errs.ConflictError("deck", deck.ID)
+		}
+
+		updated := old
+		for _, field := range fields {
+			switch field {
+			case "title":
+				updated.Title = deck.Title
+			case "description":
+				updated.Description = deck.Description
+			case "author":
+				updated.Author = deck.Author
+			}
+		}
+
+		err = r.db.ExecQueryRow(ctx, `
+		UPDATE decks SET title=?, description=?, author=?, version=version+1
+		WHERE id=?
+		RETURNING version;
+		`, updated.Title, updated.Description, updated.Author, deck.ID).Scan(&version)
+		if err != nil {
+			return translateError(err)
+		}
+
+		_, err = r.db.Exec(ctx, `
+		INSERT INTO deck_revisions(deck_id, editor, old_title, old_description, old_author, new_title, new_description, new_author, created_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?);
+		`, old.ID, editor, old.Title, old.Description, old.Author, updated.Title, updated.Description, updated.Author, now())
+		return translateError(err)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (r *DeckRepo) GetWithCardsByID(ctx context.Context, id int64) (*structs.DeckWithCards, error) {
+	var deckWithCards *structs.DeckWithCards
+	err := r.db.RunInTx(ctx, db.TxOptions{ReadOnly: true}, func(ctx context.Context) error {
+		deck, err := r.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		deckWithCards = &structs.DeckWithCards{Deck: *deck, Cards: []structs.Card{}}
+		return r.db.Select(ctx, &deckWithCards.Cards, `
+		SELECT id, front, back, deck_id, author, format, version, created_at
+		FROM cards
+		WHERE deck_id=? AND deleted_at IS NULL
+		ORDER BY id;
+		`, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deckWithCards, nil
+}
+
+const deckRevisionColumns = `id, deck_id, editor, old_title, old_description, old_author, new_title, new_description, new_author, created_at`
+
+func (r *DeckRepo) ListRevisions(ctx context.Context, deckID int64) ([]structs.DeckRevision, error) {
+	var revisions []structs.DeckRevision
+	err := r.db.Select(ctx, &revisions, "SELECT "+deckRevisionColumns+" FROM deck_revisions WHERE deck_id=? ORDER BY id DESC", deckID)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (r *DeckRepo) GetRevision(ctx context.Context, deckID, revisionID int64) (*structs.DeckRevision, error) {
+	var revision structs.DeckRevision
+	err := r.db.Get(ctx, &revision, "SELECT "+deckRevisionColumns+" FROM deck_revisions WHERE deck_id=? AND id=?", deckID, revisionID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoRows) {
+			return nil, errs.NotFoundError("revision", revisionID)
+		}
+
+		return nil, err
+	}
+
+	return &revision, nil
+}
@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"errors"
+	"flash-card-manager/pkg/errs"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// tableResources maps tables to the resource their rows are.
+var tableResources = map[string]string{
+	"cards": "card",
+	"decks": "deck",
+}
+
+// translateError turns the constraint violations SQLite reports into domain
+// errors. SQLite does not name the foreign key that failed, so repositories
+// check the references they care about themselves. Other errors are returned
+// unchanged.
+func translateError(err error) error {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+
+	// Messages look like "UNIQUE constraint failed: cards.id".
+	_, column, _ := strings.Cut(sqliteErr.Error(), ": ")
+	table, column, _ := strings.Cut(column, ".")
+
+	switch sqliteErr.ExtendedCode {
+	case sqlite3.ErrConstraintForeignKey:
+		return &errs.Error{Kind: errs.NotFound, Err: err}
+	case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+		return &errs.Error{Kind: errs.AlreadyExists, Resource: tableResources[table], Err: err}
+	case sqlite3.ErrConstraintNotNull:
+		return &errs.Error{
+			Kind:       errs.InvalidArgument,
+			Violations: []errs.FieldViolation{{Field: column, Description: "must not be null"}},
+			Err:        err,
+		}
+	default:
+		return err
+	}
+}
+
+// now is the time written to created_at and deleted_at. Timestamps are
+// stored as text, so they are kept in UTC to compare correctly.
+func now() time.Time {
+	return time.Now().UTC()
+}
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+)
+
+type IdempotencyRepo struct {
+	db db.DatabaseInterface
+}
+
+func NewIdempotency(database db.DatabaseInterface) interfaces.IdempotencyRepository {
+	return &IdempotencyRepo{db: database}
+}
+
+// Claim inserts the record, taking over the key if the record holding it has
+// expired. Other expired records are deleted along the way, so the table does
+// not need a separate cleanup job.
+func (r *IdempotencyRepo) Claim(ctx context.Context, record structs.IdempotencyRecord) (*structs.IdempotencyRecord, error) {
+	var holder *structs.IdempotencyRecord
+	err := r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		current := now()
+		_, err := r.db.Exec(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= ? AND key <> ?", current, record.Key)
+		if err != nil {
+			return err
+		}
+
+		var key string
+		err = r.db.ExecQueryRow(ctx, `
+		INSERT INTO idempotency_keys(key, request_hash, expires_at) VALUES(?1, ?2, ?3)
+		ON CONFLICT (key) DO UPDATE SET request_hash=excluded.request_hash, response=NULL, response_header=NULL, expires_at=excluded.expires_at
+		WHERE idempotency_keys.expires_at <= ?4
+		RETURNING key;
+		`, record.Key, record.RequestHash, record.ExpiresAt.UTC(), current).Scan(&key)
+		if !errors.Is(err, db.ErrNoRows) {
+			return err
+		}
+
+		holder = &structs.IdempotencyRecord{}
+		return r.db.Get(ctx, holder, "SELECT key, request_hash, response, response_header, expires_at FROM idempotency_keys WHERE key=?", record.Key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return holder, nil
+}
+
+func (r *IdempotencyRepo) Complete(ctx context.Context, record structs.IdempotencyRecord) error {
+	_, err := r.db.Exec(ctx, `
+	UPDATE idempotency_keys SET response=?3, response_header=?4, expires_at=?5
+	WHERE key=?1 AND request_hash=?2 AND response IS NULL AND expires_at > ?6;
+	`, record.Key, record.RequestHash, record.Response, record.Header, record.ExpiresAt.UTC(), now())
+	return err
+}
+
+func (r *IdempotencyRepo) Release(ctx context.Context, key string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM idempotency_keys WHERE key=? AND response IS NULL", key)
+	return err
+}
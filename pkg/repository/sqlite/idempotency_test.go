@@ -0,0 +1,55 @@
+//go:build unit
+// +build unit
+
+package sqlite
+
+import (
+	"context"
+	"flash-card-manager/pkg/repository/structs"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyRepo(t *testing.T) {
+	ctx := context.Background()
+	repo := NewIdempotency(newDatabase(t))
+	record := structs.IdempotencyRecord{Key: "k", RequestHash: []byte{1}, ExpiresAt: time.Now().Add(time.Hour)}
+
+	held, err := repo.Claim(ctx, record)
+	require.NoError(t, err)
+	assert.Nil(t, held)
+
+	held, err = repo.Claim(ctx, record)
+	require.NoError(t, err)
+	require.NotNil(t, held)
+	assert.Nil(t, held.Response)
+
+	stale := record
+	stale.RequestHash, stale.Response = []byte{9}, []byte{9}
+	require.NoError(t, repo.Complete(ctx, stale))
+	completed := record
+	completed.Response, completed.Header = []byte{2}, []byte{3}
+	require.NoError(t, repo.Complete(ctx, completed))
+	held, err = repo.Claim(ctx, record)
+	require.NoError(t, err)
+	require.NotNil(t, held)
+	assert.Equal(t, []byte{1}, held.RequestHash)
+	assert.Equal(t, []byte{2}, held.Response)
+	assert.Equal(t, []byte{3}, held.Header)
+
+	require.NoError(t, repo.Release(ctx, "k"))
+	held, err = repo.Claim(ctx, record)
+	require.NoError(t, err)
+	assert.NotNil(t, held, "a completed key is not released")
+
+	expired := structs.IdempotencyRecord{Key: "expired", RequestHash: []byte{1}, ExpiresAt: time.Now().Add(-time.Second)}
+	_, err = repo.Claim(ctx, expired)
+	require.NoError(t, err)
+	expired.ExpiresAt = time.Now().Add(time.Hour)
+	held, err = repo.Claim(ctx, expired)
+	require.NoError(t, err)
+	assert.Nil(t, held)
+}
@@ -0,0 +1,151 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+	"time"
+)
+
+type TrashRepo struct {
+	db db.DatabaseInterface
+}
+
+func NewTrash(database db.DatabaseInterface) interfaces.TrashRepository {
+	return &TrashRepo{db: database}
+}
+
+func (r *TrashRepo) ListDecks(ctx context.Context, owner string) ([]structs.TrashedDeck, error) {
+	query := `
+	SELECT d.id, d.title, d.description, d.author, d.owner, d.version, d.created_at, d.deleted_at,
+		(SELECT count(*) FROM cards c WHERE c.deck_id = d.id AND c.deleted_at = d.deleted_at) AS card_count
+	FROM decks d
+	WHERE d.owner=? AND d.deleted_at IS NOT NULL
+	ORDER BY d.deleted_at DESC;
+	`
+
+	var decks []structs.TrashedDeck
+	if err := r.db.Select(ctx, &decks, query, owner); err != nil {
+		return nil, err
+	}
+
+	return decks, nil
+}
+
+// ListCards returns cards that were deleted on their own. Cards deleted
+// together with their deck are listed under the deck instead.
+func (r *TrashRepo) ListCards(ctx context.Context, owner string) ([]structs.Card, error) {
+	query := `
+	SELECT c.id, c.front, c.back, c.deck_id, c.author, c.format, c.version, c.created_at, c.deleted_at
+	FROM cards c
+	JOIN decks d ON d.id = c.deck_id
+	WHERE d.owner=? AND c.deleted_at IS NOT NULL AND d.deleted_at IS NULL
+	ORDER BY c.deleted_at DESC;
+	`
+
+	var cards []structs.Card
+	if err := r.db.Select(ctx, &cards, query, owner); err != nil {
+		return nil, err
+	}
+
+	return cards, nil
+}
+
+// RestoreDeck takes the deck out of the trash along with the cards that were
+// deleted in the same DeleteDeck call. Cards deleted on their own earlier stay
+// in the trash.
+func (r *TrashRepo) RestoreDeck(ctx context.Context, id int64) (int64, error) {
+	var restored int64
+	err := r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		var deletedAt time.Time
+		err := r.db.ExecQueryRow(ctx, "SELECT deleted_at FROM decks WHERE id=? AND deleted_at IS NOT NULL", id).Scan(&deletedAt)
+		if errors.Is(err, db.ErrNoRows) {
+			return &errs.Error{Kind: errs.NotFound, Resource: "deck", ID: id, Message: "deck not found in trash"}
+		}
+		if err != nil {
+			return err
+		}
+
+		restored, err = r.db.Exec(ctx, "UPDATE cards SET deleted_at=NULL WHERE deck_id=? AND deleted_at=?", id, deletedAt)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.Exec(ctx, "UPDATE decks SET deleted_at=NULL WHERE id=?", id)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return restored, nil
+}
+
+// RestoreCard takes a single card out of the trash. A card whose deck is
+// still in the trash cannot be restored on its own.
+func (r *TrashRepo) RestoreCard(ctx context.Context, id int64) error {
+	return r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		var deckDeleted bool
+		err := r.db.ExecQueryRow(ctx, `
+		SELECT d.deleted_at IS NOT NULL
+		FROM cards c
+		JOIN decks d ON d.id = c.deck_id
+		WHERE c.id=? AND c.deleted_at IS NOT NULL;
+		`, id).Scan(&deckDeleted)
+		if err != nil {
+			if errors.Is(err, db.ErrNoRows) {
+				return &errs.Error{Kind: errs.NotFound, Resource: "card", ID: id, Message: "card not found in trash"}
+			}
+			return err
+		}
+
+		if deckDeleted {
+			return errs.FailedPreconditionError("card", id, "deck is in trash")
+		}
+
+		_, err = r.db.Exec(ctx, "UPDATE cards SET deleted_at=NULL WHERE id=?", id)
+		return err
+	})
+}
+
+// Purge permanently removes everything that was put into the trash before
+// deletedBefore. Revisions go with their cards and decks through ON DELETE
+// CASCADE.
+func (r *TrashRepo) Purge(ctx context.Context, deletedBefore time.Time) (int64, int64, error) {
+	return r.purge(ctx, nil, deletedBefore)
+}
+
+// PurgeOwner is Purge limited to the decks of owner and their cards.
+func (r *TrashRepo) PurgeOwner(ctx context.Context, owner string, deletedBefore time.Time) (int64, int64, error) {
+	return r.purge(ctx, &owner, deletedBefore)
+}
+
+// purge purges the trash of owner, or of everyone when owner is nil.
+func (r *TrashRepo) purge(ctx context.Context, owner *string, deletedBefore time.Time) (int64, int64, error) {
+	deletedBefore = deletedBefore.UTC()
+
+	var decks, cards int64
+	err := r.db.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		var err error
+		cards, err = r.db.Exec(ctx, `
+		DELETE FROM cards
+		WHERE deck_id IN (SELECT id FROM decks WHERE ?1 IS NULL OR owner=?1)
+			AND ((deleted_at IS NOT NULL AND deleted_at < ?2)
+				OR deck_id IN (SELECT id FROM decks WHERE deleted_at IS NOT NULL AND deleted_at < ?2));
+		`, owner, deletedBefore)
+		if err != nil {
+			return err
+		}
+
+		decks, err = r.db.Exec(ctx, "DELETE FROM decks WHERE (?1 IS NULL OR owner=?1) AND deleted_at IS NOT NULL AND deleted_at < ?2", owner, deletedBefore)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return decks, cards, nil
+}
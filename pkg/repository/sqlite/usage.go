@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+)
+
+type UsageRepo struct {
+	db db.DatabaseInterface
+}
+
+func NewUsage(database db.DatabaseInterface) interfaces.UsageRepository {
+	return &UsageRepo{db: database}
+}
+
+func (r *UsageRepo) Usage(ctx context.Context, owner string) (structs.Usage, error) {
+	query := `
+	SELECT
+		(SELECT count(*) FROM decks WHERE owner=? AND deleted_at IS NULL) AS decks,
+		(SELECT COALESCE(sum(length(CAST(cards.front AS BLOB)) + length(CAST(cards.back AS BLOB))), 0)
+		 FROM cards JOIN decks ON decks.id = cards.deck_id
+		 WHERE decks.owner=? AND decks.deleted_at IS NULL AND cards.deleted_at IS NULL) AS media_bytes;
+	`
+
+	var usage structs.Usage
+	err := r.db.Get(ctx, &usage, query, owner, owner)
+	return usage, err
+}
+
+func (r *UsageRepo) DeckUsage(ctx context.Context, deckID int64) (structs.DeckUsage, error) {
+	query := `
+	SELECT decks.id AS deck_id, decks.owner,
+		(SELECT count(*) FROM cards WHERE cards.deck_id = decks.id AND cards.deleted_at IS NULL) AS cards
+	FROM decks WHERE decks.id=? AND decks.deleted_at IS NULL;
+	`
+
+	var usage structs.DeckUsage
+	err := r.db.Get(ctx, &usage, query, deckID)
+	if errors.Is(err, db.ErrNoRows) {
+		return structs.DeckUsage{}, errs.NotFoundError("deck", deckID)
+	}
+	return usage, err
+}
@@ -0,0 +1,18 @@
+package structs
+
+import "fmt"
+
+// BatchError reports the item that made a batch operation fail. Batches are
+// all-or-nothing, so nothing from the batch was written.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
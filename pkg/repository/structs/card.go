@@ -3,10 +3,30 @@ package structs
 import "time"
 
 type Card struct {
+	ID        int64      `db:"id"`
+	Front     string     `db:"front"`
+	Back      string     `db:"back"`
+	DeckID    int64      `db:"deck_id"`
+	Author    string     `db:"author"`
+	Format    string     `db:"format"`
+	Version   int64      `db:"version"`
+	CreatedAt time.Time  `db:"created_at"`
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+type CardRevision struct {
 	ID        int64     `db:"id"`
-	Front     string    `db:"front"`
-	Back      string    `db:"back"`
-	DeckID    int64     `db:"deck_id"`
-	Author    string    `db:"author"`
+	CardID    int64     `db:"card_id"`
+	Editor    string    `db:"editor"`
+	OldFront  string    `db:"old_front"`
+	OldBack   string    `db:"old_back"`
+	OldDeckID int64     `db:"old_deck_id"`
+	OldAuthor string    `db:"old_author"`
+	OldFormat string    `db:"old_format"`
+	NewFront  string    `db:"new_front"`
+	NewBack   string    `db:"new_back"`
+	NewDeckID int64     `db:"new_deck_id"`
+	NewAuthor string    `db:"new_author"`
+	NewFormat string    `db:"new_format"`
 	CreatedAt time.Time `db:"created_at"`
 }
@@ -2,12 +2,35 @@ package structs
 
 import "time"
 
+// Deck is a deck of cards. Owner is the user who created it and whose quota
+// it counts against, empty for anonymous callers.
 type Deck struct {
-	ID          int64     `db:"id"`
-	Title       string    `db:"title"`
-	Description string    `db:"description"`
-	Author      string    `db:"author"`
-	CreatedAt   time.Time `db:"created_at"`
+	ID          int64      `db:"id"`
+	Title       string     `db:"title"`
+	Description string     `db:"description"`
+	Author      string     `db:"author"`
+	Owner       string     `db:"owner"`
+	Version     int64      `db:"version"`
+	CreatedAt   time.Time  `db:"created_at"`
+	DeletedAt   *time.Time `db:"deleted_at"`
+}
+
+type DeckRevision struct {
+	ID             int64     `db:"id"`
+	DeckID         int64     `db:"deck_id"`
+	Editor         string    `db:"editor"`
+	OldTitle       string    `db:"old_title"`
+	OldDescription string    `db:"old_description"`
+	OldAuthor      string    `db:"old_author"`
+	NewTitle       string    `db:"new_title"`
+	NewDescription string    `db:"new_description"`
+	NewAuthor      string    `db:"new_author"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+type TrashedDeck struct {
+	Deck
+	CardCount int64 `db:"card_count"`
 }
 
 type DeckWithCards struct {
@@ -0,0 +1,15 @@
+package structs
+
+import "time"
+
+// IdempotencyRecord remembers a request made with an idempotency key and,
+// once it has succeeded, the response to replay. Response is nil while the
+// request is still being handled. Header is the response metadata to send
+// again with it, such as the ETag.
+type IdempotencyRecord struct {
+	Key         string    `db:"key"`
+	RequestHash []byte    `db:"request_hash"`
+	Response    []byte    `db:"response"`
+	Header      []byte    `db:"response_header"`
+	ExpiresAt   time.Time `db:"expires_at"`
+}
@@ -0,0 +1,8 @@
+package structs
+
+// Page selects part of a list ordered newest first: at most Size items,
+// starting after the one with ID After. Zero After starts at the newest item.
+type Page struct {
+	Size  int
+	After int64
+}
@@ -0,0 +1,16 @@
+package structs
+
+// Usage is what a user stores outside the trash: their decks and the bytes
+// of the front and back of the cards in them.
+type Usage struct {
+	Decks      int64 `db:"decks"`
+	MediaBytes int64 `db:"media_bytes"`
+}
+
+// DeckUsage is the owner of a deck and how many cards it holds outside the
+// trash.
+type DeckUsage struct {
+	DeckID int64  `db:"deck_id"`
+	Owner  string `db:"owner"`
+	Cards  int64  `db:"cards"`
+}
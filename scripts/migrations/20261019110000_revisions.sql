@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE card_revisions(
+    id SERIAL PRIMARY KEY,
+    card_id INT NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    editor TEXT NOT NULL,
+    old_front TEXT NOT NULL,
+    old_back TEXT NOT NULL,
+    old_deck_id INT NOT NULL,
+    old_author TEXT NOT NULL,
+    old_format TEXT NOT NULL,
+    new_front TEXT NOT NULL,
+    new_back TEXT NOT NULL,
+    new_deck_id INT NOT NULL,
+    new_author TEXT NOT NULL,
+    new_format TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+CREATE INDEX card_revisions_card_id_idx ON card_revisions(card_id);
+
+CREATE TABLE deck_revisions(
+    id SERIAL PRIMARY KEY,
+    deck_id INT NOT NULL REFERENCES decks(id) ON DELETE CASCADE,
+    editor TEXT NOT NULL,
+    old_title TEXT NOT NULL,
+    old_description TEXT NOT NULL,
+    old_author TEXT NOT NULL,
+    new_title TEXT NOT NULL,
+    new_description TEXT NOT NULL,
+    new_author TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now() NOT NULL
+);
+
+CREATE INDEX deck_revisions_deck_id_idx ON deck_revisions(deck_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE deck_revisions;
+DROP TABLE card_revisions;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE decks
+    ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE cards
+    ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX decks_deleted_at_idx ON decks(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX cards_deleted_at_idx ON cards(deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX cards_deleted_at_idx;
+DROP INDEX decks_deleted_at_idx;
+
+ALTER TABLE cards
+    DROP COLUMN deleted_at;
+
+ALTER TABLE decks
+    DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE decks
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE cards
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE cards
+    DROP COLUMN version;
+
+ALTER TABLE decks
+    DROP COLUMN version;
+-- +goose StatementEnd
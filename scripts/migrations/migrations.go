@@ -0,0 +1,21 @@
+// Package migrations embeds the database migrations, so the server binary can
+// apply them without the goose CLI or a checkout of the repository.
+package migrations
+
+import "embed"
+
+// FS holds the Postgres migrations in PostgresDir and the SQLite ones in
+// SQLiteDir, in the layout goose expects.
+//
+//go:embed *.sql sqlite/*.sql
+var FS embed.FS
+
+const (
+	PostgresDir = "."
+	SQLiteDir   = "sqlite"
+)
+
+// Seed is the SQL that fills a fresh database with test data.
+//
+//go:embed seed/test_data.sql
+var Seed string
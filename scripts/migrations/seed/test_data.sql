@@ -0,0 +1,18 @@
+-- Test decks and cards for trying the service out. Applied by
+-- "flash-card-manager migrate seed" to a database without decks; the SQL is
+-- shared by Postgres and SQLite.
+INSERT INTO decks (title, description, author, created_at)
+VALUES
+    ('Eng words', 'Description for Deck 1', 'John Doe', CURRENT_TIMESTAMP),
+    ('Deck 2', 'Description for Deck 2', 'Jane Smith', CURRENT_TIMESTAMP);
+
+INSERT INTO cards (front, back, deck_id, author, created_at)
+SELECT seed.front, seed.back, decks.id, seed.author, CURRENT_TIMESTAMP
+FROM (
+    SELECT 'Front 1' AS front, 'Back 1' AS back, 'Eng words' AS title, 'John Doe' AS author
+    UNION ALL SELECT 'Front 2', 'Back 2', 'Eng words', 'Jane Smith'
+    UNION ALL SELECT 'Front 3', 'Back 3', 'Deck 2', 'John Doe'
+    UNION ALL SELECT 'Front 4', 'Back 4', 'Deck 2', 'Jane Smith'
+    UNION ALL SELECT 'Front 5', 'Back 5', 'Deck 2', 'Jane Smith'
+) AS seed
+JOIN decks ON decks.title = seed.title;
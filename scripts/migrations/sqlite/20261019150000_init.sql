@@ -0,0 +1,78 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE decks(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    author TEXT NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP
+);
+
+CREATE TABLE cards(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    front TEXT NOT NULL,
+    back TEXT NOT NULL,
+    deck_id INTEGER NOT NULL REFERENCES decks(id) ON DELETE CASCADE,
+    author TEXT NOT NULL,
+    format TEXT NOT NULL DEFAULT 'plain',
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX cards_deck_id_idx ON cards(deck_id);
+
+CREATE TABLE card_revisions(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    card_id INTEGER NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    editor TEXT NOT NULL,
+    old_front TEXT NOT NULL,
+    old_back TEXT NOT NULL,
+    old_deck_id INTEGER NOT NULL,
+    old_author TEXT NOT NULL,
+    old_format TEXT NOT NULL,
+    new_front TEXT NOT NULL,
+    new_back TEXT NOT NULL,
+    new_deck_id INTEGER NOT NULL,
+    new_author TEXT NOT NULL,
+    new_format TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX card_revisions_card_id_idx ON card_revisions(card_id);
+
+CREATE TABLE deck_revisions(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    deck_id INTEGER NOT NULL REFERENCES decks(id) ON DELETE CASCADE,
+    editor TEXT NOT NULL,
+    old_title TEXT NOT NULL,
+    old_description TEXT NOT NULL,
+    old_author TEXT NOT NULL,
+    new_title TEXT NOT NULL,
+    new_description TEXT NOT NULL,
+    new_author TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX deck_revisions_deck_id_idx ON deck_revisions(deck_id);
+
+CREATE TABLE idempotency_keys(
+    key TEXT PRIMARY KEY,
+    request_hash BLOB NOT NULL,
+    response BLOB,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE idempotency_keys;
+DROP TABLE deck_revisions;
+DROP TABLE card_revisions;
+DROP TABLE cards;
+DROP TABLE decks;
+-- +goose StatementEnd
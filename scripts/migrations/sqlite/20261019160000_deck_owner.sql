@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE decks ADD COLUMN owner TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX decks_owner_idx ON decks(owner) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX decks_owner_idx;
+
+ALTER TABLE decks DROP COLUMN owner;
+-- +goose StatementEnd
@@ -0,0 +1,172 @@
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/repository/postgresql"
+	"flash-card-manager/pkg/repository/structs"
+	"flash-card-manager/tests/fixtures"
+	"flash-card-manager/tests/postgres"
+	"log"
+	"testing"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+)
+
+type CardBatchTestSuite struct {
+	suite.Suite
+	DB *postgres.TDB
+}
+
+func (suite *CardBatchTestSuite) SetupTest() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatalf("Error loading .env file: %v", err)
+	}
+
+	suite.DB = postgres.NewFromEnv()
+
+	err = suite.DB.SetUp(suite.T())
+	suite.Require().NoError(err)
+}
+
+func (suite *CardBatchTestSuite) TearDownTest() {
+	ctx := context.Background()
+	suite.DB.TearDown(ctx, suite.T())
+}
+
+func (suite *CardBatchTestSuite) TestBatchAdd() {
+	// Arrange
+	ctx := context.Background()
+	deckRepo := postgresql.NewDeck(suite.DB.DB)
+	cardRepo := postgresql.NewCard(suite.DB.DB)
+
+	deckID, err := deckRepo.Add(ctx, *fixtures.Deck().Valid().P())
+	suite.Require().NoError(err)
+
+	cards := []structs.Card{
+		*fixtures.Card().Valid().DeckID(deckID).Front("first").P(),
+		*fixtures.Card().Valid().DeckID(deckID).Front("second").P(),
+	}
+
+	// Act
+	ids, err := cardRepo.BatchAdd(ctx, cards)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Require().Len(ids, 2)
+	for i, id := range ids {
+		card, err := cardRepo.GetByID(ctx, id)
+		suite.Require().NoError(err)
+		suite.Assert().Equal(cards[i].Front, card.Front)
+		suite.Assert().Equal(int64(1), card.Version)
+	}
+}
+
+func (suite *CardBatchTestSuite) TestBatchAddRollsBackOnMissingDeck() {
+	// Arrange
+	ctx := context.Background()
+	deckRepo := postgresql.NewDeck(suite.DB.DB)
+	cardRepo := postgresql.NewCard(suite.DB.DB)
+
+	deckID, err := deckRepo.Add(ctx, *fixtures.Deck().Valid().P())
+	suite.Require().NoError(err)
+
+	cards := []structs.Card{
+		*fixtures.Card().Valid().DeckID(deckID).P(),
+		*fixtures.Card().Valid().DeckID(deckID + 1000).P(),
+	}
+
+	// Act
+	_, err = cardRepo.BatchAdd(ctx, cards)
+
+	// Assert
+	var batchErr *structs.BatchError
+	suite.Require().True(errors.As(err, &batchErr))
+	suite.Assert().Equal(1, batchErr.Index)
+
+	deck, err := deckRepo.GetWithCardsByID(ctx, deckID)
+	suite.Require().NoError(err)
+	suite.Assert().Empty(deck.Cards)
+}
+
+func (suite *CardBatchTestSuite) TestBatchUpdateRollsBackOnStaleVersion() {
+	// Arrange
+	ctx := context.Background()
+	deckRepo := postgresql.NewDeck(suite.DB.DB)
+	cardRepo := postgresql.NewCard(suite.DB.DB)
+
+	deckID, err := deckRepo.Add(ctx, *fixtures.Deck().Valid().P())
+	suite.Require().NoError(err)
+	ids, err := cardRepo.BatchAdd(ctx, []structs.Card{
+		*fixtures.Card().Valid().DeckID(deckID).P(),
+		*fixtures.Card().Valid().DeckID(deckID).P(),
+	})
+	suite.Require().NoError(err)
+
+	updates := []structs.Card{
+		*fixtures.Card().Valid().ID(ids[0]).DeckID(deckID).Front("changed").P(),
+		*fixtures.Card().Valid().ID(ids[1]).DeckID(deckID).Front("changed").P(),
+	}
+	updates[1].Version = 5
+
+	// Act
+	_, err = cardRepo.BatchUpdate(ctx, updates, "editor")
+
+	// Assert
+	var batchErr *structs.BatchError
+	suite.Require().True(errors.As(err, &batchErr))
+	suite.Assert().Equal(1, batchErr.Index)
+	suite.Assert().EqualError(batchErr.Err, "version mismatch")
+
+	card, err := cardRepo.GetByID(ctx, ids[0])
+	suite.Require().NoError(err)
+	suite.Assert().NotEqual("changed", card.Front)
+}
+
+func (suite *CardBatchTestSuite) TestMoveAndBatchDelete() {
+	// Arrange
+	ctx := context.Background()
+	deckRepo := postgresql.NewDeck(suite.DB.DB)
+	cardRepo := postgresql.NewCard(suite.DB.DB)
+
+	fromID, err := deckRepo.Add(ctx, *fixtures.Deck().Valid().P())
+	suite.Require().NoError(err)
+	toID, err := deckRepo.Add(ctx, *fixtures.Deck().Valid().P())
+	suite.Require().NoError(err)
+	ids, err := cardRepo.BatchAdd(ctx, []structs.Card{
+		*fixtures.Card().Valid().DeckID(fromID).P(),
+		*fixtures.Card().Valid().DeckID(fromID).P(),
+	})
+	suite.Require().NoError(err)
+
+	// Act
+	versions, err := cardRepo.Move(ctx, ids, toID, "editor")
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Assert().Equal([]int64{2, 2}, versions)
+	deck, err := deckRepo.GetWithCardsByID(ctx, toID)
+	suite.Require().NoError(err)
+	suite.Assert().Len(deck.Cards, 2)
+
+	// Act
+	err = cardRepo.BatchDelete(ctx, append(ids, ids[0]+1000))
+
+	// Assert
+	var batchErr *structs.BatchError
+	suite.Require().True(errors.As(err, &batchErr))
+	suite.Assert().Equal(2, batchErr.Index)
+
+	suite.Require().NoError(cardRepo.BatchDelete(ctx, ids))
+	_, err = cardRepo.GetByID(ctx, ids[0])
+	suite.Assert().Error(err)
+}
+
+func TestCardBatchTestSuite(t *testing.T) {
+	suite.Run(t, new(CardBatchTestSuite))
+}
@@ -7,10 +7,10 @@ import (
 	"context"
 	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/suite"
-	"homework-3/pkg/db"
-	"homework-3/pkg/repository/postgresql"
-	"homework-3/tests/fixtures"
-	"homework-3/tests/postgres"
+	"flash-card-manager/pkg/repository/postgresql"
+	"flash-card-manager/pkg/repository/structs"
+	"flash-card-manager/tests/fixtures"
+	"flash-card-manager/tests/postgres"
 	"log"
 	"testing"
 )
@@ -26,7 +26,7 @@ func (suite *CardTestSuite) SetupTest() {
 		log.Fatalf("Error loading .env file: %v", err)
 	}
 
-	suite.DB = postgres.NewFromEnv(db.GenerateDsn())
+	suite.DB = postgres.NewFromEnv()
 	suite.Require().NoError(err)
 
 	err = suite.DB.SetUp(suite.T())
@@ -129,7 +129,7 @@ func (suite *CardTestSuite) TestUpdateCard() {
 	updatedCard.Back = "Updated Back"
 
 	// Act
-	_, err = cardRepo.Update(ctx, updatedCard)
+	_, err = cardRepo.Update(ctx, updatedCard, updatedCard.Author)
 
 	// Assert
 	suite.Require().NoError(err)
@@ -139,6 +139,38 @@ func (suite *CardTestSuite) TestUpdateCard() {
 	suite.Assert().Equal(updatedCard.Back, cardFromDB.Back)
 }
 
+func (suite *CardTestSuite) TestPatchCard() {
+	// Arrange
+	ctx := context.Background()
+	cardRepo := postgresql.NewCard(suite.DB.DB)
+	deckRepo := postgresql.NewDeck(suite.DB.DB)
+
+	deckValid := fixtures.Deck().Valid().P()
+	deckID, err := deckRepo.Add(ctx, *deckValid)
+	suite.Require().NoError(err)
+
+	cardValid := fixtures.Card().Valid().DeckID(deckID).P()
+	cardID, err := cardRepo.Add(ctx, *cardValid)
+	suite.Require().NoError(err)
+
+	// Act
+	_, err = cardRepo.Patch(ctx, structs.Card{ID: cardID, Author: "Patched Author"}, []string{"author"}, "editor")
+
+	// Assert
+	suite.Require().NoError(err)
+	cardFromDB, err := cardRepo.GetByID(ctx, cardID)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("Patched Author", cardFromDB.Author)
+	suite.Assert().Equal(cardValid.Front, cardFromDB.Front)
+	suite.Assert().Equal(cardValid.Back, cardFromDB.Back)
+
+	revisions, err := cardRepo.ListRevisions(ctx, cardID, structs.Page{Size: 10})
+	suite.Require().NoError(err)
+	suite.Require().Len(revisions, 1)
+	suite.Assert().Equal(cardValid.Author, revisions[0].OldAuthor)
+	suite.Assert().Equal(cardValid.Front, revisions[0].NewFront)
+}
+
 func TestCardTestSuite(t *testing.T) {
 	suite.Run(t, new(CardTestSuite))
 }
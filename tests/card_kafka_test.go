@@ -6,11 +6,9 @@ package tests
 import (
 	"context"
 	"encoding/json"
-	"homework-3/internal/infrastructure/kafka"
-	"homework-3/pkg/db"
-	"homework-3/pkg/repository/postgresql"
-	"homework-3/tests/fixtures"
-	"homework-3/tests/postgres"
+	"flash-card-manager/pkg/repository/postgresql"
+	"flash-card-manager/tests/fixtures"
+	"flash-card-manager/tests/postgres"
 	"log"
 	"testing"
 	"time"
@@ -33,11 +31,11 @@ func (suite *CardKafkaTestSuite) SetupSuite() {
 	if err != nil {
 		log.Fatalf("Error loading .env file: %v", err)
 	}
-	suite.DB = postgres.NewFromEnv(db.GenerateDsn())
+	suite.DB = postgres.NewFromEnv()
 	err = suite.DB.SetUp(suite.T())
 	suite.Require().NoError(err)
 
-	kafkaAddress, err := kafka.LoadKafkaConfig()
+	kafkaAddress, err := kafkaBrokers()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -71,7 +69,7 @@ func (suite *CardKafkaTestSuite) TearDownSuite() {
 }
 
 func (suite *CardKafkaTestSuite) CleanupKafka() {
-	kafkaAddress, err := kafka.LoadKafkaConfig()
+	kafkaAddress, err := kafkaBrokers()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -147,7 +145,7 @@ func (suite *CardKafkaTestSuite) TestKafkaPut() {
 	card := fixtures.Card().Valid().DeckID(1).P()
 
 	// Act
-	cardID, err := cardRepo.Update(ctx, *card)
+	cardID, err := cardRepo.Update(ctx, *card, card.Author)
 	suite.Require().NoError(err)
 
 	card.ID = cardID
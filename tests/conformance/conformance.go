@@ -0,0 +1,510 @@
+// Package conformance holds the behaviour every implementation of the card,
+// deck, trash and usage repositories has to share, as a test suite each of them
+// runs against its own storage.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/errs"
+	"flash-card-manager/pkg/repository/interfaces"
+	"flash-card-manager/pkg/repository/structs"
+	"flash-card-manager/tests/fixtures"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// Repositories are the implementations under test. They must share their
+// storage, and Tx must run transactions over it.
+type Repositories struct {
+	Cards interfaces.CardRepository
+	Decks interfaces.DeckRepository
+	Trash interfaces.TrashRepository
+	Usage interfaces.UsageRepository
+	Tx    db.TxManager
+}
+
+// Suite runs against the repositories New returns. New is called before
+// every test and has to hand out empty storage, cleaning it up with
+// t.Cleanup if needed.
+type Suite struct {
+	suite.Suite
+	New func(t *testing.T) Repositories
+
+	repos Repositories
+}
+
+func (s *Suite) SetupTest() {
+	s.repos = s.New(s.T())
+}
+
+func (s *Suite) addDeck(ctx context.Context) int64 {
+	id, err := s.repos.Decks.Add(ctx, *fixtures.Deck().Valid().P())
+	s.Require().NoError(err)
+	return id
+}
+
+func (s *Suite) addCard(ctx context.Context, deckID int64) int64 {
+	id, err := s.repos.Cards.Add(ctx, *fixtures.Card().Valid().DeckID(deckID).P())
+	s.Require().NoError(err)
+	return id
+}
+
+// requireNotFound checks err is a NotFound error about the given resource.
+func (s *Suite) requireNotFound(err error, resource string) {
+	s.T().Helper()
+	s.Require().ErrorIs(err, &errs.Error{Kind: errs.NotFound, Resource: resource})
+}
+
+func (s *Suite) TestAddAssignsIDsAndDefaults() {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	first := s.addDeck(ctx)
+	second := s.addDeck(ctx)
+	cardID := s.addCard(ctx, first)
+
+	// Assert
+	s.Assert().Greater(second, first)
+
+	deck, err := s.repos.Decks.GetByID(ctx, first)
+	s.Require().NoError(err)
+	s.Assert().Equal("Title", deck.Title)
+	s.Assert().Equal(int64(1), deck.Version)
+	s.Assert().WithinDuration(time.Now(), deck.CreatedAt, time.Minute)
+
+	card, err := s.repos.Cards.GetByID(ctx, cardID)
+	s.Require().NoError(err)
+	s.Assert().Equal(first, card.DeckID)
+	s.Assert().Equal("some front", card.Front)
+	s.Assert().Equal(int64(1), card.Version)
+	s.Assert().WithinDuration(time.Now(), card.CreatedAt, time.Minute)
+}
+
+func (s *Suite) TestNotFound() {
+	ctx := context.Background()
+	deckID := s.addDeck(ctx)
+
+	_, err := s.repos.Decks.GetByID(ctx, deckID+100)
+	s.requireNotFound(err, "deck")
+
+	_, err = s.repos.Decks.GetWithCardsByID(ctx, deckID+100)
+	s.requireNotFound(err, "deck")
+
+	_, err = s.repos.Cards.GetByID(ctx, 1)
+	s.requireNotFound(err, "card")
+
+	_, err = s.repos.Cards.Add(ctx, *fixtures.Card().Valid().DeckID(deckID + 100).P())
+	s.requireNotFound(err, "deck")
+
+	_, err = s.repos.Cards.GetRevision(ctx, 1, 1)
+	s.requireNotFound(err, "revision")
+
+	_, err = s.repos.Decks.GetRevision(ctx, deckID, 1)
+	s.requireNotFound(err, "revision")
+}
+
+func (s *Suite) TestDeleteDeckCascadesToCards() {
+	// Arrange
+	ctx := context.Background()
+	deckID := s.addDeck(ctx)
+	cardIDs := []int64{s.addCard(ctx, deckID), s.addCard(ctx, deckID)}
+	s.Require().NoError(s.repos.Cards.Delete(ctx, cardIDs[0]))
+
+	// Act
+	err := s.repos.Decks.Delete(ctx, deckID)
+
+	// Assert
+	s.Require().NoError(err)
+	_, err = s.repos.Decks.GetByID(ctx, deckID)
+	s.requireNotFound(err, "deck")
+	_, err = s.repos.Cards.GetByID(ctx, cardIDs[1])
+	s.requireNotFound(err, "card")
+
+	decks, err := s.repos.Trash.ListDecks(ctx, "")
+	s.Require().NoError(err)
+	s.Require().Len(decks, 1)
+	s.Assert().Equal(int64(1), decks[0].CardCount)
+
+	restored, err := s.repos.Trash.RestoreDeck(ctx, deckID)
+	s.Require().NoError(err)
+	s.Assert().Equal(int64(1), restored)
+
+	deck, err := s.repos.Decks.GetWithCardsByID(ctx, deckID)
+	s.Require().NoError(err)
+	s.Require().Len(deck.Cards, 1)
+	s.Assert().Equal(cardIDs[1], deck.Cards[0].ID)
+
+	cards, err := s.repos.Trash.ListCards(ctx, "")
+	s.Require().NoError(err)
+	s.Require().Len(cards, 1)
+	s.Assert().Equal(cardIDs[0], cards[0].ID)
+}
+
+func (s *Suite) TestRestoreCardOfTrashedDeck() {
+	ctx := context.Background()
+	deckID := s.addDeck(ctx)
+	cardID := s.addCard(ctx, deckID)
+	s.Require().NoError(s.repos.Decks.Delete(ctx, deckID))
+
+	err := s.repos.Trash.RestoreCard(ctx, cardID)
+	s.Require().ErrorIs(err, errs.ErrFailedPrecondition)
+
+	err = s.repos.Trash.RestoreCard(ctx, cardID+100)
+	s.requireNotFound(err, "card")
+
+	_, err = s.repos.Trash.RestoreDeck(ctx, deckID+100)
+	s.requireNotFound(err, "deck")
+}
+
+func (s *Suite) TestPurge() {
+	// Arrange
+	ctx := context.Background()
+	trashed := s.addDeck(ctx)
+	s.addCard(ctx, trashed)
+	kept := s.addDeck(ctx)
+	keptCard := s.addCard(ctx, kept)
+	trashedCard := s.addCard(ctx, kept)
+	s.Require().NoError(s.repos.Decks.Delete(ctx, trashed))
+	s.Require().NoError(s.repos.Cards.Delete(ctx, trashedCard))
+
+	// Act
+	decks, cards, err := s.repos.Trash.Purge(ctx, time.Now().Add(time.Minute))
+
+	// Assert
+	s.Require().NoError(err)
+	s.Assert().Equal(int64(1), decks)
+	s.Assert().Equal(int64(2), cards)
+
+	_, err = s.repos.Cards.GetByID(ctx, keptCard)
+	s.Assert().NoError(err)
+	_, err = s.repos.Trash.RestoreDeck(ctx, trashed)
+	s.requireNotFound(err, "deck")
+}
+
+func (s *Suite) TestTrashIsPerOwner() {
+	// Arrange
+	ctx := context.Background()
+	addDeck := func(owner string) (deckID, cardID int64) {
+		deckID, err := s.repos.Decks.Add(ctx, *fixtures.Deck().Valid().Owner(owner).P())
+		s.Require().NoError(err)
+		cardID = s.addCard(ctx, deckID)
+		s.Require().NoError(s.repos.Cards.Delete(ctx, s.addCard(ctx, deckID)))
+		s.Require().NoError(s.repos.Decks.Delete(ctx, deckID))
+		return deckID, cardID
+	}
+	alice, _ := addDeck("alice")
+	bob, bobCard := addDeck("bob")
+	_, err := s.repos.Trash.RestoreDeck(ctx, bob)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repos.Cards.Delete(ctx, bobCard))
+
+	// Act
+	decks, err := s.repos.Trash.ListDecks(ctx, "alice")
+	s.Require().NoError(err)
+	cards, err := s.repos.Trash.ListCards(ctx, "alice")
+	s.Require().NoError(err)
+	purgedDecks, purgedCards, err := s.repos.Trash.PurgeOwner(ctx, "alice", time.Now().Add(time.Minute))
+	s.Require().NoError(err)
+
+	// Assert
+	s.Require().Len(decks, 1)
+	s.Assert().Equal(alice, decks[0].ID)
+	s.Assert().Empty(cards)
+	s.Assert().Equal(int64(1), purgedDecks)
+	s.Assert().Equal(int64(2), purgedCards)
+
+	cards, err = s.repos.Trash.ListCards(ctx, "bob")
+	s.Require().NoError(err)
+	s.Require().Len(cards, 2)
+	s.Assert().NoError(s.repos.Trash.RestoreCard(ctx, bobCard))
+}
+
+func (s *Suite) TestUsage() {
+	// Arrange
+	ctx := context.Background()
+	addDeck := func(owner string) int64 {
+		id, err := s.repos.Decks.Add(ctx, *fixtures.Deck().Valid().Owner(owner).P())
+		s.Require().NoError(err)
+		return id
+	}
+	addCard := func(deckID int64, front, back string) int64 {
+		id, err := s.repos.Cards.Add(ctx, *fixtures.Card().Valid().DeckID(deckID).Front(front).Back(back).P())
+		s.Require().NoError(err)
+		return id
+	}
+
+	first := addDeck("alice")
+	second := addDeck("alice")
+	trashed := addDeck("alice")
+	other := addDeck("bob")
+	addCard(first, "héllo", "world")
+	trashedCard := addCard(first, "front", "back")
+	addCard(second, "a", "bc")
+	addCard(trashed, "front", "back")
+	addCard(other, "front", "back")
+	s.Require().NoError(s.repos.Cards.Delete(ctx, trashedCard))
+	s.Require().NoError(s.repos.Decks.Delete(ctx, trashed))
+
+	// Act
+	usage, err := s.repos.Usage.Usage(ctx, "alice")
+
+	// Assert
+	s.Require().NoError(err)
+	// "héllo" is six bytes in UTF-8.
+	s.Assert().Equal(structs.Usage{Decks: 2, MediaBytes: 6 + 5 + 1 + 2}, usage)
+
+	usage, err = s.repos.Usage.Usage(ctx, "carol")
+	s.Require().NoError(err)
+	s.Assert().Equal(structs.Usage{}, usage)
+
+	deckUsage, err := s.repos.Usage.DeckUsage(ctx, first)
+	s.Require().NoError(err)
+	s.Assert().Equal(structs.DeckUsage{DeckID: first, Owner: "alice", Cards: 1}, deckUsage)
+
+	deck, err := s.repos.Decks.GetByID(ctx, other)
+	s.Require().NoError(err)
+	s.Assert().Equal("bob", deck.Owner)
+
+	_, err = s.repos.Usage.DeckUsage(ctx, trashed)
+	s.requireNotFound(err, "deck")
+}
+
+func (s *Suite) TestPatchRecordsRevisions() {
+	// Arrange
+	ctx := context.Background()
+	deckID := s.addDeck(ctx)
+	otherDeckID := s.addDeck(ctx)
+	cardID := s.addCard(ctx, deckID)
+
+	// Act
+	version, err := s.repos.Cards.Patch(ctx, structs.Card{ID: cardID, Front: "new front", DeckID: otherDeckID}, []string{"front", "deck_id"}, "editor")
+
+	// Assert
+	s.Require().NoError(err)
+	s.Assert().Equal(int64(2), version)
+
+	card, err := s.repos.Cards.GetByID(ctx, cardID)
+	s.Require().NoError(err)
+	s.Assert().Equal("new front", card.Front)
+	s.Assert().Equal("some back", card.Back)
+	s.Assert().Equal(otherDeckID, card.DeckID)
+
+	revisions, err := s.repos.Cards.ListRevisions(ctx, cardID, structs.Page{Size: 10})
+	s.Require().NoError(err)
+	s.Require().Len(revisions, 1)
+	s.Assert().Equal("some front", revisions[0].OldFront)
+	s.Assert().Equal("new front", revisions[0].NewFront)
+	s.Assert().Equal(otherDeckID, revisions[0].NewDeckID)
+	s.Assert().Equal("editor", revisions[0].Editor)
+
+	revision, err := s.repos.Cards.GetRevision(ctx, cardID, revisions[0].ID)
+	s.Require().NoError(err)
+	s.Assert().Equal(revisions[0].ID, revision.ID)
+
+	version, err = s.repos.Decks.Update(ctx, structs.Deck{ID: deckID, Title: "new title", Author: "Author"}, "editor")
+	s.Require().NoError(err)
+	s.Assert().Equal(int64(2), version)
+
+	deckRevisions, err := s.repos.Decks.ListRevisions(ctx, deckID)
+	s.Require().NoError(err)
+	s.Require().Len(deckRevisions, 1)
+	s.Assert().Equal("Title", deckRevisions[0].OldTitle)
+	s.Assert().Equal("new title", deckRevisions[0].NewTitle)
+	s.Assert().Equal("", deckRevisions[0].NewDescription)
+}
+
+func (s *Suite) TestListRevisionsPages() {
+	// Arrange
+	ctx := context.Background()
+	cardID := s.addCard(ctx, s.addDeck(ctx))
+	for _, author := range []string{"first", "second", "third"} {
+		_, err := s.repos.Cards.Patch(ctx, structs.Card{ID: cardID, Author: author}, []string{"author"}, "editor")
+		s.Require().NoError(err)
+	}
+
+	// Act
+	first, err := s.repos.Cards.ListRevisions(ctx, cardID, structs.Page{Size: 2})
+	s.Require().NoError(err)
+	s.Require().Len(first, 2)
+	second, err := s.repos.Cards.ListRevisions(ctx, cardID, structs.Page{Size: 2, After: first[1].ID})
+	s.Require().NoError(err)
+
+	// Assert
+	s.Assert().Equal("third", first[0].NewAuthor)
+	s.Assert().Equal("second", first[1].NewAuthor)
+	s.Require().Len(second, 1)
+	s.Assert().Equal("first", second[0].NewAuthor)
+}
+
+func (s *Suite) TestUpdateChecksVersion() {
+	ctx := context.Background()
+	deckID := s.addDeck(ctx)
+	cardID := s.addCard(ctx, deckID)
+	card := *fixtures.Card().Valid().ID(cardID).DeckID(deckID).P()
+
+	card.Version = 2
+	_, err := s.repos.Cards.Update(ctx, card, "editor")
+	s.Require().ErrorIs(err, errs.ErrConflict)
+
+	card.Version = 1
+	version, err := s.repos.Cards.Update(ctx, card, "editor")
+	s.Require().NoError(err)
+	s.Assert().Equal(int64(2), version)
+
+	_, err = s.repos.Decks.Update(ctx, structs.Deck{ID: deckID, Title: "T", Author: "A", Version: 5}, "editor")
+	s.Require().ErrorIs(err, errs.ErrConflict)
+
+	version, err = s.repos.Decks.Update(ctx, structs.Deck{ID: deckID + 100, Title: "T", Author: "A"}, "editor")
+	s.Require().NoError(err)
+	s.Assert().Zero(version)
+
+	card.ID = cardID + 100
+	version, err = s.repos.Cards.Update(ctx, card, "editor")
+	s.Require().NoError(err)
+	s.Assert().Zero(version)
+}
+
+func (s *Suite) TestPatchToMissingDeck() {
+	ctx := context.Background()
+	deckID := s.addDeck(ctx)
+	cardID := s.addCard(ctx, deckID)
+
+	_, err := s.repos.Cards.Patch(ctx, structs.Card{ID: cardID, DeckID: deckID + 100}, []string{"deck_id"}, "editor")
+	s.requireNotFound(err, "deck")
+}
+
+func (s *Suite) TestUpdateToTrashedDeck() {
+	// Arrange
+	ctx := context.Background()
+	deckID := s.addDeck(ctx)
+	trashedDeckID := s.addDeck(ctx)
+	cardID := s.addCard(ctx, deckID)
+	s.Require().NoError(s.repos.Decks.Delete(ctx, trashedDeckID))
+	card := *fixtures.Card().Valid().ID(cardID).DeckID(trashedDeckID).P()
+
+	// Act
+	_, patchErr := s.repos.Cards.Patch(ctx, card, []string{"deck_id"}, "editor")
+	_, updateErr := s.repos.Cards.Update(ctx, card, "editor")
+	_, batchErr := s.repos.Cards.BatchUpdate(ctx, []structs.Card{card}, "editor")
+
+	// Assert
+	s.requireNotFound(patchErr, "deck")
+	s.requireNotFound(updateErr, "deck")
+	s.requireNotFound(batchErr, "deck")
+
+	got, err := s.repos.Cards.GetByID(ctx, cardID)
+	s.Require().NoError(err)
+	s.Assert().Equal(deckID, got.DeckID)
+	s.Assert().Equal(int64(1), got.Version)
+}
+
+func (s *Suite) TestBatchesAreAllOrNothing() {
+	// Arrange
+	ctx := context.Background()
+	deckID := s.addDeck(ctx)
+	otherDeckID := s.addDeck(ctx)
+	cardID := s.addCard(ctx, deckID)
+
+	// Act
+	_, addErr := s.repos.Cards.BatchAdd(ctx, []structs.Card{
+		*fixtures.Card().Valid().DeckID(deckID).P(),
+		*fixtures.Card().Valid().DeckID(deckID + 100).P(),
+	})
+	_, moveErr := s.repos.Cards.Move(ctx, []int64{cardID, cardID + 100}, otherDeckID, "editor")
+	deleteErr := s.repos.Cards.BatchDelete(ctx, []int64{cardID, cardID + 100})
+
+	// Assert
+	for _, err := range []error{addErr, moveErr, deleteErr} {
+		var batchErr *structs.BatchError
+		s.Require().True(errors.As(err, &batchErr), "expected a batch error, got %v", err)
+		s.Assert().Equal(1, batchErr.Index)
+		s.Assert().ErrorIs(err, errs.ErrNotFound)
+	}
+
+	deck, err := s.repos.Decks.GetWithCardsByID(ctx, deckID)
+	s.Require().NoError(err)
+	s.Require().Len(deck.Cards, 1)
+	s.Assert().Equal(int64(1), deck.Cards[0].Version)
+}
+
+func (s *Suite) TestBatches() {
+	// Arrange
+	ctx := context.Background()
+	deckID := s.addDeck(ctx)
+	otherDeckID := s.addDeck(ctx)
+
+	// Act
+	ids, err := s.repos.Cards.BatchAdd(ctx, []structs.Card{
+		*fixtures.Card().Valid().Front("first").DeckID(deckID).P(),
+		*fixtures.Card().Valid().Front("second").DeckID(deckID).P(),
+	})
+	s.Require().NoError(err)
+	s.Require().Len(ids, 2)
+
+	versions, err := s.repos.Cards.BatchUpdate(ctx, []structs.Card{
+		*fixtures.Card().Valid().ID(ids[0]).Front("updated").DeckID(deckID).P(),
+	}, "editor")
+	s.Require().NoError(err)
+	s.Assert().Equal([]int64{2}, versions)
+
+	versions, err = s.repos.Cards.Move(ctx, ids, otherDeckID, "editor")
+	s.Require().NoError(err)
+	s.Assert().Equal([]int64{3, 2}, versions)
+
+	err = s.repos.Cards.BatchDelete(ctx, ids[1:])
+	s.Require().NoError(err)
+
+	// Assert
+	deck, err := s.repos.Decks.GetWithCardsByID(ctx, otherDeckID)
+	s.Require().NoError(err)
+	s.Require().Len(deck.Cards, 1)
+	s.Assert().Equal("updated", deck.Cards[0].Front)
+}
+
+func (s *Suite) TestTransactionRollsBack() {
+	// Arrange
+	ctx := context.Background()
+	var deckID int64
+
+	// Act
+	err := s.repos.Tx.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		deckID = s.addDeck(ctx)
+		s.addCard(ctx, deckID)
+		return errors.New("abort")
+	})
+
+	// Assert
+	s.Require().EqualError(err, "abort")
+	_, err = s.repos.Decks.GetByID(ctx, deckID)
+	s.requireNotFound(err, "deck")
+}
+
+func (s *Suite) TestNestedTransactionRollsBackAlone() {
+	// Arrange
+	ctx := context.Background()
+	var outerID, innerID int64
+
+	// Act
+	err := s.repos.Tx.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		outerID = s.addDeck(ctx)
+
+		innerErr := s.repos.Tx.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+			innerID = s.addDeck(ctx)
+			return errors.New("abort inner")
+		})
+		s.Require().EqualError(innerErr, "abort inner")
+		return nil
+	})
+
+	// Assert
+	s.Require().NoError(err)
+	_, err = s.repos.Decks.GetByID(ctx, outerID)
+	s.Assert().NoError(err)
+	_, err = s.repos.Decks.GetByID(ctx, innerID)
+	s.requireNotFound(err, "deck")
+}
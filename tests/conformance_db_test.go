@@ -0,0 +1,38 @@
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"context"
+	"flash-card-manager/pkg/repository/postgresql"
+	"flash-card-manager/tests/conformance"
+	"flash-card-manager/tests/postgres"
+	"log"
+	"testing"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestPostgresConformance(t *testing.T) {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatalf("Error loading .env file: %v", err)
+	}
+
+	tdb := postgres.NewFromEnv()
+	suite.Run(t, &conformance.Suite{New: func(t *testing.T) conformance.Repositories {
+		require.NoError(t, tdb.SetUp(t))
+		t.Cleanup(func() { tdb.TearDown(context.Background(), t) })
+
+		return conformance.Repositories{
+			Cards: postgresql.NewCard(tdb.DB),
+			Decks: postgresql.NewDeck(tdb.DB),
+			Trash: postgresql.NewTrash(tdb.DB),
+			Usage: postgresql.NewUsage(tdb.DB),
+			Tx:    tdb.DB,
+		}
+	}})
+}
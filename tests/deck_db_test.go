@@ -7,10 +7,9 @@ import (
 	"context"
 	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/suite"
-	"homework-3/pkg/db"
-	"homework-3/pkg/repository/postgresql"
-	"homework-3/tests/fixtures"
-	"homework-3/tests/postgres"
+	"flash-card-manager/pkg/repository/postgresql"
+	"flash-card-manager/tests/fixtures"
+	"flash-card-manager/tests/postgres"
 	"log"
 	"testing"
 )
@@ -26,7 +25,7 @@ func (suite *DeckTestSuite) SetupTest() {
 		log.Fatalf("Error loading .env file: %v", err)
 	}
 
-	suite.DB = postgres.NewFromEnv(db.GenerateDsn())
+	suite.DB = postgres.NewFromEnv()
 	suite.Require().NoError(err)
 
 	err = suite.DB.SetUp(suite.T())
@@ -104,11 +103,11 @@ func (suite *DeckTestSuite) TestUpdateDeck() {
 	updatedDeck.Author = "Updated Author"
 
 	// Act
-	affectedRows, err := deckRepo.Update(ctx, updatedDeck)
+	version, err := deckRepo.Update(ctx, updatedDeck, updatedDeck.Author)
 
 	// Assert
 	suite.Require().NoError(err)
-	suite.Assert().Equal(int64(1), affectedRows)
+	suite.Assert().Equal(int64(2), version)
 
 	deckFromDB, err := deckRepo.GetByID(ctx, deckID)
 	suite.Require().NoError(err)
@@ -117,6 +116,34 @@ func (suite *DeckTestSuite) TestUpdateDeck() {
 	suite.Assert().Equal(updatedDeck.Author, deckFromDB.Author)
 }
 
+func (suite *DeckTestSuite) TestUpdateStaleVersion() {
+	// Arrange
+	ctx := context.Background()
+	deckRepo := postgresql.NewDeck(suite.DB.DB)
+	deckValid := fixtures.Deck().Valid().P()
+	deckID, err := deckRepo.Add(ctx, *deckValid)
+	suite.Require().NoError(err)
+
+	updatedDeck := *deckValid
+	updatedDeck.ID = deckID
+	updatedDeck.Version = 1
+	updatedDeck.Title = "First Title"
+	_, err = deckRepo.Update(ctx, updatedDeck, updatedDeck.Author)
+	suite.Require().NoError(err)
+
+	// Act
+	updatedDeck.Title = "Second Title"
+	_, err = deckRepo.Update(ctx, updatedDeck, updatedDeck.Author)
+
+	// Assert
+	suite.Require().EqualError(err, "version mismatch")
+
+	deckFromDB, err := deckRepo.GetByID(ctx, deckID)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("First Title", deckFromDB.Title)
+	suite.Assert().Equal(int64(2), deckFromDB.Version)
+}
+
 func TestDeckTestSuite(t *testing.T) {
 	suite.Run(t, new(DeckTestSuite))
 }
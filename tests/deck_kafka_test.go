@@ -6,12 +6,10 @@ package tests
 import (
 	"context"
 	"encoding/json"
-	"homework-3/internal/infrastructure/kafka"
-	"homework-3/pkg/db"
-	"homework-3/pkg/repository/postgresql"
-	"homework-3/pkg/repository/structs"
-	"homework-3/tests/fixtures"
-	"homework-3/tests/postgres"
+	"flash-card-manager/pkg/repository/postgresql"
+	"flash-card-manager/pkg/repository/structs"
+	"flash-card-manager/tests/fixtures"
+	"flash-card-manager/tests/postgres"
 	"log"
 	"testing"
 	"time"
@@ -36,11 +34,11 @@ func (suite *DeckKafkaTestSuite) SetupSuite() {
 	if err != nil {
 		log.Fatalf("Error loading .env file: %v", err)
 	}
-	suite.DB = postgres.NewFromEnv(db.GenerateDsn())
+	suite.DB = postgres.NewFromEnv()
 	err = suite.DB.SetUp(suite.T())
 	suite.Require().NoError(err)
 
-	kafkaAddress, err := kafka.LoadKafkaConfig()
+	kafkaAddress, err := kafkaBrokers()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -74,7 +72,7 @@ func (suite *DeckKafkaTestSuite) TearDownSuite() {
 }
 
 func (suite *DeckKafkaTestSuite) CleanupKafka() {
-	kafkaAddress, err := kafka.LoadKafkaConfig()
+	kafkaAddress, err := kafkaBrokers()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -147,7 +145,7 @@ func (suite *DeckKafkaTestSuite) TestKafkaUpdateDeck() {
 
 	// Act
 	deckID, err := deckRepo.Add(ctx, *deck)
-	deckID, err = deckRepo.Update(ctx, *deck)
+	deckID, err = deckRepo.Update(ctx, *deck, deck.Author)
 	suite.Require().NoError(err)
 
 	deck.ID = deckID
@@ -33,6 +33,11 @@ func (b *DeckBuilder) Author(v string) *DeckBuilder {
 	return b
 }
 
+func (b *DeckBuilder) Owner(v string) *DeckBuilder {
+	b.instance.Owner = v
+	return b
+}
+
 func (b *DeckBuilder) CreatedAt(v time.Time) *DeckBuilder {
 	b.instance.CreatedAt = v
 	return b
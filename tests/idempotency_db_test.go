@@ -0,0 +1,97 @@
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"context"
+	"flash-card-manager/pkg/repository/postgresql"
+	"flash-card-manager/pkg/repository/structs"
+	"flash-card-manager/tests/postgres"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+)
+
+type IdempotencyTestSuite struct {
+	suite.Suite
+	DB *postgres.TDB
+}
+
+func (suite *IdempotencyTestSuite) SetupTest() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatalf("Error loading .env file: %v", err)
+	}
+
+	suite.DB = postgres.NewFromEnv()
+
+	err = suite.DB.SetUp(suite.T())
+	suite.Require().NoError(err)
+}
+
+func (suite *IdempotencyTestSuite) TearDownTest() {
+	ctx := context.Background()
+	suite.DB.TearDown(ctx, suite.T())
+}
+
+func (suite *IdempotencyTestSuite) TestClaimCompleteAndReplay() {
+	// Arrange
+	ctx := context.Background()
+	repo := postgresql.NewIdempotency(suite.DB.DB)
+	record := structs.IdempotencyRecord{Key: "k", RequestHash: []byte{1}, ExpiresAt: time.Now().Add(time.Hour)}
+
+	// Act
+	held, err := repo.Claim(ctx, record)
+	suite.Require().NoError(err)
+	suite.Require().Nil(held)
+
+	inProgress, err := repo.Claim(ctx, record)
+	suite.Require().NoError(err)
+
+	completed := record
+	completed.Response, completed.Header = []byte{2}, []byte{3}
+	suite.Require().NoError(repo.Complete(ctx, completed))
+	replayed, err := repo.Claim(ctx, record)
+	suite.Require().NoError(err)
+
+	// Assert
+	suite.Require().NotNil(inProgress)
+	suite.Assert().Nil(inProgress.Response)
+	suite.Require().NotNil(replayed)
+	suite.Assert().Equal([]byte{1}, replayed.RequestHash)
+	suite.Assert().Equal([]byte{2}, replayed.Response)
+	suite.Assert().Equal([]byte{3}, replayed.Header)
+}
+
+func (suite *IdempotencyTestSuite) TestReleaseAndExpiry() {
+	// Arrange
+	ctx := context.Background()
+	repo := postgresql.NewIdempotency(suite.DB.DB)
+	released := structs.IdempotencyRecord{Key: "released", RequestHash: []byte{1}, ExpiresAt: time.Now().Add(time.Hour)}
+	expired := structs.IdempotencyRecord{Key: "expired", RequestHash: []byte{1}, ExpiresAt: time.Now().Add(-time.Second)}
+
+	// Act
+	_, err := repo.Claim(ctx, released)
+	suite.Require().NoError(err)
+	suite.Require().NoError(repo.Release(ctx, "released"))
+	reclaimed, err := repo.Claim(ctx, released)
+	suite.Require().NoError(err)
+
+	_, err = repo.Claim(ctx, expired)
+	suite.Require().NoError(err)
+	expired.ExpiresAt = time.Now().Add(time.Hour)
+	takenOver, err := repo.Claim(ctx, expired)
+	suite.Require().NoError(err)
+
+	// Assert
+	suite.Assert().Nil(reclaimed)
+	suite.Assert().Nil(takenOver)
+}
+
+func TestIdempotencyTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyTestSuite))
+}
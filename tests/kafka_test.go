@@ -6,9 +6,8 @@ package tests
 import (
 	"context"
 	"fmt"
-	"homework-3/internal/infrastructure/kafka"
-	"homework-3/pkg/db"
-	"homework-3/tests/postgres"
+	"flash-card-manager/internal/config"
+	"flash-card-manager/tests/postgres"
 	"log"
 	"testing"
 	"time"
@@ -31,11 +30,11 @@ func (suite *KafkaTestSuite) SetupSuite() {
 	if err != nil {
 		log.Fatalf("Error loading .env file: %v", err)
 	}
-	suite.DB = postgres.NewFromEnv(db.GenerateDsn())
+	suite.DB = postgres.NewFromEnv()
 	err = suite.DB.SetUp(suite.T())
 	suite.Require().NoError(err)
 
-	kafkaAddress, err := kafka.LoadKafkaConfig()
+	kafkaAddress, err := kafkaBrokers()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -69,7 +68,7 @@ func (suite *KafkaTestSuite) TearDownSuite() {
 }
 
 func (suite *KafkaTestSuite) CleanupKafka() {
-	kafkaAddress, err := kafka.LoadKafkaConfig()
+	kafkaAddress, err := kafkaBrokers()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -130,3 +129,13 @@ func (suite *KafkaTestSuite) TestKafkaIntegration() {
 func TestKafkaTestSuite(t *testing.T) {
 	suite.Run(t, new(KafkaTestSuite))
 }
+
+// kafkaBrokers are the brokers from KAFKA_BROKERS, or KAFKA_HOST and
+// KAFKA_PORT.
+func kafkaBrokers() ([]string, error) {
+	cfg, err := config.FromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Kafka.Brokers, nil
+}
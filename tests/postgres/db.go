@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"flash-card-manager/internal/config"
 	"flash-card-manager/pkg/db"
 	"log"
 	"strings"
@@ -10,11 +11,17 @@ import (
 )
 
 type TDB struct {
-	DB db.DatabaseInterface
+	DB db.PostgresInterface
 }
 
-func NewFromEnv(dsn string) *TDB {
-	db, err := db.NewDB(context.Background(), dsn)
+// NewFromEnv connects to the database the environment configures, through
+// DB_DSN or the older DBHOST, DBPORT, DBUSER, DBPASSWORD and DBNAME.
+func NewFromEnv() *TDB {
+	cfg, err := config.FromEnv()
+	if err != nil {
+		log.Fatalf("Failed to read the database configuration: %v", err)
+	}
+	db, err := db.NewDB(context.Background(), cfg.Storage.DSN)
 	if err != nil {
 		log.Fatalf("Failed to create database connection: %v", err)
 	}
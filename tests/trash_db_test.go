@@ -0,0 +1,132 @@
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"context"
+	"flash-card-manager/pkg/repository/postgresql"
+	"flash-card-manager/tests/fixtures"
+	"flash-card-manager/tests/postgres"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrashTestSuite struct {
+	suite.Suite
+	DB *postgres.TDB
+}
+
+func (suite *TrashTestSuite) SetupTest() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatalf("Error loading .env file: %v", err)
+	}
+
+	suite.DB = postgres.NewFromEnv()
+
+	err = suite.DB.SetUp(suite.T())
+	suite.Require().NoError(err)
+}
+
+func (suite *TrashTestSuite) TearDownTest() {
+	ctx := context.Background()
+	suite.DB.TearDown(ctx, suite.T())
+}
+
+func (suite *TrashTestSuite) TestRestoreDeckRestoresItsCards() {
+	// Arrange
+	ctx := context.Background()
+	deckRepo := postgresql.NewDeck(suite.DB.DB)
+	cardRepo := postgresql.NewCard(suite.DB.DB)
+	trashRepo := postgresql.NewTrash(suite.DB.DB)
+
+	deckID, err := deckRepo.Add(ctx, *fixtures.Deck().Valid().P())
+	suite.Require().NoError(err)
+	keptCardID, err := cardRepo.Add(ctx, *fixtures.Card().Valid().DeckID(deckID).P())
+	suite.Require().NoError(err)
+	trashedCardID, err := cardRepo.Add(ctx, *fixtures.Card().Valid().DeckID(deckID).P())
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(cardRepo.Delete(ctx, trashedCardID))
+	suite.Require().NoError(deckRepo.Delete(ctx, deckID))
+
+	_, err = cardRepo.GetByID(ctx, keptCardID)
+	suite.Require().Error(err)
+
+	// Act
+	restoredCards, err := trashRepo.RestoreDeck(ctx, deckID)
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Assert().Equal(int64(1), restoredCards)
+
+	_, err = deckRepo.GetByID(ctx, deckID)
+	suite.Assert().NoError(err)
+	_, err = cardRepo.GetByID(ctx, keptCardID)
+	suite.Assert().NoError(err)
+	_, err = cardRepo.GetByID(ctx, trashedCardID)
+	suite.Assert().Error(err)
+
+	trashedCards, err := trashRepo.ListCards(ctx, "")
+	suite.Require().NoError(err)
+	suite.Require().Len(trashedCards, 1)
+	suite.Assert().Equal(trashedCardID, trashedCards[0].ID)
+}
+
+func (suite *TrashTestSuite) TestRestoreCardOfTrashedDeck() {
+	// Arrange
+	ctx := context.Background()
+	deckRepo := postgresql.NewDeck(suite.DB.DB)
+	cardRepo := postgresql.NewCard(suite.DB.DB)
+	trashRepo := postgresql.NewTrash(suite.DB.DB)
+
+	deckID, err := deckRepo.Add(ctx, *fixtures.Deck().Valid().P())
+	suite.Require().NoError(err)
+	cardID, err := cardRepo.Add(ctx, *fixtures.Card().Valid().DeckID(deckID).P())
+	suite.Require().NoError(err)
+	suite.Require().NoError(deckRepo.Delete(ctx, deckID))
+
+	// Act
+	err = trashRepo.RestoreCard(ctx, cardID)
+
+	// Assert
+	suite.Assert().EqualError(err, "deck is in trash")
+}
+
+func (suite *TrashTestSuite) TestPurge() {
+	// Arrange
+	ctx := context.Background()
+	deckRepo := postgresql.NewDeck(suite.DB.DB)
+	cardRepo := postgresql.NewCard(suite.DB.DB)
+	trashRepo := postgresql.NewTrash(suite.DB.DB)
+
+	deckID, err := deckRepo.Add(ctx, *fixtures.Deck().Valid().P())
+	suite.Require().NoError(err)
+	_, err = cardRepo.Add(ctx, *fixtures.Card().Valid().DeckID(deckID).P())
+	suite.Require().NoError(err)
+	suite.Require().NoError(deckRepo.Delete(ctx, deckID))
+
+	// Act
+	decksBefore, cardsBefore, err := trashRepo.Purge(ctx, time.Now().Add(-time.Hour))
+	suite.Require().NoError(err)
+	decks, cards, err := trashRepo.Purge(ctx, time.Now().Add(time.Minute))
+
+	// Assert
+	suite.Require().NoError(err)
+	suite.Assert().Zero(decksBefore)
+	suite.Assert().Zero(cardsBefore)
+	suite.Assert().Equal(int64(1), decks)
+	suite.Assert().Equal(int64(1), cards)
+
+	_, err = trashRepo.RestoreDeck(ctx, deckID)
+	suite.Assert().Error(err)
+}
+
+func TestTrashTestSuite(t *testing.T) {
+	suite.Run(t, new(TrashTestSuite))
+}
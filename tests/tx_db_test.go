@@ -0,0 +1,93 @@
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"flash-card-manager/pkg/db"
+	"flash-card-manager/pkg/repository/postgresql"
+	"flash-card-manager/tests/fixtures"
+	"flash-card-manager/tests/postgres"
+	"log"
+	"testing"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/suite"
+)
+
+type TxTestSuite struct {
+	suite.Suite
+	DB *postgres.TDB
+}
+
+func (suite *TxTestSuite) SetupTest() {
+	err := godotenv.Load("../.env")
+	if err != nil {
+		log.Fatalf("Error loading .env file: %v", err)
+	}
+
+	suite.DB = postgres.NewFromEnv()
+
+	err = suite.DB.SetUp(suite.T())
+	suite.Require().NoError(err)
+}
+
+func (suite *TxTestSuite) TearDownTest() {
+	ctx := context.Background()
+	suite.DB.TearDown(ctx, suite.T())
+}
+
+func (suite *TxTestSuite) TestRollbackOnError() {
+	// Arrange
+	ctx := context.Background()
+	deckRepo := postgresql.NewDeck(suite.DB.DB)
+	var deckID int64
+
+	// Act
+	err := suite.DB.DB.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+		var err error
+		deckID, err = deckRepo.Add(ctx, *fixtures.Deck().Valid().P())
+		suite.Require().NoError(err)
+		return errors.New("abort")
+	})
+
+	// Assert
+	suite.Require().EqualError(err, "abort")
+	_, err = deckRepo.GetByID(ctx, deckID)
+	suite.Assert().Error(err)
+}
+
+func (suite *TxTestSuite) TestNestedCallUsesSavepoint() {
+	// Arrange
+	ctx := context.Background()
+	deckRepo := postgresql.NewDeck(suite.DB.DB)
+	var outerID, innerID int64
+
+	// Act
+	err := suite.DB.DB.RunInTx(ctx, db.TxOptions{IsoLevel: db.Serializable}, func(ctx context.Context) error {
+		var err error
+		outerID, err = deckRepo.Add(ctx, *fixtures.Deck().Valid().P())
+		suite.Require().NoError(err)
+
+		innerErr := suite.DB.DB.RunInTx(ctx, db.TxOptions{}, func(ctx context.Context) error {
+			innerID, err = deckRepo.Add(ctx, *fixtures.Deck().Valid().P())
+			suite.Require().NoError(err)
+			return errors.New("abort inner")
+		})
+		suite.Require().EqualError(innerErr, "abort inner")
+		return nil
+	})
+
+	// Assert
+	suite.Require().NoError(err)
+	_, err = deckRepo.GetByID(ctx, outerID)
+	suite.Assert().NoError(err)
+	_, err = deckRepo.GetByID(ctx, innerID)
+	suite.Assert().Error(err)
+}
+
+func TestTxTestSuite(t *testing.T) {
+	suite.Run(t, new(TxTestSuite))
+}